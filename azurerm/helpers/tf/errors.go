@@ -1,9 +1,40 @@
 package tf
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/apierrors"
+)
 
 // todo this should be moved to internal somewhere?
 func ImportAsExistsError(resourceName, id string) error {
 	msg := "A resource with the ID %q already exists - to be managed via Terraform this resource needs to be imported into the State. Please see the resource documentation for %q for more information."
 	return fmt.Errorf(msg, id, resourceName)
 }
+
+// AzureAPIError returns a concise error for an error returned from the Azure API (for example when the
+// request was disallowed by a Policy, or would exceed a Quota) - retaining any context added to the error.
+// Errors which don't contain an Azure API error are returned as-is.
+func AzureAPIError(err error) error {
+	return apierrors.Format(err)
+}
+
+// WithAzureAPIErrors wraps the CRUD functions for the specified (untyped) Resource or Data Source, such that any errors
+// returned from the Azure API are returned using AzureAPIError
+func WithAzureAPIErrors(resource *schema.Resource) {
+	var wrap = func(f func(d *schema.ResourceData, meta interface{}) error) func(d *schema.ResourceData, meta interface{}) error {
+		if f == nil {
+			return nil
+		}
+
+		return func(d *schema.ResourceData, meta interface{}) error {
+			return AzureAPIError(f(d, meta))
+		}
+	}
+
+	resource.Create = wrap(resource.Create)
+	resource.Read = wrap(resource.Read)
+	resource.Update = wrap(resource.Update)
+	resource.Delete = wrap(resource.Delete)
+}
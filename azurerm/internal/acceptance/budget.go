@@ -0,0 +1,158 @@
+package acceptance
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// resourceBudgetEnvVar is the Environment Variable used to specify the total number of slots
+	// available in the Resource Budget, shared across all Test processes on this machine
+	resourceBudgetEnvVar = "ARM_TEST_RESOURCE_BUDGET"
+
+	// resourceBudgetDirectoryEnvVar is the Environment Variable used to (optionally) override the
+	// directory used to store the lock files for the Resource Budget
+	resourceBudgetDirectoryEnvVar = "ARM_TEST_RESOURCE_BUDGET_DIRECTORY"
+)
+
+// resourceBudget limits the number of quota-heavy tests which can run concurrently, across all of the
+// Test processes on this machine. Each slot in the budget is represented by a lock file within a directory,
+// which is created exclusively when the slot is acquired and removed when it's released.
+type resourceBudget struct {
+	// directory is the directory containing the lock files for each slot
+	directory string
+
+	// slots is the total number of slots available in the budget
+	slots int
+
+	// pollInterval is how long to wait before trying to acquire slots again
+	pollInterval time.Duration
+
+	// staleAfter is how long a lock file can exist before it's assumed that the process holding it
+	// has crashed, meaning that the lock can be removed
+	staleAfter time.Duration
+}
+
+// resourceBudgetFromEnvironment returns the Resource Budget configured via Environment Variables
+// or nil if a Resource Budget hasn't been configured
+func resourceBudgetFromEnvironment() (*resourceBudget, error) {
+	v := os.Getenv(resourceBudgetEnvVar)
+	if v == "" {
+		return nil, nil
+	}
+
+	slots, err := strconv.Atoi(v)
+	if err != nil || slots < 1 {
+		return nil, fmt.Errorf("`%s` must be a positive integer but got %q", resourceBudgetEnvVar, v)
+	}
+
+	directory := os.Getenv(resourceBudgetDirectoryEnvVar)
+	if directory == "" {
+		directory = filepath.Join(os.TempDir(), "terraform-provider-azurerm-resource-budget")
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, fmt.Errorf("creating the Resource Budget directory %q: %+v", directory, err)
+	}
+
+	return &resourceBudget{
+		directory:    directory,
+		slots:        slots,
+		pollInterval: 10 * time.Second,
+		// the acceptance tests have a timeout of 180m - so any lock older than this is stale
+		staleAfter: 4 * time.Hour,
+	}, nil
+}
+
+// acquire blocks until `cost` slots are available in the Resource Budget, returning a function
+// which releases them. Slots are acquired all-or-nothing, so that tests waiting on the budget
+// don't hold partial allocations (which could otherwise deadlock).
+func (b resourceBudget) acquire(testName string, cost int) (func(), error) {
+	if cost > b.slots {
+		log.Printf("[DEBUG] %q has a cost of %d which exceeds the Resource Budget of %d - using the entire budget", testName, cost, b.slots)
+		cost = b.slots
+	}
+
+	for {
+		locks, err := b.tryAcquire(testName, cost)
+		if err != nil {
+			return nil, err
+		}
+
+		if locks != nil {
+			return func() {
+				for _, lock := range locks {
+					if err := os.Remove(lock); err != nil && !os.IsNotExist(err) {
+						log.Printf("[DEBUG] Error releasing Resource Budget slot %q: %+v", lock, err)
+					}
+				}
+			}, nil
+		}
+
+		// add some jitter to avoid all the waiting tests retrying at the same time
+		wait := b.pollInterval + time.Duration(rand.Int63n(int64(b.pollInterval)/2+1))
+		log.Printf("[DEBUG] Waiting %s for %d slot(s) in the Resource Budget for %q..", wait, cost, testName)
+		time.Sleep(wait)
+	}
+}
+
+// tryAcquire attempts to acquire `cost` slots from the Resource Budget, returning the lock files
+// if these were acquired - or nil if there's insufficient capacity
+func (b resourceBudget) tryAcquire(testName string, cost int) ([]string, error) {
+	acquired := make([]string, 0)
+	release := func() {
+		for _, lock := range acquired {
+			_ = os.Remove(lock)
+		}
+	}
+
+	for i := 0; i < b.slots && len(acquired) < cost; i++ {
+		lock := filepath.Join(b.directory, fmt.Sprintf("slot-%d.lock", i))
+		b.removeIfStale(lock)
+
+		file, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err != nil {
+			if os.IsExist(err) {
+				continue
+			}
+
+			release()
+			return nil, fmt.Errorf("acquiring Resource Budget slot %q: %+v", lock, err)
+		}
+
+		_, err = file.WriteString(fmt.Sprintf("%s\n%d\n", testName, os.Getpid()))
+		file.Close()
+		acquired = append(acquired, lock)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("writing Resource Budget slot %q: %+v", lock, err)
+		}
+	}
+
+	if len(acquired) < cost {
+		release()
+		return nil, nil
+	}
+
+	return acquired, nil
+}
+
+func (b resourceBudget) removeIfStale(lock string) {
+	info, err := os.Stat(lock)
+	if err != nil || time.Since(info.ModTime()) < b.staleAfter {
+		return
+	}
+
+	holder := ""
+	if contents, err := ioutil.ReadFile(lock); err == nil {
+		holder = strings.Split(string(contents), "\n")[0]
+	}
+	log.Printf("[DEBUG] Removing stale Resource Budget slot %q (held by %q since %s)", lock, holder, info.ModTime())
+	_ = os.Remove(lock)
+}
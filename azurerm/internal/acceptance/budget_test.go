@@ -0,0 +1,207 @@
+package acceptance
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/types"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+)
+
+func TestResourceBudgetAcquire(t *testing.T) {
+	directory, err := ioutil.TempDir("", "resource-budget")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(directory)
+
+	budget := resourceBudget{
+		directory:    directory,
+		slots:        3,
+		pollInterval: time.Millisecond,
+		staleAfter:   time.Hour,
+	}
+
+	first, err := budget.tryAcquire("first", 2)
+	if err != nil {
+		t.Fatalf("acquiring first: %+v", err)
+	}
+	if len(first) != 2 {
+		t.Fatalf("Expected 2 slots to be acquired but got %d", len(first))
+	}
+
+	// only a single slot remains - so this should fail without holding any slots
+	second, err := budget.tryAcquire("second", 2)
+	if err != nil {
+		t.Fatalf("acquiring second: %+v", err)
+	}
+	if second != nil {
+		t.Fatalf("Expected no slots to be acquired but got %+v", second)
+	}
+
+	third, err := budget.tryAcquire("third", 1)
+	if err != nil {
+		t.Fatalf("acquiring third: %+v", err)
+	}
+	if len(third) != 1 {
+		t.Fatalf("Expected 1 slot to be acquired but got %d", len(third))
+	}
+
+	// releasing the first allocation should allow the second to acquire it
+	for _, lock := range first {
+		if err := os.Remove(lock); err != nil {
+			t.Fatalf("releasing %q: %+v", lock, err)
+		}
+	}
+	release, err := budget.acquire("second", 2)
+	if err != nil {
+		t.Fatalf("acquiring second: %+v", err)
+	}
+	release()
+
+	files, err := filepath.Glob(filepath.Join(directory, "*.lock"))
+	if err != nil {
+		t.Fatalf("listing lock files: %+v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 lock file to remain but got %+v", files)
+	}
+}
+
+func TestResourceBudgetStaleLocks(t *testing.T) {
+	directory, err := ioutil.TempDir("", "resource-budget")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(directory)
+
+	budget := resourceBudget{
+		directory:    directory,
+		slots:        1,
+		pollInterval: time.Millisecond,
+		staleAfter:   time.Hour,
+	}
+
+	lock := filepath.Join(directory, "slot-0.lock")
+	if err := ioutil.WriteFile(lock, []byte("crashed\n1\n"), 0644); err != nil {
+		t.Fatalf("writing lock: %+v", err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(lock, old, old); err != nil {
+		t.Fatalf("updating lock: %+v", err)
+	}
+
+	locks, err := budget.tryAcquire("test", 1)
+	if err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	if len(locks) != 1 {
+		t.Fatalf("Expected the stale lock to be replaced but got %+v", locks)
+	}
+}
+
+func TestResourceBudgetFromEnvironment(t *testing.T) {
+	defer os.Unsetenv(resourceBudgetEnvVar)
+
+	testData := []struct {
+		value       string
+		expectNil   bool
+		expectError bool
+	}{
+		{
+			value:     "",
+			expectNil: true,
+		},
+		{
+			value:       "pandas",
+			expectError: true,
+		},
+		{
+			value:       "0",
+			expectError: true,
+		},
+		{
+			value: "5",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.value)
+
+		os.Setenv(resourceBudgetEnvVar, v.value)
+		budget, err := resourceBudgetFromEnvironment()
+		if v.expectError {
+			if err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+		if (budget == nil) != v.expectNil {
+			t.Fatalf("Expected the budget to be nil (%t) but got %+v", v.expectNil, budget)
+		}
+	}
+}
+
+type freeTestResource struct{}
+
+func (freeTestResource) Exists(_ context.Context, _ *clients.Client, _ *terraform.InstanceState) (*bool, error) {
+	return nil, nil
+}
+
+type expensiveTestResource struct {
+	freeTestResource
+}
+
+func (expensiveTestResource) ResourceCost() int {
+	return 4
+}
+
+func TestResourceBudgetCostFor(t *testing.T) {
+	testData := []struct {
+		name     string
+		data     TestData
+		resource types.TestResource
+		expected int
+	}{
+		{
+			name:     "data source",
+			data:     TestData{},
+			expected: 0,
+		},
+		{
+			name:     "resource without a cost",
+			data:     TestData{},
+			resource: freeTestResource{},
+			expected: 0,
+		},
+		{
+			name:     "resource with a cost",
+			data:     TestData{},
+			resource: expensiveTestResource{},
+			expected: 4,
+		},
+		{
+			name:     "cost overridden in the test",
+			data:     TestData{}.WithResourceCost(2),
+			resource: expensiveTestResource{},
+			expected: 2,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := v.data.costFor(v.resource)
+		if actual != v.expected {
+			t.Fatalf("Expected a cost of %d but got %d", v.expected, actual)
+		}
+	}
+}
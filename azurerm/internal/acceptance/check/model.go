@@ -0,0 +1,309 @@
+package check
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/provider"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+)
+
+// ModelMatcher is a function which asserts on the value of a field within a Model,
+// returning an error if the value doesn't match
+type ModelMatcher func(value interface{}) error
+
+type thatModelType struct {
+	// resourceName being the full resource name e.g. azurerm_foo.bar
+	resourceName string
+
+	// path is the path to the nested block within the Model being asserted on e.g. `rule.0`
+	path string
+
+	// ignoredFields is a list of fields (by their `tfschema` path, e.g. `rule.priority`) which are ignored
+	ignoredFields map[string]struct{}
+
+	// matchers is a map of fields (by their `tfschema` path, e.g. `rule.priority`) to a custom matcher
+	matchers map[string]ModelMatcher
+}
+
+// Model returns a type which can be used to assert on the Model for a Typed Resource
+// (or Data Source), decoded from the Terraform State
+func (t thatType) Model() thatModelType {
+	return thatModelType{
+		resourceName:  t.resourceName,
+		ignoredFields: make(map[string]struct{}),
+		matchers:      make(map[string]ModelMatcher),
+	}
+}
+
+// Block returns a type which can be used to assert on a nested block within the Model,
+// specified by the `tfschema` path, for example `rule.0`
+func (t thatModelType) Block(path string) thatModelType {
+	t.path = path
+	return t
+}
+
+// IgnoringFields returns a type which ignores the specified fields (by their `tfschema` path,
+// relative to the Model or Block being asserted on, e.g. `rule.priority`) when comparing
+func (t thatModelType) IgnoringFields(fields ...string) thatModelType {
+	ignored := make(map[string]struct{})
+	for k := range t.ignoredFields {
+		ignored[k] = struct{}{}
+	}
+	for _, field := range fields {
+		ignored[field] = struct{}{}
+	}
+	t.ignoredFields = ignored
+	return t
+}
+
+// WithMatcher returns a type which uses the specified ModelMatcher to assert on the specified
+// field (by its `tfschema` path, relative to the Model or Block being asserted on) rather than
+// comparing it to the expected value
+func (t thatModelType) WithMatcher(field string, matcher ModelMatcher) thatModelType {
+	matchers := make(map[string]ModelMatcher)
+	for k, v := range t.matchers {
+		matchers[k] = v
+	}
+	matchers[field] = matcher
+	t.matchers = matchers
+	return t
+}
+
+// Matches returns a TestCheckFunc which decodes the Terraform State for this Resource into
+// its Model and then validates that it (or the Block being asserted on) matches the expected value
+func (t thatModelType) Matches(expected interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[t.resourceName]
+		if !ok {
+			return fmt.Errorf("%q was not found in the state", t.resourceName)
+		}
+
+		model, err := decodeModelForResource(t.resourceName, rs.Primary)
+		if err != nil {
+			return err
+		}
+
+		actual, err := valueAtPath(reflect.ValueOf(model), t.path)
+		if err != nil {
+			return fmt.Errorf("locating %q within the Model for %q: %+v", t.path, t.resourceName, err)
+		}
+
+		differences := compareModels(t.path, expected, actual.Interface(), t.ignoredFields, t.matchers)
+		if len(differences) > 0 {
+			return fmt.Errorf("the Model for %q didn't match:\n\n%s", t.resourceName, strings.Join(differences, "\n"))
+		}
+
+		return nil
+	}
+}
+
+// MatchesRegex returns a ModelMatcher which validates that the value matches the given regular expression
+func MatchesRegex(r *regexp.Regexp) ModelMatcher {
+	return func(value interface{}) error {
+		v := fmt.Sprintf("%v", value)
+		if !r.MatchString(v) {
+			return fmt.Errorf("%q didn't match the regular expression %q", v, r.String())
+		}
+		return nil
+	}
+}
+
+// IsNotEmpty returns a ModelMatcher which validates that the value isn't the zero value for its type
+func IsNotEmpty() ModelMatcher {
+	return func(value interface{}) error {
+		v := reflect.ValueOf(value)
+		if !v.IsValid() || v.IsZero() || ((v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0) {
+			return fmt.Errorf("expected a value but it was empty")
+		}
+		return nil
+	}
+}
+
+// HasLength returns a ModelMatcher which validates that the list/map/string contains the specified number of items
+func HasLength(length int) ModelMatcher {
+	return func(value interface{}) error {
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.Slice, reflect.Map, reflect.String:
+			if v.Len() != length {
+				return fmt.Errorf("expected a length of %d but got %d", length, v.Len())
+			}
+			return nil
+		}
+		return fmt.Errorf("expected a list, map or string but got %T", value)
+	}
+}
+
+func decodeModelForResource(resourceName string, state *terraform.InstanceState) (interface{}, error) {
+	resourceType := strings.Split(strings.TrimPrefix(resourceName, "data."), ".")[0]
+	isDataSource := strings.HasPrefix(resourceName, "data.")
+
+	for _, service := range provider.SupportedTypedServices() {
+		if isDataSource {
+			for _, ds := range service.DataSources() {
+				if ds.ResourceType() == resourceType {
+					return sdk.DecodeInstanceState(state, ds.Arguments(), ds.Attributes(), ds.ModelObject())
+				}
+			}
+			continue
+		}
+
+		for _, r := range service.Resources() {
+			if r.ResourceType() == resourceType {
+				return sdk.DecodeInstanceState(state, r.Arguments(), r.Attributes(), r.ModelObject())
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%q isn't a Typed Resource or Data Source", resourceType)
+}
+
+// valueAtPath returns the value at the specified `tfschema` path (e.g. `rule.0.name`) within the Model
+func valueAtPath(input reflect.Value, path string) (reflect.Value, error) {
+	value := reflect.Indirect(input)
+	if path == "" {
+		return value, nil
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		value = reflect.Indirect(value)
+		switch value.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByTFSchemaName(value, segment)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("the field %q was not found", segment)
+			}
+			value = field
+
+		case reflect.Slice:
+			index, err := strconv.Atoi(segment)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("expected an index for %q but got %q", path, segment)
+			}
+			if index < 0 || index >= value.Len() {
+				return reflect.Value{}, fmt.Errorf("index %d was out of range (length %d)", index, value.Len())
+			}
+			value = value.Index(index)
+
+		default:
+			return reflect.Value{}, fmt.Errorf("unable to locate %q within a %s", segment, value.Kind())
+		}
+	}
+
+	return value, nil
+}
+
+func fieldByTFSchemaName(input reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < input.NumField(); i++ {
+		if tag, ok := input.Type().Field(i).Tag.Lookup("tfschema"); ok && tag == name {
+			return input.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// compareModels compares the expected and actual values, returning a list of the differences found
+func compareModels(path string, expected, actual interface{}, ignoredFields map[string]struct{}, matchers map[string]ModelMatcher) []string {
+	differences := make([]string, 0)
+	compareValues(path, "", reflect.ValueOf(expected), reflect.ValueOf(actual), ignoredFields, matchers, &differences)
+	return differences
+}
+
+// compareValues compares two values - where `path` is the full path (including indexes) used in any output
+// and `fieldPath` is the path (excluding indexes) used to look up any ignored fields and matchers
+func compareValues(path, fieldPath string, expected, actual reflect.Value, ignoredFields map[string]struct{}, matchers map[string]ModelMatcher, differences *[]string) {
+	if _, ignored := ignoredFields[fieldPath]; ignored && fieldPath != "" {
+		return
+	}
+
+	displayPath := path
+	if displayPath == "" {
+		displayPath = "(root)"
+	}
+
+	if matcher, ok := matchers[fieldPath]; ok && fieldPath != "" {
+		var value interface{}
+		if actual.IsValid() {
+			value = actual.Interface()
+		}
+		if err := matcher(value); err != nil {
+			*differences = append(*differences, fmt.Sprintf("%s: %+v", displayPath, err))
+		}
+		return
+	}
+
+	expected = reflect.Indirect(expected)
+	actual = reflect.Indirect(actual)
+	if !expected.IsValid() || !actual.IsValid() {
+		if expected.IsValid() != actual.IsValid() {
+			*differences = append(*differences, fmt.Sprintf("%s: expected %s but got %s", displayPath, formatValue(expected), formatValue(actual)))
+		}
+		return
+	}
+
+	if expected.Type() != actual.Type() {
+		*differences = append(*differences, fmt.Sprintf("%s: expected a %s but got a %s", displayPath, expected.Type(), actual.Type()))
+		return
+	}
+
+	switch expected.Kind() {
+	case reflect.Struct:
+		for i := 0; i < expected.NumField(); i++ {
+			name, ok := expected.Type().Field(i).Tag.Lookup("tfschema")
+			if !ok {
+				continue
+			}
+			compareValues(joinPath(path, name), joinPath(fieldPath, name), expected.Field(i), actual.Field(i), ignoredFields, matchers, differences)
+		}
+
+	case reflect.Slice:
+		if expected.Len() != actual.Len() {
+			*differences = append(*differences, fmt.Sprintf("%s: expected %d items but got %d", displayPath, expected.Len(), actual.Len()))
+			return
+		}
+		for i := 0; i < expected.Len(); i++ {
+			compareValues(joinPath(path, strconv.Itoa(i)), fieldPath, expected.Index(i), actual.Index(i), ignoredFields, matchers, differences)
+		}
+
+	case reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, key := range append(expected.MapKeys(), actual.MapKeys()...) {
+			keys[fmt.Sprintf("%v", key.Interface())] = key
+		}
+		names := make([]string, 0)
+		for k := range keys {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			key := keys[name]
+			compareValues(joinPath(path, name), joinPath(fieldPath, name), expected.MapIndex(key), actual.MapIndex(key), ignoredFields, matchers, differences)
+		}
+
+	default:
+		if !reflect.DeepEqual(expected.Interface(), actual.Interface()) {
+			*differences = append(*differences, fmt.Sprintf("%s: expected %s but got %s", displayPath, formatValue(expected), formatValue(actual)))
+		}
+	}
+}
+
+func joinPath(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return fmt.Sprintf("%s.%s", parent, child)
+}
+
+func formatValue(input reflect.Value) string {
+	if !input.IsValid() {
+		return "(not set)"
+	}
+	return fmt.Sprintf("%#v", input.Interface())
+}
@@ -0,0 +1,161 @@
+package check
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+type modelTestRule struct {
+	Name     string `tfschema:"name"`
+	Priority int    `tfschema:"priority"`
+}
+
+type modelTestModel struct {
+	Name  string            `tfschema:"name"`
+	Id    string            `tfschema:"resource_id"`
+	Rules []modelTestRule   `tfschema:"rule"`
+	Tags  map[string]string `tfschema:"tags"`
+}
+
+func TestCompareModels(t *testing.T) {
+	actual := modelTestModel{
+		Name: "example",
+		Id:   "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+		Rules: []modelTestRule{
+			{
+				Name:     "first",
+				Priority: 100,
+			},
+			{
+				Name:     "second",
+				Priority: 200,
+			},
+		},
+		Tags: map[string]string{
+			"hello": "world",
+		},
+	}
+
+	testData := []struct {
+		name     string
+		expected interface{}
+		ignored  []string
+		matchers map[string]ModelMatcher
+		output   []string
+	}{
+		{
+			name: "matches",
+			expected: modelTestModel{
+				Name: "example",
+				Id:   "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+				Rules: []modelTestRule{
+					{
+						Name:     "first",
+						Priority: 100,
+					},
+					{
+						Name:     "second",
+						Priority: 200,
+					},
+				},
+				Tags: map[string]string{
+					"hello": "world",
+				},
+			},
+			output: []string{},
+		},
+		{
+			name: "ignored and matched fields",
+			expected: modelTestModel{
+				Name: "example",
+				Rules: []modelTestRule{
+					{
+						Name: "first",
+					},
+					{
+						Name: "second",
+					},
+				},
+			},
+			ignored: []string{"rule.priority", "tags"},
+			matchers: map[string]ModelMatcher{
+				"resource_id": MatchesRegex(regexp.MustCompile("^/subscriptions/")),
+			},
+			output: []string{},
+		},
+		{
+			name: "differences",
+			expected: modelTestModel{
+				Name: "other",
+				Rules: []modelTestRule{
+					{
+						Name:     "first",
+						Priority: 101,
+					},
+				},
+				Tags: map[string]string{
+					"hello": "there",
+					"other": "value",
+				},
+			},
+			matchers: map[string]ModelMatcher{
+				"resource_id": HasLength(1),
+			},
+			output: []string{
+				`name: expected "other" but got "example"`,
+				`resource_id: expected a length of 1 but got 74`,
+				`rule: expected 1 items but got 2`,
+				`tags.hello: expected "there" but got "world"`,
+				`tags.other: expected "value" but got (not set)`,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		ignored := make(map[string]struct{})
+		for _, field := range v.ignored {
+			ignored[field] = struct{}{}
+		}
+		matchers := v.matchers
+		if matchers == nil {
+			matchers = make(map[string]ModelMatcher)
+		}
+
+		output := compareModels("", v.expected, actual, ignored, matchers)
+		if !reflect.DeepEqual(output, v.output) {
+			t.Fatalf("Expected %+v but got %+v", v.output, output)
+		}
+	}
+}
+
+func TestValueAtPath(t *testing.T) {
+	model := &modelTestModel{
+		Rules: []modelTestRule{
+			{
+				Name: "first",
+			},
+			{
+				Name: "second",
+			},
+		},
+	}
+
+	value, err := valueAtPath(reflect.ValueOf(model), "rule.1")
+	if err != nil {
+		t.Fatalf("locating value: %+v", err)
+	}
+	output := compareModels("rule.1", modelTestRule{Name: "second"}, value.Interface(), map[string]struct{}{}, map[string]ModelMatcher{})
+	if len(output) > 0 {
+		t.Fatalf("Expected no differences but got %+v", output)
+	}
+
+	if _, err := valueAtPath(reflect.ValueOf(model), "rule.2"); err == nil {
+		t.Fatalf("Expected an error for an out of range index but didn't get one")
+	}
+	if _, err := valueAtPath(reflect.ValueOf(model), "missing"); err == nil {
+		t.Fatalf("Expected an error for a missing field but didn't get one")
+	}
+}
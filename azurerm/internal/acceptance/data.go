@@ -51,10 +51,22 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// resourceCost is the number of slots from the Resource Budget this test consumes
+	// when set, this overrides the cost defined on the TestResource
+	resourceCost int
 }
 
 // BuildTestData generates some test data for the given resource
 func BuildTestData(t *testing.T, resourceType string, resourceLabel string) TestData {
+	shard, err := testShardFromEnvironment()
+	if err != nil {
+		t.Fatalf("Error determining the Test Shard: %+v", err)
+	}
+	if shard != nil && !shard.includesTest(t.Name()) {
+		t.Skipf("Skipping since %q isn't within Test Shard %d of %d", t.Name(), shard.index, shard.count)
+	}
+
 	EnsureProvidersAreInitialised()
 
 	env, err := Environment()
@@ -87,6 +99,13 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 	return testData
 }
 
+// WithResourceCost returns a copy of this Test Data which consumes the specified number of slots
+// from the Resource Budget (when configured) - overriding any cost defined on the TestResource
+func (td TestData) WithResourceCost(cost int) TestData {
+	td.resourceCost = cost
+	return td
+}
+
 // RandomIntOfLength is a random 8 to 18 digit integer which is unique to this test case
 func (td *TestData) RandomIntOfLength(len int) int {
 	// len should not be
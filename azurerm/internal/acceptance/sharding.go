@@ -0,0 +1,58 @@
+package acceptance
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// shardCountEnvVar is the Environment Variable used to specify the number of workers the tests are split across
+	shardCountEnvVar = "ARM_TEST_SHARD_COUNT"
+
+	// shardIndexEnvVar is the Environment Variable used to specify the (zero-based) index of this worker
+	shardIndexEnvVar = "ARM_TEST_SHARD_INDEX"
+)
+
+// testShard is a deterministic subset of the tests, allowing the tests to be split across multiple workers
+type testShard struct {
+	count int
+	index int
+}
+
+// testShardFromEnvironment returns the Test Shard configured via Environment Variables
+// or nil if sharding hasn't been configured
+func testShardFromEnvironment() (*testShard, error) {
+	countRaw := os.Getenv(shardCountEnvVar)
+	indexRaw := os.Getenv(shardIndexEnvVar)
+	if countRaw == "" && indexRaw == "" {
+		return nil, nil
+	}
+
+	count, err := strconv.Atoi(countRaw)
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("`%s` must be a positive integer but got %q", shardCountEnvVar, countRaw)
+	}
+
+	index, err := strconv.Atoi(indexRaw)
+	if err != nil || index < 0 || index >= count {
+		return nil, fmt.Errorf("`%s` must be an integer between 0 and %d but got %q", shardIndexEnvVar, count-1, indexRaw)
+	}
+
+	return &testShard{
+		count: count,
+		index: index,
+	}, nil
+}
+
+// includesTest returns whether the specified test should be run within this shard - which is determined
+// from the top-level test name, so that tests run in sequence (as sub-tests) remain on the same worker
+func (s testShard) includesTest(testName string) bool {
+	topLevelName := strings.Split(testName, "/")[0]
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(topLevelName))
+	return int(hash.Sum32()%uint32(s.count)) == s.index
+}
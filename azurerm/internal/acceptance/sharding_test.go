@@ -0,0 +1,34 @@
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTestShardIncludesTest(t *testing.T) {
+	shards := []testShard{
+		{count: 3, index: 0},
+		{count: 3, index: 1},
+		{count: 3, index: 2},
+	}
+
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("TestAccExample_test%d", i)
+
+		matches := 0
+		for _, shard := range shards {
+			if shard.includesTest(name) {
+				matches++
+			}
+
+			// sub-tests must run within the same shard as their parent
+			if shard.includesTest(name) != shard.includesTest(name+"/subtest") {
+				t.Fatalf("Expected %q and its sub-tests to be in the same shard", name)
+			}
+		}
+
+		if matches != 1 {
+			t.Fatalf("Expected %q to be in exactly 1 shard but was in %d", name, matches)
+		}
+	}
+}
@@ -6,11 +6,15 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/helpers"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/testclient"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/types"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/provider"
+	azSchema "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
 type DisappearsStepData struct {
@@ -109,6 +113,65 @@ func (td TestData) ImportStepFor(resourceName string, ignore ...string) resource
 	return step
 }
 
+// ImportStepFromSchema returns a Test Step which Imports the Resource, ignoring any fields
+// which can't be verified based on the Schema for this Resource (that is, fields annotated as
+// not being returned from the API) - in addition to any other fields specified
+func (td TestData) ImportStepFromSchema(ignore ...string) resource.TestStep {
+	return td.ImportStepFromSchemaFor(td.ResourceName, ignore...)
+}
+
+// ImportStepFromSchemaFor returns a Test Step which Imports a given resource by name, ignoring
+// any fields which can't be verified based on the Schema for this Resource - in addition
+// to any other fields specified
+func (td TestData) ImportStepFromSchemaFor(resourceName string, ignore ...string) resource.TestStep {
+	if strings.HasPrefix(resourceName, "data.") {
+		return td.ImportStepFor(resourceName)
+	}
+
+	resourceType := strings.Split(resourceName, ".")[0]
+
+	fields, err := importVerifyIgnoreFieldsForResourceType(resourceType)
+	if err != nil {
+		return resource.TestStep{
+			ResourceName: resourceName,
+			SkipFunc: func() (bool, error) {
+				return false, err
+			},
+		}
+	}
+
+	for _, v := range ignore {
+		if !utils.SliceContainsValue(fields, v) {
+			fields = append(fields, v)
+		}
+	}
+
+	return td.ImportStepFor(resourceName, fields...)
+}
+
+func importVerifyIgnoreFieldsForResourceType(resourceType string) ([]string, error) {
+	p, ok := provider.TestAzureProvider().(*schema.Provider)
+	if !ok {
+		return nil, fmt.Errorf("unable to determine the Provider Schema")
+	}
+
+	r, ok := p.ResourcesMap[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("the Resource %q was not found in the Provider Schema", resourceType)
+	}
+
+	if r.Importer == nil {
+		return nil, fmt.Errorf("the Resource %q doesn't support Import - remove the ImportStepFromSchema / ImportStepFromSchemaFor", resourceType)
+	}
+
+	fields, err := azSchema.ImportVerifyIgnoreFields(r.Schema, provider.FieldsNotReturnedByAPI()[resourceType])
+	if err != nil {
+		return nil, fmt.Errorf("determining the fields to ignore for the Resource %q: %+v", resourceType, err)
+	}
+
+	return fields, nil
+}
+
 // RequiresImportErrorStep returns a Test Step which expects a Requires Import
 // error to be returned when running this step
 func (td TestData) RequiresImportErrorStep(configBuilder func(data TestData) string) resource.TestStep {
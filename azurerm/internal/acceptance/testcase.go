@@ -2,6 +2,7 @@ package acceptance
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -21,7 +22,7 @@ func (td TestData) DataSourceTest(t *testing.T, steps []resource.TestStep) {
 		PreCheck: func() { PreCheck(t) },
 		Steps:    steps,
 	}
-	td.runAcceptanceTest(t, testCase)
+	td.runAcceptanceTest(t, testCase, td.costFor(nil))
 }
 
 // lintignore:AT001
@@ -33,7 +34,7 @@ func (td TestData) DataSourceTestInSequence(t *testing.T, steps []resource.TestS
 		Steps:    steps,
 	}
 
-	td.runAcceptanceSequentialTest(t, testCase)
+	td.runAcceptanceSequentialTest(t, testCase, td.costFor(nil))
 }
 
 func (td TestData) ResourceTest(t *testing.T, testResource types.TestResource, steps []resource.TestStep) {
@@ -48,7 +49,7 @@ func (td TestData) ResourceTest(t *testing.T, testResource types.TestResource, s
 		},
 		Steps: steps,
 	}
-	td.runAcceptanceTest(t, testCase)
+	td.runAcceptanceTest(t, testCase, td.costFor(testResource))
 }
 
 func (td TestData) ResourceSequentialTest(t *testing.T, testResource types.TestResource, steps []resource.TestStep) {
@@ -64,7 +65,7 @@ func (td TestData) ResourceSequentialTest(t *testing.T, testResource types.TestR
 		Steps: steps,
 	}
 
-	td.runAcceptanceSequentialTest(t, testCase)
+	td.runAcceptanceSequentialTest(t, testCase, td.costFor(testResource))
 }
 
 func RunTestsInSequence(t *testing.T, tests map[string]map[string]func(t *testing.T)) {
@@ -81,7 +82,41 @@ func RunTestsInSequence(t *testing.T, tests map[string]map[string]func(t *testin
 	}
 }
 
-func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
+// costFor returns the number of slots from the Resource Budget this test consumes - tests which
+// don't specify a cost don't consume any slots, so only quota-heavy tests are limited
+func (td TestData) costFor(testResource types.TestResource) int {
+	if td.resourceCost > 0 {
+		return td.resourceCost
+	}
+
+	if v, ok := testResource.(types.TestResourceWithCost); ok && v.ResourceCost() > 0 {
+		return v.ResourceCost()
+	}
+
+	return 0
+}
+
+// acquireResourceBudget blocks until this test can acquire the required slots from the
+// Resource Budget (when configured), returning a function to release them
+func (td TestData) acquireResourceBudget(t *testing.T, cost int) func() {
+	budget, err := resourceBudgetFromEnvironment()
+	if err != nil {
+		t.Fatalf("Error loading the Resource Budget: %+v", err)
+	}
+
+	// there's no need to acquire a budget for tests which are going to be skipped, or are free
+	if budget == nil || cost == 0 || os.Getenv(resource.TestEnvVar) == "" {
+		return func() {}
+	}
+
+	release, err := budget.acquire(t.Name(), cost)
+	if err != nil {
+		t.Fatalf("Error acquiring %d slot(s) from the Resource Budget: %+v", cost, err)
+	}
+	return release
+}
+
+func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase, cost int) {
 	testCase.ProviderFactories = map[string]terraform.ResourceProviderFactory{
 		"azuread": func() (terraform.ResourceProvider, error) {
 			aad := azuread.Provider()
@@ -93,10 +128,16 @@ func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 		},
 	}
 
-	resource.ParallelTest(t, testCase)
+	// NOTE: the budget must be acquired once the test is running in parallel, rather than whilst
+	// it's paused waiting to run in parallel, to avoid holding slots for tests which aren't running
+	t.Parallel()
+	release := td.acquireResourceBudget(t, cost)
+	defer release()
+
+	resource.Test(t, testCase)
 }
 
-func (td TestData) runAcceptanceSequentialTest(t *testing.T, testCase resource.TestCase) {
+func (td TestData) runAcceptanceSequentialTest(t *testing.T, testCase resource.TestCase, cost int) {
 	testCase.ProviderFactories = map[string]terraform.ResourceProviderFactory{
 		"azuread": func() (terraform.ResourceProvider, error) {
 			aad := azuread.Provider()
@@ -108,5 +149,8 @@ func (td TestData) runAcceptanceSequentialTest(t *testing.T, testCase resource.T
 		},
 	}
 
+	release := td.acquireResourceBudget(t, cost)
+	defer release()
+
 	resource.Test(t, testCase)
 }
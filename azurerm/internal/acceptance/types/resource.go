@@ -15,3 +15,13 @@ type TestResourceVerifyingRemoved interface {
 	TestResource
 	Destroy(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error)
 }
+
+// TestResourceWithCost is an optional interface which a TestResource can implement to specify
+// the relative cost of provisioning it (for example, in terms of quota) - which is used to limit
+// how many quota-heavy tests run concurrently when a Resource Budget is configured
+type TestResourceWithCost interface {
+	TestResource
+
+	// ResourceCost returns the number of slots from the Resource Budget this Resource consumes
+	ResourceCost() int
+}
@@ -0,0 +1,134 @@
+package apierrors
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Class is a category of error returned by the Azure API, which share the same remediation
+type Class string
+
+const (
+	// ClassUnknown is an error which doesn't belong to any of the known classes
+	ClassUnknown Class = ""
+
+	// ClassPolicyViolation is returned when the request was disallowed by an Azure Policy
+	ClassPolicyViolation Class = "PolicyViolation"
+
+	// ClassQuotaExceeded is returned when the request would exceed a Quota (e.g. the number of cores in a region)
+	ClassQuotaExceeded Class = "QuotaExceeded"
+
+	// ClassSkuNotAvailable is returned when the requested SKU isn't available (e.g. in the specified location)
+	ClassSkuNotAvailable Class = "SkuNotAvailable"
+
+	// ClassAuthorizationFailed is returned when the credentials used don't have permission to perform the request
+	ClassAuthorizationFailed Class = "AuthorizationFailed"
+
+	// ClassConflict is returned when the request conflicts with the current state of the resource, for
+	// example when another operation is in progress
+	ClassConflict Class = "Conflict"
+)
+
+var quotaNameRegex = regexp.MustCompile(`(?i)exceed(?:ing|s)? (?:the )?approved (.+?) quota`)
+
+var classCodes = map[Class][]string{
+	ClassPolicyViolation: {
+		"RequestDisallowedByPolicy",
+		"PolicyViolation",
+	},
+	ClassQuotaExceeded: {
+		"QuotaExceeded",
+		"OperationNotAllowedQuotaExceeded",
+		"SubscriptionQuotaExceeded",
+		"ResourceQuotaExceeded",
+		"InsufficientCoreQuota",
+	},
+	ClassSkuNotAvailable: {
+		"SkuNotAvailable",
+		"SkuNotSupported",
+		"NotAvailableForSubscription",
+	},
+	ClassAuthorizationFailed: {
+		"AuthorizationFailed",
+		"LinkedAuthorizationFailed",
+		"InvalidAuthenticationTokenTenant",
+		"Forbidden",
+	},
+	ClassConflict: {
+		"Conflict",
+		"AnotherOperationInProgress",
+		"OperationInProgress",
+		"ResourceGroupBeingDeleted",
+		"ScopeLocked",
+		"RetryableError",
+	},
+}
+
+// ClassOf returns the Class of the specified error, or ClassUnknown if this isn't an Azure API error
+func ClassOf(err error) Class {
+	if v, ok := Decode(err); ok {
+		return v.Class()
+	}
+
+	return ClassUnknown
+}
+
+// HasClass returns whether the specified error is an Azure API error of the specified Class
+func HasClass(err error, class Class) bool {
+	return class != ClassUnknown && ClassOf(err) == class
+}
+
+func classify(e Error) Class {
+	// the more specific Classes are checked first, since these are commonly nested within
+	// more generic errors (e.g. a `QuotaExceeded` within an `OperationNotAllowed`)
+	for _, class := range []Class{ClassPolicyViolation, ClassQuotaExceeded, ClassSkuNotAvailable, ClassAuthorizationFailed} {
+		if hasCode(e, class) {
+			return class
+		}
+	}
+
+	// some API's return a generic error code with a more specific message
+	if strings.EqualFold(e.Code, "OperationNotAllowed") && quotaNameRegex.MatchString(e.Message) {
+		return ClassQuotaExceeded
+	}
+
+	if hasCode(e, ClassConflict) || e.StatusCode == http.StatusConflict {
+		return ClassConflict
+	}
+
+	return ClassUnknown
+}
+
+// hasCode returns whether the error (or any nested error) has one of the codes for the specified Class
+func hasCode(e Error, class Class) bool {
+	for _, code := range classCodes[class] {
+		if strings.EqualFold(e.Code, code) {
+			return true
+		}
+	}
+
+	for _, detail := range e.Details {
+		if hasCode(detail, class) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As is a convenience wrapper around errors.As for the Error type
+func As(err error) (*Error, bool) {
+	var output Error
+	if errors.As(err, &output) {
+		return &output, true
+	}
+
+	var ptr *Error
+	if errors.As(err, &ptr) && ptr != nil {
+		return ptr, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,301 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+var (
+	statusCodeRegex = regexp.MustCompile(`\bStatus(?:Code)?=(\d{3})\b`)
+
+	// autorestPrefixRegex matches the start of the error rendered by the Azure SDK (for example
+	// `resources.GroupsClient#CreateOrUpdate: Failure sending request`)
+	autorestPrefixRegex = regexp.MustCompile(`[A-Za-z0-9_.]+#[A-Za-z0-9_]+: `)
+)
+
+// rawError is the format used for errors (and nested errors) within the Azure Resource Manager API
+type rawError struct {
+	Code           string           `json:"code"`
+	Message        string           `json:"message"`
+	Target         *string          `json:"target"`
+	Details        []rawError       `json:"details"`
+	AdditionalInfo []AdditionalInfo `json:"additionalInfo"`
+}
+
+func (r rawError) toError() Error {
+	output := Error{
+		Code:           r.Code,
+		Message:        r.Message,
+		AdditionalInfo: r.AdditionalInfo,
+	}
+	if r.Target != nil {
+		output.Target = *r.Target
+	}
+	for _, v := range r.Details {
+		output.Details = append(output.Details, v.toError())
+	}
+	return output
+}
+
+// Decode decodes the Azure Resource Manager API error contained within the specified error - either from
+// the error types used in the Azure SDK, or (since these are commonly formatted into another error using
+// `%+v`) from the error message rendered by the Azure SDK.
+func Decode(err error) (*Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	if v, ok := As(err); ok {
+		return v, true
+	}
+
+	if v, ok := decodeFromTypes(err); ok {
+		return v, true
+	}
+
+	return decodeFromMessage(err)
+}
+
+// Format returns a concise, actionable error for the specified error when it contains an Azure Resource Manager
+// API error, retaining any context added by the Provider - otherwise the original error is returned.
+func Format(err error) error {
+	if v, ok := Decode(err); ok {
+		return *v
+	}
+
+	return err
+}
+
+// Parse parses the body of an Azure Resource Manager API error response (in the format `{"error": {...}}`)
+func Parse(statusCode int, body []byte) (*Error, bool) {
+	var envelope struct {
+		Error *rawError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Error == nil || envelope.Error.Code == "" {
+		return nil, false
+	}
+
+	output := envelope.Error.toError()
+	output.StatusCode = statusCode
+	return &output, true
+}
+
+func decodeFromTypes(err error) (*Error, bool) {
+	var serviceError *azure.ServiceError
+
+	var requestError *azure.RequestError
+	var requestErrorValue azure.RequestError
+	var serviceErrorValue azure.ServiceError
+	var inner error
+	switch {
+	case errors.As(err, &requestError) && requestError.ServiceError != nil:
+		serviceError = requestError.ServiceError
+		inner = requestError
+	case errors.As(err, &requestErrorValue) && requestErrorValue.ServiceError != nil:
+		serviceError = requestErrorValue.ServiceError
+		inner = requestErrorValue
+	case errors.As(err, &serviceError) && serviceError != nil:
+		inner = serviceError
+	case errors.As(err, &serviceErrorValue):
+		serviceError = &serviceErrorValue
+		inner = serviceErrorValue
+	default:
+		return nil, false
+	}
+
+	if serviceError.Code == "" {
+		return nil, false
+	}
+
+	raw := rawError{
+		Code:    serviceError.Code,
+		Message: serviceError.Message,
+		Target:  serviceError.Target,
+	}
+	if err := remarshal(serviceError.Details, &raw.Details); err != nil {
+		return nil, false
+	}
+	if err := remarshal(serviceError.AdditionalInfo, &raw.AdditionalInfo); err != nil {
+		return nil, false
+	}
+
+	output := raw.toError()
+	output.original = err
+	output.prefix = prefixOf(err.Error())
+	output.suffix = suffixOf(err.Error(), inner.Error())
+
+	var detailedError autorest.DetailedError
+	var detailedErrorPtr *autorest.DetailedError
+	switch {
+	case requestError != nil:
+		output.StatusCode = statusCodeOf(requestError.StatusCode)
+	case errors.As(err, &detailedError):
+		output.StatusCode = statusCodeOf(detailedError.StatusCode)
+	case errors.As(err, &detailedErrorPtr):
+		output.StatusCode = statusCodeOf(detailedErrorPtr.StatusCode)
+	}
+	if output.StatusCode == 0 {
+		output.StatusCode = statusCodeFromMessage(err.Error())
+	}
+
+	return &output, true
+}
+
+// decodeFromMessage decodes the error rendered by `azure.ServiceError`, which is in the format:
+// `Code="..." Message="..." Target="..." Details=[...] InnerError={...} AdditionalInfo=[...]`
+func decodeFromMessage(err error) (*Error, bool) {
+	message := err.Error()
+
+	start := strings.Index(message, `Code="`)
+	if start == -1 {
+		return nil, false
+	}
+
+	raw := rawError{}
+	remaining := message[start:]
+	found := false
+	for remaining != "" {
+		remaining = strings.TrimLeft(remaining, " ")
+		separator := strings.Index(remaining, "=")
+		if separator <= 0 {
+			break
+		}
+		key := remaining[:separator]
+		value := remaining[separator+1:]
+
+		var consumed int
+		var ok bool
+		switch key {
+		case "Code":
+			raw.Code, consumed, ok = readQuoted(value)
+			found = ok
+		case "Message":
+			raw.Message, consumed, ok = readQuoted(value)
+		case "Target":
+			var target string
+			target, consumed, ok = readQuoted(value)
+			raw.Target = &target
+		case "Details":
+			consumed, ok = readJSON(value, &raw.Details)
+		case "InnerError":
+			var innerError interface{}
+			consumed, ok = readJSON(value, &innerError)
+		case "AdditionalInfo":
+			consumed, ok = readJSON(value, &raw.AdditionalInfo)
+		}
+		if !ok {
+			break
+		}
+
+		remaining = value[consumed:]
+	}
+
+	if !found || raw.Code == "" {
+		return nil, false
+	}
+
+	output := raw.toError()
+	output.original = err
+	output.prefix = prefixOf(message)
+	// anything which couldn't be parsed is context added by the Provider after the error from the Azure SDK
+	output.suffix = strings.TrimSpace(remaining)
+	output.StatusCode = statusCodeFromMessage(message)
+	return &output, true
+}
+
+// readQuoted reads a Go-quoted string from the start of the input, returning the number of bytes consumed
+func readQuoted(input string) (string, int, bool) {
+	if !strings.HasPrefix(input, `"`) {
+		return "", 0, false
+	}
+
+	escaped := false
+	for i := 1; i < len(input); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case input[i] == '\\':
+			escaped = true
+		case input[i] == '"':
+			value, err := strconv.Unquote(input[:i+1])
+			if err != nil {
+				return "", 0, false
+			}
+			return value, i + 1, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// readJSON reads a single JSON value from the start of the input, returning the number of bytes consumed
+func readJSON(input string, output interface{}) (int, bool) {
+	decoder := json.NewDecoder(strings.NewReader(input))
+	if err := decoder.Decode(output); err != nil {
+		return 0, false
+	}
+
+	return int(decoder.InputOffset()), true
+}
+
+// prefixOf returns the context added to the error message by the Provider, prior to the error from the Azure SDK
+func prefixOf(message string) string {
+	end := len(message)
+	if loc := autorestPrefixRegex.FindStringIndex(message); loc != nil {
+		end = loc[0]
+	}
+	for _, marker := range []string{"StatusCode=", "autorest/azure:", `Code="`} {
+		if i := strings.Index(message, marker); i != -1 && i < end {
+			end = i
+		}
+	}
+
+	return strings.TrimRight(strings.TrimSpace(message[:end]), ":")
+}
+
+// suffixOf returns the context added to the error message by the Provider, after the error from the Azure SDK
+func suffixOf(message string, inner string) string {
+	i := strings.LastIndex(message, inner)
+	if i == -1 {
+		return ""
+	}
+
+	return strings.TrimSpace(message[i+len(inner):])
+}
+
+func statusCodeFromMessage(message string) int {
+	if match := statusCodeRegex.FindStringSubmatch(message); len(match) == 2 {
+		if v, err := strconv.Atoi(match[1]); err == nil {
+			return v
+		}
+	}
+
+	return 0
+}
+
+func statusCodeOf(input interface{}) int {
+	if v, ok := input.(int); ok {
+		return v
+	}
+
+	return 0
+}
+
+func remarshal(input interface{}, output interface{}) error {
+	if input == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, output)
+}
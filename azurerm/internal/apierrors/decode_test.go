@@ -0,0 +1,209 @@
+package apierrors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+const testPolicyAssignmentId = "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/policyAssignments/allowed-locations"
+
+func testPolicyError() error {
+	target := "example-resources"
+	serviceError := &azure.ServiceError{
+		Code:    "RequestDisallowedByPolicy",
+		Message: "Resource 'example-resources' was disallowed by policy.",
+		Target:  &target,
+		AdditionalInfo: []map[string]interface{}{
+			{
+				"type": "PolicyViolation",
+				"info": map[string]interface{}{
+					"policyAssignmentId":          testPolicyAssignmentId,
+					"policyAssignmentName":        "allowed-locations",
+					"policyAssignmentDisplayName": "Allowed Locations",
+					"policyDefinitionDisplayName": "Allowed locations",
+				},
+			},
+		},
+	}
+	return autorest.NewErrorWithError(&azure.RequestError{
+		DetailedError: autorest.DetailedError{StatusCode: http.StatusForbidden},
+		ServiceError:  serviceError,
+	}, "resources.GroupsClient", "CreateOrUpdate", nil, "Failure responding to request")
+}
+
+func TestDecodePolicyViolation(t *testing.T) {
+	testData := map[string]error{
+		"types":   testPolicyError(),
+		"message": fmt.Errorf("creating Resource Group %q: %+v", "example-resources", testPolicyError()),
+	}
+
+	for name, err := range testData {
+		t.Logf("[DEBUG] Testing %q..", name)
+
+		actual, ok := Decode(err)
+		if !ok {
+			t.Fatalf("expected %q to be decoded", err.Error())
+		}
+		if actual.Class() != ClassPolicyViolation {
+			t.Fatalf("expected the class to be %q but got %q", ClassPolicyViolation, actual.Class())
+		}
+		if actual.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected the status code to be %d but got %d", http.StatusForbidden, actual.StatusCode)
+		}
+
+		violations := actual.PolicyViolations()
+		if len(violations) != 1 || violations[0].PolicyAssignmentId != testPolicyAssignmentId {
+			t.Fatalf("expected a single Policy Violation for %q but got %+v", testPolicyAssignmentId, violations)
+		}
+
+		if !strings.Contains(actual.Error(), testPolicyAssignmentId) || !strings.Contains(actual.Error(), "Allowed Locations") {
+			t.Fatalf("expected the message to include the Policy Assignment but got %q", actual.Error())
+		}
+	}
+
+	formatted := Format(fmt.Errorf("creating Resource Group %q: %+v", "example-resources", testPolicyError())).Error()
+	if !strings.HasPrefix(formatted, `creating Resource Group "example-resources": the request was disallowed by Azure Policy`) {
+		t.Fatalf("expected the context to be retained but got %q", formatted)
+	}
+	if strings.Contains(formatted, "GroupsClient") {
+		t.Fatalf("expected the Azure SDK error to be removed but got %q", formatted)
+	}
+}
+
+func TestDecodeMessage(t *testing.T) {
+	testData := []struct {
+		message string
+		code    string
+		class   Class
+		status  int
+		quota   string
+		details int
+	}{
+		{
+			message: `creating Linux Virtual Machine "example": compute.VirtualMachinesClient#CreateOrUpdate: Failure sending request: StatusCode=409 -- Original Error: Code="OperationNotAllowed" Message="Operation could not be completed as it results in exceeding approved Total Regional Cores quota. Additional details - Deployment Model: Resource Manager, Location: westeurope, Current Limit: 10, Current Usage: 8, Additional Required: 4"`,
+			code:    "OperationNotAllowed",
+			class:   ClassQuotaExceeded,
+			status:  409,
+			quota:   "Total Regional Cores",
+		},
+		{
+			message: `waiting for creation of Kubernetes Cluster "example": Code="QuotaExceeded" Message="Provisioning failed" Target="standardDSv2Family" Details=[{"code":"QuotaExceeded","message":"Quota exceeded for standardDSv2Family"}]`,
+			code:    "QuotaExceeded",
+			class:   ClassQuotaExceeded,
+			quota:   "standardDSv2Family",
+			details: 1,
+		},
+		{
+			message: `creating Virtual Machine: autorest/azure: Service returned an error. Status=409 Code="SkuNotAvailable" Message="The requested size for resource 'example' is currently not available in location 'westeurope'."`,
+			code:    "SkuNotAvailable",
+			class:   ClassSkuNotAvailable,
+			status:  409,
+		},
+		{
+			message: `retrieving Key Vault: keyvault.VaultsClient#Get: Failure responding to request: StatusCode=403 -- Original Error: autorest/azure: Service returned an error. Status=403 Code="AuthorizationFailed" Message="The client 'abc' does not have authorization to perform action 'Microsoft.KeyVault/vaults/read' over scope '/subscriptions/00000000-0000-0000-0000-000000000000'."`,
+			code:    "AuthorizationFailed",
+			class:   ClassAuthorizationFailed,
+			status:  403,
+		},
+		{
+			message: `deleting Subnet: Code="AnotherOperationInProgress" Message="Another operation on this or dependent resource is in progress." Details=[] AdditionalInfo=[]`,
+			code:    "AnotherOperationInProgress",
+			class:   ClassConflict,
+		},
+		{
+			message: `updating Storage Account: StatusCode=400 -- Original Error: Code="InvalidParameter" Message="The value \"abc\" is invalid." Target="name" InnerError={"code":"x"} Details=[{"code":"Nested","message":"first"},{"code":"Nested","message":"second","details":[{"code":"Deeper","message":"third"}]}]`,
+			code:    "InvalidParameter",
+			class:   ClassUnknown,
+			status:  400,
+			details: 2,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.code)
+
+		actual, ok := Decode(fmt.Errorf("%s", v.message))
+		if !ok {
+			t.Fatalf("expected %q to be decoded", v.message)
+		}
+		if actual.Code != v.code {
+			t.Fatalf("expected the code to be %q but got %q", v.code, actual.Code)
+		}
+		if actual.Class() != v.class {
+			t.Fatalf("expected the class to be %q but got %q", v.class, actual.Class())
+		}
+		if actual.StatusCode != v.status {
+			t.Fatalf("expected the status code to be %d but got %d", v.status, actual.StatusCode)
+		}
+		if v.quota != "" && actual.QuotaName() != v.quota {
+			t.Fatalf("expected the quota to be %q but got %q", v.quota, actual.QuotaName())
+		}
+		if len(actual.Details) != v.details {
+			t.Fatalf("expected %d details but got %d", v.details, len(actual.Details))
+		}
+	}
+}
+
+func TestDecodeRetainsContext(t *testing.T) {
+	serviceError := &azure.ServiceError{
+		Code:    "Conflict",
+		Message: "The Subnet is in use.",
+	}
+
+	testData := map[string]error{
+		"types":                fmt.Errorf("deleting Subnet %q: %w - remove the Network Interfaces first", "internal", serviceError),
+		"message":              fmt.Errorf("deleting Subnet %q: %+v - remove the Network Interfaces first", "internal", serviceError),
+		"message with details": fmt.Errorf("deleting Subnet %q: %s; remove the Network Interfaces first", "internal", `Code="Conflict" Message="The Subnet is in use." Details=[] AdditionalInfo=[]`),
+	}
+
+	for name, err := range testData {
+		t.Logf("[DEBUG] Testing %q..", name)
+
+		actual, ok := Decode(err)
+		if !ok {
+			t.Fatalf("expected %q to be decoded", err.Error())
+		}
+		if actual.Message != "The Subnet is in use." {
+			t.Fatalf("expected the message to be %q but got %q", "The Subnet is in use.", actual.Message)
+		}
+		if !strings.HasPrefix(actual.Error(), `deleting Subnet "internal": `) {
+			t.Fatalf("expected the context prior to the error to be retained but got %q", actual.Error())
+		}
+		if !strings.HasSuffix(actual.Error(), "remove the Network Interfaces first") {
+			t.Fatalf("expected the context after the error to be retained but got %q", actual.Error())
+		}
+	}
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	for _, err := range []error{nil, fmt.Errorf("something went wrong"), fmt.Errorf(`Code="`)} {
+		if _, ok := Decode(err); ok {
+			t.Fatalf("expected %+v not to be decoded", err)
+		}
+	}
+
+	err := fmt.Errorf("something went wrong")
+	if Format(err) != err {
+		t.Fatalf("expected the original error to be returned")
+	}
+}
+
+func TestParse(t *testing.T) {
+	body := `{"error": {"code": "ScopeLocked", "message": "The scope is locked.", "details": [{"code": "Nested", "message": "A nested error"}]}}`
+	actual, ok := Parse(http.StatusConflict, []byte(body))
+	if !ok {
+		t.Fatalf("expected the body to be parsed")
+	}
+	if actual.Class() != ClassConflict || len(actual.Details) != 1 {
+		t.Fatalf("expected a Conflict with 1 detail but got %+v", actual)
+	}
+
+	if _, ok := Parse(http.StatusBadRequest, []byte(`{"message": "not an ARM error"}`)); ok {
+		t.Fatalf("expected a non-ARM error not to be parsed")
+	}
+}
@@ -0,0 +1,228 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Error is an error returned by the Azure Resource Manager API, decoded from either the
+// Azure SDK error types or the rendered error message
+type Error struct {
+	// StatusCode is the HTTP Status Code returned by the API, when known
+	StatusCode int
+
+	// Code is the error code returned by the API (e.g. `RequestDisallowedByPolicy`)
+	Code string
+
+	// Message is the (human readable) error message returned by the API
+	Message string
+
+	// Target is the target of the error (e.g. the name of a field or a quota), when specified
+	Target string
+
+	// Details contains any nested errors returned by the API
+	Details []Error
+
+	// AdditionalInfo contains any additional information returned by the API, such as the details
+	// of a Policy Violation
+	AdditionalInfo []AdditionalInfo
+
+	// prefix is the context added to the error by the Provider (e.g. `creating Resource Group "example"`)
+	prefix string
+
+	// suffix is any context added to the error by the Provider after the error from the Azure SDK
+	suffix string
+
+	original error
+}
+
+type AdditionalInfo struct {
+	Type string                 `json:"type"`
+	Info map[string]interface{} `json:"info"`
+}
+
+// PolicyViolation is the details of an Azure Policy which disallowed the request
+type PolicyViolation struct {
+	PolicyAssignmentId          string `json:"policyAssignmentId"`
+	PolicyAssignmentName        string `json:"policyAssignmentName"`
+	PolicyAssignmentDisplayName string `json:"policyAssignmentDisplayName"`
+	PolicyAssignmentScope       string `json:"policyAssignmentScope"`
+	PolicyDefinitionId          string `json:"policyDefinitionId"`
+	PolicyDefinitionDisplayName string `json:"policyDefinitionDisplayName"`
+	PolicySetDefinitionId       string `json:"policySetDefinitionId"`
+	PolicySetDefinitionName     string `json:"policySetDefinitionName"`
+}
+
+// Class returns the Class of this error, based on the error codes returned by the API
+func (e Error) Class() Class {
+	return classify(e)
+}
+
+// PolicyViolations returns the details of the Azure Policies which disallowed the request
+func (e Error) PolicyViolations() []PolicyViolation {
+	output := make([]PolicyViolation, 0)
+	seen := make(map[string]struct{})
+
+	var walk func(input Error)
+	walk = func(input Error) {
+		for _, info := range input.AdditionalInfo {
+			if !strings.EqualFold(info.Type, "PolicyViolation") || info.Info == nil {
+				continue
+			}
+
+			var violation PolicyViolation
+			raw, err := json.Marshal(info.Info)
+			if err != nil {
+				continue
+			}
+			if err := json.Unmarshal(raw, &violation); err != nil {
+				continue
+			}
+
+			key := strings.ToLower(violation.PolicyAssignmentId + violation.PolicyDefinitionId)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			output = append(output, violation)
+		}
+
+		for _, detail := range input.Details {
+			walk(detail)
+		}
+	}
+	walk(e)
+
+	return output
+}
+
+// QuotaName returns the name of the Quota which would be exceeded by the request, when known
+func (e Error) QuotaName() string {
+	if e.Target != "" {
+		return e.Target
+	}
+
+	if match := quotaNameRegex.FindStringSubmatch(e.Message); len(match) == 2 {
+		return strings.TrimSpace(match[1])
+	}
+
+	for _, detail := range e.Details {
+		if classify(detail) == ClassQuotaExceeded {
+			if name := detail.QuotaName(); name != "" {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+// Unwrap returns the original error
+func (e Error) Unwrap() error {
+	return e.original
+}
+
+func (e Error) Error() string {
+	output := e.summary()
+	if e.prefix != "" {
+		output = fmt.Sprintf("%s: %s", e.prefix, output)
+	}
+	if e.suffix != "" {
+		output = fmt.Sprintf("%s\n%s", output, e.suffix)
+	}
+
+	return output
+}
+
+// summary returns a concise, actionable summary of this error, dependent on the Class of error
+func (e Error) summary() string {
+	lines := make([]string, 0)
+
+	switch e.Class() {
+	case ClassPolicyViolation:
+		lines = append(lines, fmt.Sprintf("the request was disallowed by Azure Policy (%s): %s", e.Code, e.Message))
+		for _, v := range e.PolicyViolations() {
+			lines = append(lines, fmt.Sprintf("  - Policy Assignment %q (%s)", coalesce(v.PolicyAssignmentDisplayName, v.PolicyAssignmentName), v.PolicyAssignmentId))
+			if definition := coalesce(v.PolicyDefinitionDisplayName, v.PolicyDefinitionId); definition != "" {
+				lines = append(lines, fmt.Sprintf("    Policy Definition %q", definition))
+			}
+		}
+
+	case ClassQuotaExceeded:
+		quota := e.QuotaName()
+		if quota == "" {
+			quota = "a"
+		} else {
+			quota = fmt.Sprintf("the %q", quota)
+		}
+		lines = append(lines, fmt.Sprintf("the request would exceed %s quota (%s) - the quota needs to be increased before retrying: %s", quota, e.Code, e.Message))
+
+	case ClassSkuNotAvailable:
+		lines = append(lines, fmt.Sprintf("the requested SKU isn't available (%s): %s", e.Code, e.Message))
+
+	case ClassAuthorizationFailed:
+		lines = append(lines, fmt.Sprintf("the credentials used by Terraform aren't authorized to perform this action (%s): %s", e.Code, e.Message))
+
+	case ClassConflict:
+		lines = append(lines, fmt.Sprintf("the request conflicts with the current state of the resource, another operation may be in progress (%s): %s", e.Code, e.Message))
+
+	default:
+		line := fmt.Sprintf("%s: %s", e.Code, e.Message)
+		if e.StatusCode != 0 {
+			line = fmt.Sprintf("unexpected status %d with error: %s", e.StatusCode, line)
+		}
+		lines = append(lines, line)
+	}
+
+	if e.Target != "" && e.Class() != ClassQuotaExceeded {
+		lines = append(lines, fmt.Sprintf("  Target: %s", e.Target))
+	}
+
+	// policy violations include the details of the failing policies above
+	if e.Class() != ClassPolicyViolation {
+		for _, detail := range uniqueDetails(e) {
+			lines = append(lines, fmt.Sprintf("  - %s", detail))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// uniqueDetails returns the nested errors which provide further information than the top-level error
+func uniqueDetails(e Error) []string {
+	output := make([]string, 0)
+	seen := map[string]struct{}{
+		e.Code + e.Message: {},
+	}
+
+	var walk func(input []Error)
+	walk = func(input []Error) {
+		for _, detail := range input {
+			key := detail.Code + detail.Message
+			if _, ok := seen[key]; !ok && detail.Message != "" {
+				seen[key] = struct{}{}
+				line := fmt.Sprintf("%s: %s", detail.Code, detail.Message)
+				if detail.Target != "" {
+					line = fmt.Sprintf("%s (Target %q)", line, detail.Target)
+				}
+				output = append(output, line)
+			}
+			walk(detail.Details)
+		}
+	}
+	walk(e.Details)
+
+	sort.Strings(output)
+	return output
+}
+
+func coalesce(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,194 @@
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// headerCorrelationRequestID is the header containing the Correlation Request ID for the request
+const headerCorrelationRequestID = "x-ms-correlation-request-id"
+
+// Entry is a single request to (and the response from) an Azure API within the Audit Log
+type Entry struct {
+	Time                 time.Time `json:"time"`
+	Method               string    `json:"method"`
+	URL                  string    `json:"url"`
+	StatusCode           int       `json:"status_code,omitempty"`
+	DurationMs           int64     `json:"duration_ms"`
+	CorrelationRequestID string    `json:"correlation_request_id,omitempty"`
+	RequestBody          string    `json:"request_body,omitempty"`
+	ResponseBody         string    `json:"response_body,omitempty"`
+	Error                string    `json:"error,omitempty"`
+}
+
+// Logger writes an Audit Log of the requests made to the Azure API's (one JSON-encoded Entry per line),
+// redacting any sensitive values within them
+type Logger struct {
+	redactor *Redactor
+
+	lock   sync.Mutex
+	writer io.Writer
+
+	// file is the file which the Audit Log is written to, when opened by this Logger
+	file *os.File
+}
+
+// New returns a Logger which appends to the file at the specified path, which is created if it doesn't exist
+func New(path string, redactor *Redactor) (*Logger, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening the Audit Log %q: %+v", path, err)
+	}
+
+	logger := NewWithWriter(file, redactor)
+	logger.file = file
+	return logger, nil
+}
+
+// NewWithWriter returns a Logger which writes to the specified Writer
+func NewWithWriter(writer io.Writer, redactor *Redactor) *Logger {
+	if redactor == nil {
+		redactor = NewRedactor()
+	}
+
+	return &Logger{
+		redactor: redactor,
+		writer:   writer,
+	}
+}
+
+// Sender returns an autorest.Sender which records each request sent using the specified Sender in the Audit Log
+func (l *Logger) Sender(sender autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		entry := Entry{
+			Time:                 time.Now().UTC(),
+			Method:               r.Method,
+			URL:                  l.redactor.RedactURL(r.URL),
+			CorrelationRequestID: r.Header.Get(headerCorrelationRequestID),
+			RequestBody:          l.requestBody(r),
+		}
+
+		resp, err := sender.Do(r)
+		entry.DurationMs = time.Since(entry.Time).Milliseconds()
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		if resp != nil {
+			entry.StatusCode = resp.StatusCode
+			entry.ResponseBody = l.responseBody(resp)
+		}
+
+		l.write(entry)
+		return resp, err
+	})
+}
+
+// requestBody returns the redacted body of the request - which is only read when it's JSON, since other
+// requests can be large (e.g. uploading a Blob) and may not be able to be re-read
+func (l *Logger) requestBody(r *http.Request) string {
+	if r.Body == nil || r.Body == http.NoBody {
+		return ""
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if !isJSON(contentType) {
+		return omitted(r.ContentLength)
+	}
+
+	var body []byte
+	var err error
+	if r.GetBody != nil {
+		var reader io.ReadCloser
+		if reader, err = r.GetBody(); err == nil {
+			body, err = ioutil.ReadAll(reader)
+			reader.Close()
+		}
+	} else {
+		body, err = ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	if err != nil {
+		return fmt.Sprintf("[unable to read the body: %+v]", err)
+	}
+
+	return l.redactor.RedactBody(contentType, body)
+}
+
+// responseBody returns the redacted body of the response, which (as for requests) is only read when it's JSON
+func (l *Logger) responseBody(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !isJSON(contentType) {
+		return omitted(resp.ContentLength)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Sprintf("[unable to read the body: %+v]", err)
+	}
+
+	return l.redactor.RedactBody(contentType, body)
+}
+
+func (l *Logger) write(entry Entry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	// the Audit Log is best-effort, so failing to write to it shouldn't fail the request
+	_, _ = l.writer.Write(append(line, '\n'))
+}
+
+// Close flushes and closes the file which the Audit Log is written to (when opened by this Logger) - any
+// requests sent after the Logger is closed are no longer recorded in the Audit Log
+func (l *Logger) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.writer = ioutil.Discard
+	if l.file == nil {
+		return nil
+	}
+
+	file := l.file
+	l.file = nil
+	syncErr := file.Sync()
+	closeErr := file.Close()
+	if syncErr != nil {
+		return fmt.Errorf("flushing the Audit Log %q: %+v", file.Name(), syncErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("closing the Audit Log %q: %+v", file.Name(), closeErr)
+	}
+
+	return nil
+}
+
+func omitted(length int64) string {
+	if length <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("[%d bytes omitted]", length)
+}
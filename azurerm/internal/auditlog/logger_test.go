@@ -0,0 +1,107 @@
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestLoggerSender(t *testing.T) {
+	responseBody := `{"keys":[{"keyName":"key1","value":"secret-key"}]}`
+	sender := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(responseBody)),
+		}, nil
+	})
+
+	buf := bytes.Buffer{}
+	logger := NewWithWriter(&buf, NewRedactor())
+
+	req, err := http.NewRequest(http.MethodPost, "https://management.azure.com/example/listKeys?api-version=2021-01-01", strings.NewReader(`{"password":"hunter2"}`))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerCorrelationRequestID, "00000000-0000-0000-0000-000000000000")
+
+	resp, err := logger.Sender(sender).Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	// the response body should still be readable by the caller
+	body, _ := ioutil.ReadAll(resp.Body)
+	if string(body) != responseBody {
+		t.Fatalf("expected the response body %q but got %q", responseBody, string(body))
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("decoding entry %q: %+v", buf.String(), err)
+	}
+	if entry.Method != http.MethodPost || entry.StatusCode != http.StatusOK || entry.CorrelationRequestID != "00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if strings.Contains(buf.String(), "hunter2") || strings.Contains(buf.String(), "secret-key") {
+		t.Fatalf("expected the sensitive values to be redacted but got %q", buf.String())
+	}
+}
+
+func TestLoggerClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auditlog")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	logger, err := New(path, NewRedactor())
+	if err != nil {
+		t.Fatalf("opening the Audit Log: %+v", err)
+	}
+
+	sender := autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Body:       http.NoBody,
+		}, nil
+	})
+	send := func() {
+		req, err := http.NewRequest(http.MethodDelete, "https://management.azure.com/example?api-version=2021-01-01", nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		if _, err := logger.Sender(sender).Do(req); err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+	}
+
+	send()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("closing the Audit Log: %+v", err)
+	}
+	// requests sent once the Logger is closed shouldn't fail, but are no longer recorded
+	send()
+
+	// closing the Logger multiple times is a no-op
+	if err := logger.Close(); err != nil {
+		t.Fatalf("closing the Audit Log a second time: %+v", err)
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading the Audit Log: %+v", err)
+	}
+	if lines := strings.Count(string(contents), "\n"); lines != 1 {
+		t.Fatalf("expected 1 entry in the Audit Log but got %d: %q", lines, string(contents))
+	}
+}
@@ -0,0 +1,145 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// redacted is the value used in place of a sensitive value
+const redacted = "[REDACTED]"
+
+// defaultSensitiveFields are the names of properties known to contain sensitive values in the requests to
+// (and responses from) Azure Resource Manager and the Key Vault/Storage data-plane API's
+var defaultSensitiveFields = []string{
+	"accessKey",
+	"accessToken",
+	"adminPassword",
+	"administratorLoginPassword",
+	"clientSecret",
+	"connectionString",
+	"password",
+	"primaryConnectionString",
+	"primaryKey",
+	"primaryMasterKey",
+	"primaryReadonlyMasterKey",
+	"refreshToken",
+	"sasToken",
+	"secondaryConnectionString",
+	"secondaryKey",
+	"secondaryMasterKey",
+	"secondaryReadonlyMasterKey",
+	"secret",
+	"sharedKey",
+	"value",
+}
+
+// sensitiveQueryParameters are the names of query string parameters known to contain sensitive values,
+// for example the signature of a Shared Access Signature
+var sensitiveQueryParameters = []string{
+	"code",
+	"sig",
+	"token",
+}
+
+// Redactor redacts the sensitive values within the requests to (and responses from) the Azure API's
+type Redactor struct {
+	fields map[string]struct{}
+}
+
+// NewRedactor returns a Redactor for the known sensitive fields, in addition to the fields specified - which can
+// be either the name of the property in the API (e.g. `primaryKey`) or in the Schema (e.g. `primary_key`)
+func NewRedactor(fields ...string) *Redactor {
+	r := &Redactor{
+		fields: make(map[string]struct{}),
+	}
+	for _, v := range append(defaultSensitiveFields, fields...) {
+		r.fields[normalizeFieldName(v)] = struct{}{}
+	}
+	return r
+}
+
+// IsSensitive returns whether the specified field contains a sensitive value
+func (r *Redactor) IsSensitive(field string) bool {
+	_, ok := r.fields[normalizeFieldName(field)]
+	return ok
+}
+
+// RedactBody returns the specified body with any sensitive values redacted - since only JSON bodies can be
+// inspected, any other body is omitted entirely (since for example this could be the contents of a Blob)
+func (r *Redactor) RedactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if isJSON(contentType) {
+		var decoded interface{}
+		if err := json.Unmarshal(body, &decoded); err == nil {
+			if encoded, err := json.Marshal(r.redactValue("", decoded)); err == nil {
+				return string(encoded)
+			}
+		}
+	}
+
+	return fmt.Sprintf("[%d bytes omitted]", len(body))
+}
+
+// RedactURL returns the specified URL with the values of any sensitive query string parameters redacted
+func (r *Redactor) RedactURL(input *url.URL) string {
+	if input == nil {
+		return ""
+	}
+
+	output := *input
+	values := output.Query()
+	changed := false
+	for key := range values {
+		for _, parameter := range sensitiveQueryParameters {
+			if strings.EqualFold(key, parameter) {
+				values.Set(key, redacted)
+				changed = true
+			}
+		}
+	}
+	if changed {
+		output.RawQuery = values.Encode()
+	}
+	output.User = nil
+
+	return output.String()
+}
+
+// redactValue redacts the value of any sensitive fields within the specified value - where only string values are
+// redacted, since the same property name can be used for a list of items (e.g. `value` in a List response)
+func (r *Redactor) redactValue(field string, input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = r.redactValue(key, value)
+		}
+		return v
+
+	case []interface{}:
+		for i, value := range v {
+			v[i] = r.redactValue(field, value)
+		}
+		return v
+
+	case string:
+		if field != "" && r.IsSensitive(field) {
+			return redacted
+		}
+	}
+
+	return input
+}
+
+func isJSON(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "json")
+}
+
+// normalizeFieldName normalizes both the Schema (`primary_key`) and API (`primaryKey`) names for a field
+func normalizeFieldName(input string) string {
+	return strings.ToLower(strings.ReplaceAll(input, "_", ""))
+}
@@ -0,0 +1,70 @@
+package auditlog
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	redactor := NewRedactor("custom_secret_field")
+
+	testData := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    string
+	}{
+		{
+			name:        "list keys",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"keys":[{"keyName":"key1","permissions":"FULL","value":"abc123"}]}`,
+			expected:    `{"keys":[{"keyName":"key1","permissions":"FULL","value":"[REDACTED]"}]}`,
+		},
+		{
+			name:        "list response",
+			contentType: "application/json",
+			body:        `{"value":[{"name":"example","properties":{"primaryConnectionString":"Endpoint=sb://"}}]}`,
+			expected:    `{"value":[{"name":"example","properties":{"primaryConnectionString":"[REDACTED]"}}]}`,
+		},
+		{
+			name:        "schema field",
+			contentType: "application/json",
+			body:        `{"properties":{"customSecretField":"abc","other":"def"}}`,
+			expected:    `{"properties":{"customSecretField":"[REDACTED]","other":"def"}}`,
+		},
+		{
+			name:        "non-json",
+			contentType: "application/octet-stream",
+			body:        "AccountKey=abc123",
+			expected:    "[17 bytes omitted]",
+		},
+		{
+			name:        "invalid json",
+			contentType: "application/json",
+			body:        `{"value": "abc`,
+			expected:    "[14 bytes omitted]",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := redactor.RedactBody(v.contentType, []byte(v.body))
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	input, err := url.Parse("https://example.blob.core.windows.net/container/blob?sv=2019-12-12&sig=abc123&se=2021-01-01")
+	if err != nil {
+		t.Fatalf("parsing URL: %+v", err)
+	}
+
+	actual := NewRedactor().RedactURL(input)
+	if strings.Contains(actual, "abc123") || !strings.Contains(actual, "sv=2019-12-12") {
+		t.Fatalf("expected the signature to be redacted but got %q", actual)
+	}
+}
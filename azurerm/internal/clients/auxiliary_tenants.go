@@ -0,0 +1,206 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+)
+
+// maxAuxiliaryTenantsPerRequest is the maximum number of Auxiliary Tenants which Azure Resource Manager
+// supports within the `x-ms-authorization-auxiliary` header for a single request
+const maxAuxiliaryTenantsPerRequest = 3
+
+const headerAuxiliaryAuthorization = "x-ms-authorization-auxiliary"
+
+var authorizationUriTenantRegex = regexp.MustCompile(`authorization_uri="[^"]*/([0-9a-fA-F-]{36})"`)
+
+// auxiliaryTenantsAuthorizer is an autorest.Authorizer which authorizes requests using the Primary Tenant and
+// attaches tokens for any Auxiliary Tenants required by the request - determined from the Resource IDs
+// specified using `common.WithAuxiliaryResourceIDs`. This allows more Auxiliary Tenants to be configured
+// than Azure supports for a single request, since tokens are only requested (and cached) when required.
+type auxiliaryTenantsAuthorizer struct {
+	primary            autorest.Authorizer
+	auxiliaryTenantIds []string
+
+	// tenantAuthorizer returns an Authorizer for the specified Tenant
+	tenantAuthorizer func(tenantId string) (autorest.Authorizer, error)
+
+	// subscriptionTenant returns the ID of the Tenant which the specified Subscription belongs to
+	subscriptionTenant func(ctx context.Context, subscriptionId string) (string, error)
+
+	lock                sync.Mutex
+	tenantAuthorizers   map[string]autorest.Authorizer
+	subscriptionTenants map[string]string
+}
+
+func newAuxiliaryTenantsAuthorizer(primary autorest.Authorizer, auxiliaryTenantIds []string, tenantAuthorizer func(tenantId string) (autorest.Authorizer, error), subscriptionTenant func(ctx context.Context, subscriptionId string) (string, error)) *auxiliaryTenantsAuthorizer {
+	return &auxiliaryTenantsAuthorizer{
+		primary:             primary,
+		auxiliaryTenantIds:  auxiliaryTenantIds,
+		tenantAuthorizer:    tenantAuthorizer,
+		subscriptionTenant:  subscriptionTenant,
+		tenantAuthorizers:   make(map[string]autorest.Authorizer),
+		subscriptionTenants: make(map[string]string),
+	}
+}
+
+func (a *auxiliaryTenantsAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := a.primary.WithAuthorization()(p).Prepare(r)
+			if err != nil {
+				return r, err
+			}
+
+			tenantIds, err := a.tenantsForRequest(r.Context())
+			if err != nil {
+				return r, err
+			}
+			if len(tenantIds) == 0 {
+				return r, nil
+			}
+
+			tokens := make([]string, 0)
+			for _, tenantId := range tenantIds {
+				token, err := a.tokenForTenant(r.Context(), tenantId)
+				if err != nil {
+					return r, fmt.Errorf("obtaining a token for the Auxiliary Tenant %q: %+v", tenantId, err)
+				}
+				tokens = append(tokens, token)
+			}
+
+			return autorest.Prepare(r, autorest.WithHeader(headerAuxiliaryAuthorization, strings.Join(tokens, ", ")))
+		})
+	}
+}
+
+// tenantsForRequest returns the Auxiliary Tenants which a token is required for
+func (a *auxiliaryTenantsAuthorizer) tenantsForRequest(ctx context.Context) ([]string, error) {
+	resourceIds := common.AuxiliaryResourceIDs(ctx)
+	if len(resourceIds) == 0 {
+		// when no Resource IDs are specified, all of the Auxiliary Tenants are used when possible (as before)
+		if len(a.auxiliaryTenantIds) <= maxAuxiliaryTenantsPerRequest {
+			return a.auxiliaryTenantIds, nil
+		}
+		return []string{}, nil
+	}
+
+	output := make([]string, 0)
+	for _, resourceId := range resourceIds {
+		subscriptionId := subscriptionIdFromResourceId(resourceId)
+		if subscriptionId == "" {
+			continue
+		}
+
+		tenantId, err := a.tenantForSubscription(ctx, subscriptionId)
+		if err != nil {
+			return nil, fmt.Errorf("determining the Tenant for Subscription %q (referenced by %q): %+v", subscriptionId, resourceId, err)
+		}
+
+		if !containsTenant(a.auxiliaryTenantIds, tenantId) || containsTenant(output, tenantId) {
+			continue
+		}
+		output = append(output, tenantId)
+	}
+
+	if len(output) > maxAuxiliaryTenantsPerRequest {
+		return nil, fmt.Errorf("a single request can only reference Resources in up to %d Auxiliary Tenants but got %d (%s)", maxAuxiliaryTenantsPerRequest, len(output), strings.Join(output, ", "))
+	}
+
+	return output, nil
+}
+
+func (a *auxiliaryTenantsAuthorizer) tenantForSubscription(ctx context.Context, subscriptionId string) (string, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	key := strings.ToLower(subscriptionId)
+	if v, ok := a.subscriptionTenants[key]; ok {
+		return v, nil
+	}
+
+	tenantId, err := a.subscriptionTenant(ctx, subscriptionId)
+	if err != nil {
+		return "", err
+	}
+
+	a.subscriptionTenants[key] = tenantId
+	return tenantId, nil
+}
+
+func (a *auxiliaryTenantsAuthorizer) tokenForTenant(ctx context.Context, tenantId string) (string, error) {
+	a.lock.Lock()
+	authorizer, ok := a.tenantAuthorizers[tenantId]
+	if !ok {
+		log.Printf("[DEBUG] Building Authorizer for Auxiliary Tenant %q..", tenantId)
+		var err error
+		authorizer, err = a.tenantAuthorizer(tenantId)
+		if err != nil {
+			a.lock.Unlock()
+			return "", err
+		}
+		a.tenantAuthorizers[tenantId] = authorizer
+	}
+	a.lock.Unlock()
+
+	// the Authorizer for the Tenant refreshes the token (when required) and sets the Authorization header
+	req, err := autorest.Prepare((&http.Request{URL: &url.URL{}}).WithContext(ctx), authorizer.WithAuthorization())
+	if err != nil {
+		return "", err
+	}
+
+	return req.Header.Get("Authorization"), nil
+}
+
+// subscriptionTenantFromChallenge returns a function which determines the Tenant a Subscription belongs to from
+// the challenge returned by Azure Resource Manager for an unauthenticated request - which doesn't require access
+func subscriptionTenantFromChallenge(resourceManagerEndpoint string, sender autorest.Sender) func(ctx context.Context, subscriptionId string) (string, error) {
+	return func(ctx context.Context, subscriptionId string) (string, error) {
+		uri := fmt.Sprintf("%s/subscriptions/%s?api-version=2020-01-01", strings.TrimSuffix(resourceManagerEndpoint, "/"), url.PathEscape(subscriptionId))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+		if err != nil {
+			return "", fmt.Errorf("building request: %+v", err)
+		}
+
+		resp, err := sender.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("retrieving Subscription: %+v", err)
+		}
+		defer resp.Body.Close()
+
+		challenge := resp.Header.Get("WWW-Authenticate")
+		matches := authorizationUriTenantRegex.FindStringSubmatch(challenge)
+		if len(matches) != 2 {
+			return "", fmt.Errorf("the Tenant couldn't be determined from the challenge %q (Status %d)", challenge, resp.StatusCode)
+		}
+
+		return matches[1], nil
+	}
+}
+
+func subscriptionIdFromResourceId(input string) string {
+	segments := strings.Split(strings.Trim(input, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if strings.EqualFold(segments[i], "subscriptions") {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
+
+func containsTenant(input []string, tenantId string) bool {
+	for _, v := range input {
+		if strings.EqualFold(v, tenantId) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+)
+
+type testTenantAuthorizers struct {
+	built map[string]int
+}
+
+func (t *testTenantAuthorizers) authorizer(tenantId string) (autorest.Authorizer, error) {
+	t.built[tenantId]++
+	return autorest.NewAPIKeyAuthorizerWithHeaders(map[string]interface{}{
+		"Authorization": fmt.Sprintf("Bearer token-for-%s", tenantId),
+	}), nil
+}
+
+func testAuxiliaryTenantsAuthorizer(auxiliaryTenantIds []string, authorizers *testTenantAuthorizers, lookups *int) *auxiliaryTenantsAuthorizer {
+	primary := autorest.NewAPIKeyAuthorizerWithHeaders(map[string]interface{}{
+		"Authorization": "Bearer token-for-primary",
+	})
+	subscriptionTenant := func(_ context.Context, subscriptionId string) (string, error) {
+		*lookups++
+		// for testing purposes Subscription `sub-N` belongs to Tenant `tenant-N`
+		return strings.Replace(subscriptionId, "sub-", "tenant-", 1), nil
+	}
+	return newAuxiliaryTenantsAuthorizer(primary, auxiliaryTenantIds, authorizers.authorizer, subscriptionTenant)
+}
+
+func authorizeRequest(authorizer autorest.Authorizer, ctx context.Context) (*http.Request, error) {
+	return autorest.Prepare((&http.Request{URL: &url.URL{}}).WithContext(ctx), authorizer.WithAuthorization())
+}
+
+func TestAuxiliaryTenantsAuthorizer(t *testing.T) {
+	tenants := make([]string, 0)
+	for i := 1; i <= 12; i++ {
+		tenants = append(tenants, fmt.Sprintf("tenant-%d", i))
+	}
+
+	testData := []struct {
+		name        string
+		tenants     []string
+		resourceIds []string
+		expected    string
+		shouldError bool
+	}{
+		{
+			name:     "up to 3 tenants without resource ids",
+			tenants:  tenants[0:3],
+			expected: "Bearer token-for-tenant-1, Bearer token-for-tenant-2, Bearer token-for-tenant-3",
+		},
+		{
+			name:     "more than 3 tenants without resource ids",
+			tenants:  tenants,
+			expected: "",
+		},
+		{
+			name:    "resource ids in auxiliary tenants",
+			tenants: tenants,
+			resourceIds: []string{
+				"/subscriptions/sub-10/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+				"/subscriptions/sub-7/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+				"/subscriptions/sub-10/resourceGroups/group2/providers/Microsoft.Network/virtualNetworks/network2",
+			},
+			expected: "Bearer token-for-tenant-10, Bearer token-for-tenant-7",
+		},
+		{
+			name:    "resource id in an unconfigured tenant",
+			tenants: tenants,
+			resourceIds: []string{
+				"/subscriptions/sub-99/resourceGroups/group1",
+			},
+			expected: "",
+		},
+		{
+			name:    "too many auxiliary tenants for a single request",
+			tenants: tenants,
+			resourceIds: []string{
+				"/subscriptions/sub-1/resourceGroups/group1",
+				"/subscriptions/sub-2/resourceGroups/group1",
+				"/subscriptions/sub-3/resourceGroups/group1",
+				"/subscriptions/sub-4/resourceGroups/group1",
+			},
+			shouldError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		authorizers := &testTenantAuthorizers{built: make(map[string]int)}
+		lookups := 0
+		authorizer := testAuxiliaryTenantsAuthorizer(v.tenants, authorizers, &lookups)
+
+		ctx := common.WithAuxiliaryResourceIDs(context.TODO(), v.resourceIds...)
+		req, err := authorizeRequest(authorizer, ctx)
+		if v.shouldError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("authorizing request: %+v", err)
+		}
+
+		if actual := req.Header.Get("Authorization"); actual != "Bearer token-for-primary" {
+			t.Fatalf("expected the primary token but got %q", actual)
+		}
+		if actual := req.Header.Get(headerAuxiliaryAuthorization); actual != v.expected {
+			t.Fatalf("expected the auxiliary header %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestAuxiliaryTenantsAuthorizerCachesTokensAndTenants(t *testing.T) {
+	authorizers := &testTenantAuthorizers{built: make(map[string]int)}
+	lookups := 0
+	authorizer := testAuxiliaryTenantsAuthorizer([]string{"tenant-1", "tenant-2", "tenant-3", "tenant-4"}, authorizers, &lookups)
+
+	ctx := common.WithAuxiliaryResourceIDs(context.TODO(), "/subscriptions/sub-4/resourceGroups/group1")
+	for i := 0; i < 3; i++ {
+		if _, err := authorizeRequest(authorizer, ctx); err != nil {
+			t.Fatalf("authorizing request: %+v", err)
+		}
+	}
+
+	if lookups != 1 {
+		t.Fatalf("expected the Tenant for the Subscription to be looked up once but got %d", lookups)
+	}
+	if len(authorizers.built) != 1 || authorizers.built["tenant-4"] != 1 {
+		t.Fatalf("expected a single Authorizer to be built for `tenant-4` but got %+v", authorizers.built)
+	}
+}
+
+func TestSubscriptionTenantFromChallenge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subscriptions/00000000-0000-0000-0000-000000000000" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Bearer authorization_uri="https://login.windows.net/11111111-1111-1111-1111-111111111111", error="invalid_token", error_description="The authentication failed because of missing 'Authorization' header."`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	lookup := subscriptionTenantFromChallenge(server.URL, server.Client())
+
+	actual, err := lookup(context.TODO(), "00000000-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatalf("determining tenant: %+v", err)
+	}
+	if actual != "11111111-1111-1111-1111-111111111111" {
+		t.Fatalf("expected the tenant `11111111-1111-1111-1111-111111111111` but got %q", actual)
+	}
+
+	if _, err := lookup(context.TODO(), "does-not-exist"); err == nil {
+		t.Fatalf("expected an error when no challenge was returned but didn't get one")
+	}
+}
+
+func TestValidateAuxiliaryTenants(t *testing.T) {
+	auxiliaryTenantIds := []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222", "33333333-3333-3333-3333-333333333333", "44444444-4444-4444-4444-444444444444"}
+	testData := []struct {
+		name    string
+		builder ClientBuilder
+		valid   bool
+	}{
+		{
+			name: "no auxiliary tenants",
+			builder: ClientBuilder{
+				AuthConfig: &authentication.Config{},
+			},
+			valid: true,
+		},
+		{
+			name: "service principal",
+			builder: ClientBuilder{
+				AuthConfig:         &authentication.Config{AuthenticatedAsAServicePrincipal: true},
+				AuxiliaryTenantIDs: auxiliaryTenantIds,
+			},
+			valid: true,
+		},
+		{
+			name: "oidc",
+			builder: ClientBuilder{
+				AuthConfig:         &authentication.Config{},
+				OIDCConfig:         &OIDCConfig{Token: "abc"},
+				AuxiliaryTenantIDs: auxiliaryTenantIds,
+			},
+			valid: true,
+		},
+		{
+			name: "azure cli or managed identity",
+			builder: ClientBuilder{
+				AuthConfig:         &authentication.Config{},
+				AuxiliaryTenantIDs: auxiliaryTenantIds,
+			},
+			valid: false,
+		},
+		{
+			name: "empty tenant id",
+			builder: ClientBuilder{
+				AuthConfig:         &authentication.Config{AuthenticatedAsAServicePrincipal: true},
+				AuxiliaryTenantIDs: []string{"11111111-1111-1111-1111-111111111111", ""},
+			},
+			valid: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := validateAuxiliaryTenants(v.builder)
+		if v.valid && err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !v.valid && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+	}
+}
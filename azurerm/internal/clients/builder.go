@@ -7,9 +7,12 @@ import (
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/authentication"
 	"github.com/hashicorp/go-azure-helpers/sender"
+	"github.com/hashicorp/go-multierror"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/auditlog"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/location"
@@ -26,6 +29,29 @@ type ClientBuilder struct {
 	StorageUseAzureAD           bool
 	TerraformVersion            string
 	Features                    features.UserFeatures
+
+	// OIDCConfig specifies that Workload Identity Federation (OIDC) should be used to authenticate
+	// as the Service Principal defined in the AuthConfig, rather than its Authentication Method
+	OIDCConfig *OIDCConfig
+
+	// AuxiliaryTenantIDs specifies Auxiliary Tenants whose tokens are obtained when first required by a request,
+	// rather than sent with every request - allowing more than the 3 Auxiliary Tenants supported by Azure
+	// Resource Manager for a single request (and the AuthConfig) to be used
+	AuxiliaryTenantIDs []string
+
+	// CorrelationRequestIDPerOperation specifies that a separate Correlation Request ID should be used for each
+	// Operation (e.g. the Create of a Resource), rather than one for all requests made by the Provider
+	CorrelationRequestIDPerOperation bool
+
+	// PermissionPreflight specifies that the permissions required to provision each Resource should be checked during the plan
+	PermissionPreflight bool
+
+	// APIProfile specifies the API Profile (e.g. `2019-03-01-hybrid`) whose API Versions should be used,
+	// which allows a subset of the Provider to be used against Azure Stack Hub
+	APIProfile string
+
+	// AuditLog specifies that each request made to (and response from) the Azure API's should be recorded
+	AuditLog *auditlog.Logger
 }
 
 const azureStackEnvironmentError = `
@@ -35,29 +61,100 @@ API and feature availability.
 
 Terraform instead offers a separate "azurestack" provider which supports the functionality
 and API's available in Azure Stack via Azure Stack Profiles.
+
+Alternatively a subset of the Data Sources and Resources within the AzureRM Provider can be
+used against Azure Stack Hub by specifying an API Profile (e.g. "2019-03-01-hybrid") using the
+Environment Variable "ARM_API_PROFILE".
 `
 
 func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
-	// point folks towards the separate Azure Stack Provider when using Azure Stack
-	if strings.EqualFold(builder.AuthConfig.Environment, "AZURESTACKCLOUD") {
-		return nil, fmt.Errorf(azureStackEnvironmentError)
+	// the Authentication Methods supported by go-azure-helpers are validated when building the AuthConfig,
+	// however OIDC isn't - so this needs to be validated here, before any requests are made
+	if builder.OIDCConfig != nil {
+		if err := validateOIDCAuthConfig(*builder.AuthConfig, *builder.OIDCConfig); err != nil {
+			return nil, err
+		}
 	}
-
-	isAzureStack, err := authentication.IsEnvironmentAzureStack(ctx, builder.AuthConfig.MetadataHost, builder.AuthConfig.Environment)
-	if err != nil {
-		return nil, fmt.Errorf("unable to determine if environment is Azure Stack: %+v", err)
+	if err := validateAuxiliaryTenants(builder); err != nil {
+		return nil, err
 	}
-	if isAzureStack {
-		return nil, fmt.Errorf(azureStackEnvironmentError)
+
+	if builder.APIProfile != "" {
+		if err := validateAPIProfile(builder.APIProfile, builder.AuthConfig.MetadataHost); err != nil {
+			return nil, err
+		}
+		log.Printf("[DEBUG] Using the API Profile %q", builder.APIProfile)
+	} else {
+		// point folks towards the separate Azure Stack Provider when using Azure Stack
+		if strings.EqualFold(builder.AuthConfig.Environment, "AZURESTACKCLOUD") {
+			return nil, fmt.Errorf(azureStackEnvironmentError)
+		}
+
+		isAzureStack, err := authentication.IsEnvironmentAzureStack(ctx, builder.AuthConfig.MetadataHost, builder.AuthConfig.Environment)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine if environment is Azure Stack: %+v", err)
+		}
+		if isAzureStack {
+			return nil, fmt.Errorf(azureStackEnvironmentError)
+		}
 	}
 
+	// when using Azure Stack Hub the endpoints are retrieved from the Metadata Host
 	env, err := authentication.AzureEnvironmentByNameFromEndpoint(ctx, builder.AuthConfig.MetadataHost, builder.AuthConfig.Environment)
 	if err != nil {
 		return nil, fmt.Errorf("unable to find environment %q from endpoint %q: %+v", builder.AuthConfig.Environment, builder.AuthConfig.MetadataHost, err)
 	}
 
+	authConfig := *builder.AuthConfig
+	var authorizers authorizerSource = authConfig
+	if builder.OIDCConfig != nil {
+		log.Printf("[DEBUG] Using OIDC for Authentication")
+		authorizers = oidcAuth{
+			clientId: authConfig.ClientID,
+			config:   *builder.OIDCConfig,
+		}
+	}
+
+	oauthConfig, err := authConfig.BuildOAuthConfig(env.ActiveDirectoryEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("building OAuth Config: %+v", err)
+	}
+
+	// OAuthConfigForTenant returns a pointer, which can be nil.
+	if oauthConfig == nil {
+		return nil, fmt.Errorf("unable to configure OAuthConfig for tenant %s", authConfig.TenantID)
+	}
+
+	sender := sender.BuildSender("AzureRM")
+
+	// the tokens for each audience are shared by all of the clients using that audience, and are refreshed
+	// in the background until the context is done
+	tokens := newTokenCache(ctx, authorizers, sender)
+
+	auth, err := buildEndpointAuthorizers(tokens, oauthConfig, *env)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(builder.AuxiliaryTenantIDs) > 0 {
+		tenantAuthorizer := func(tenantId string) (autorest.Authorizer, error) {
+			tenantOAuthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, tenantId)
+			if err != nil {
+				return nil, fmt.Errorf("building OAuth Config for Tenant %q: %+v", tenantId, err)
+			}
+			return tokens.authorizer(&authentication.OAuthConfig{OAuth: tenantOAuthConfig}, env.TokenAudience)
+		}
+		subscriptionTenant := subscriptionTenantFromChallenge(env.ResourceManagerEndpoint, sender)
+		auth.resourceManager = newAuxiliaryTenantsAuthorizer(auth.resourceManager, builder.AuxiliaryTenantIDs, tenantAuthorizer, subscriptionTenant)
+	}
+
+	if builder.OIDCConfig != nil {
+		authConfig.AuthenticatedAsAServicePrincipal = true
+		authConfig.GetAuthenticatedObjectID = servicePrincipalObjectIDFunc(env.GraphEndpoint, authConfig.TenantID, authConfig.ClientID, sender, auth.graph)
+	}
+
 	// client declarations:
-	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, *env, builder.SkipProviderRegistration)
+	account, err := NewResourceManagerAccount(ctx, authConfig, *env, builder.SkipProviderRegistration)
 	if err != nil {
 		return nil, fmt.Errorf("Error building account: %+v", err)
 	}
@@ -66,34 +163,69 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		Account: account,
 	}
 
-	oauthConfig, err := builder.AuthConfig.BuildOAuthConfig(env.ActiveDirectoryEndpoint)
-	if err != nil {
-		return nil, fmt.Errorf("building OAuth Config: %+v", err)
+	o := &common.ClientOptions{
+		SubscriptionId:              authConfig.SubscriptionID,
+		TenantID:                    authConfig.TenantID,
+		PartnerId:                   builder.PartnerId,
+		TerraformVersion:            builder.TerraformVersion,
+		GraphAuthorizer:             auth.graph,
+		GraphEndpoint:               env.GraphEndpoint,
+		KeyVaultAuthorizer:          auth.keyVault,
+		ResourceManagerAuthorizer:   auth.resourceManager,
+		ResourceManagerEndpoint:     env.ResourceManagerEndpoint,
+		StorageAuthorizer:           auth.storage,
+		SynapseAuthorizer:           auth.synapse,
+		SkipProviderReg:             builder.SkipProviderRegistration,
+		DisableCorrelationRequestID: builder.DisableCorrelationRequestID,
+		CustomCorrelationRequestID:  builder.CustomCorrelationRequestID,
+		DisableTerraformPartnerID:   builder.DisableTerraformPartnerID,
+		Environment:                 *env,
+		Features:                    builder.Features,
+		StorageUseAzureAD:           builder.StorageUseAzureAD,
+		APIProfile:                  builder.APIProfile,
+		AuditLog:                    builder.AuditLog,
 	}
 
-	// OAuthConfigForTenant returns a pointer, which can be nil.
-	if oauthConfig == nil {
-		return nil, fmt.Errorf("unable to configure OAuthConfig for tenant %s", builder.AuthConfig.TenantID)
+	if err := client.Build(ctx, o); err != nil {
+		return nil, fmt.Errorf("error building Client: %+v", err)
+	}
+	client.PermissionPreflight = builder.PermissionPreflight
+	if !builder.DisableCorrelationRequestID {
+		client.CorrelationRequestID = common.RunCorrelationRequestID(builder.CustomCorrelationRequestID)
+		client.CorrelationRequestIDPerOperation = builder.CorrelationRequestIDPerOperation
 	}
 
-	sender := sender.BuildSender("AzureRM")
+	if features.EnhancedValidationEnabled() {
+		location.CacheSupportedLocations(ctx, env)
+		resourceproviders.CacheSupportedProviders(ctx, client.Resource.ProvidersClient)
+	}
 
+	return &client, nil
+}
+
+type endpointAuthorizers struct {
+	graph           autorest.Authorizer
+	keyVault        *autorest.BearerAuthorizerCallback
+	resourceManager autorest.Authorizer
+	storage         autorest.Authorizer
+	synapse         autorest.Authorizer
+}
+
+func buildEndpointAuthorizers(tokens *tokenCache, oauthConfig *authentication.OAuthConfig, env azure.Environment) (*endpointAuthorizers, error) {
 	// Resource Manager endpoints
-	endpoint := env.ResourceManagerEndpoint
-	auth, err := builder.AuthConfig.GetAuthorizationToken(sender, oauthConfig, env.TokenAudience)
+	auth, err := tokens.authorizer(oauthConfig, env.TokenAudience)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get authorization token for resource manager: %+v", err)
 	}
 
 	// Graph Endpoints
-	graphEndpoint := env.GraphEndpoint
-	graphAuth, err := builder.AuthConfig.GetAuthorizationToken(sender, oauthConfig, graphEndpoint)
+	graphAuth, err := tokens.authorizer(oauthConfig, env.GraphEndpoint)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get authorization token for graph endpoints: %+v", err)
 	}
 
 	// Storage Endpoints
-	storageAuth, err := builder.AuthConfig.GetAuthorizationToken(sender, oauthConfig, env.ResourceIdentifiers.Storage)
+	storageAuth, err := tokens.authorizer(oauthConfig, env.ResourceIdentifiers.Storage)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get authorization token for storage endpoints: %+v", err)
 	}
@@ -101,7 +233,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	// Synapse Endpoints
 	var synapseAuth autorest.Authorizer = nil
 	if env.ResourceIdentifiers.Synapse != azure.NotAvailable {
-		synapseAuth, err = builder.AuthConfig.GetAuthorizationToken(sender, oauthConfig, env.ResourceIdentifiers.Synapse)
+		synapseAuth, err = tokens.authorizer(oauthConfig, env.ResourceIdentifiers.Synapse)
 		if err != nil {
 			return nil, fmt.Errorf("unable to get authorization token for synapse endpoints: %+v", err)
 		}
@@ -110,37 +242,67 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	// Key Vault Endpoints
-	keyVaultAuth := builder.AuthConfig.BearerAuthorizerCallback(sender, oauthConfig)
+	keyVaultAuth := tokens.bearerAuthorizerCallback(oauthConfig)
 
-	o := &common.ClientOptions{
-		SubscriptionId:              builder.AuthConfig.SubscriptionID,
-		TenantID:                    builder.AuthConfig.TenantID,
-		PartnerId:                   builder.PartnerId,
-		TerraformVersion:            builder.TerraformVersion,
-		GraphAuthorizer:             graphAuth,
-		GraphEndpoint:               graphEndpoint,
-		KeyVaultAuthorizer:          keyVaultAuth,
-		ResourceManagerAuthorizer:   auth,
-		ResourceManagerEndpoint:     endpoint,
-		StorageAuthorizer:           storageAuth,
-		SynapseAuthorizer:           synapseAuth,
-		SkipProviderReg:             builder.SkipProviderRegistration,
-		DisableCorrelationRequestID: builder.DisableCorrelationRequestID,
-		CustomCorrelationRequestID:  builder.CustomCorrelationRequestID,
-		DisableTerraformPartnerID:   builder.DisableTerraformPartnerID,
-		Environment:                 *env,
-		Features:                    builder.Features,
-		StorageUseAzureAD:           builder.StorageUseAzureAD,
+	return &endpointAuthorizers{
+		graph:           graphAuth,
+		keyVault:        keyVaultAuth,
+		resourceManager: auth,
+		storage:         storageAuth,
+		synapse:         synapseAuth,
+	}, nil
+}
+
+// validateOIDCAuthConfig performs the same validation as go-azure-helpers does for the other
+// Service Principal Authentication Methods, in addition to validating the OIDCConfig itself
+func validateOIDCAuthConfig(config authentication.Config, oidcConfig OIDCConfig) error {
+	var err *multierror.Error
+
+	fmtErrorMessage := "A %s must be configured when authenticating as a Service Principal using OIDC."
+
+	if config.SubscriptionID == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Subscription ID"))
+	}
+	if config.ClientID == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Client ID"))
+	}
+	if config.TenantID == "" {
+		err = multierror.Append(err, fmt.Errorf(fmtErrorMessage, "Tenant ID"))
+	}
+	if validateErr := oidcConfig.Validate(); validateErr != nil {
+		err = multierror.Append(err, validateErr)
 	}
 
-	if err := client.Build(ctx, o); err != nil {
-		return nil, fmt.Errorf("error building Client: %+v", err)
+	return err.ErrorOrNil()
+}
+
+// validateAuxiliaryTenants ensures that a Service Principal is used when Auxiliary Tenants are obtained on-demand,
+// since a token for another Tenant can't be obtained when authenticating using the Azure CLI or a Managed Identity
+func validateAuxiliaryTenants(builder ClientBuilder) error {
+	if len(builder.AuxiliaryTenantIDs) == 0 {
+		return nil
 	}
 
-	if features.EnhancedValidationEnabled() {
-		location.CacheSupportedLocations(ctx, env)
-		resourceproviders.CacheSupportedProviders(ctx, client.Resource.ProvidersClient)
+	if builder.OIDCConfig == nil && !builder.AuthConfig.AuthenticatedAsAServicePrincipal {
+		return fmt.Errorf("`auxiliary_tenant_ids` can only be used when authenticating as a Service Principal (using a Client Secret, Client Certificate or OIDC)")
 	}
 
-	return &client, nil
+	for _, tenantId := range builder.AuxiliaryTenantIDs {
+		if strings.TrimSpace(tenantId) == "" {
+			return fmt.Errorf("`auxiliary_tenant_ids` cannot contain an empty Tenant ID")
+		}
+	}
+
+	return nil
+}
+
+func validateAPIProfile(profile, metadataHost string) error {
+	if !common.IsAPIProfileSupported(profile) {
+		return fmt.Errorf("the API Profile %q is not supported - supported values are: %s", profile, strings.Join(common.SupportedAPIProfiles(), ", "))
+	}
+	if metadataHost == "" {
+		return fmt.Errorf("a `metadata_host` must be specified when using an API Profile, since this is used to retrieve the endpoints for Azure Stack Hub")
+	}
+
+	return nil
 }
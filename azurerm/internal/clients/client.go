@@ -106,6 +106,14 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// PermissionPreflight specifies whether the permissions required to provision each Resource should be checked during the plan
+	PermissionPreflight bool
+
+	// CorrelationRequestID is the Correlation Request ID used for this Terraform run, from which the Correlation Request
+	// ID for each Operation is derived when CorrelationRequestIDPerOperation is enabled
+	CorrelationRequestID             string
+	CorrelationRequestIDPerOperation bool
+
 	Advisor               *advisor.Client
 	AnalysisServices      *analysisServices.Client
 	ApiManagement         *apiManagement.Client
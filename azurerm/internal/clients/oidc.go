@@ -0,0 +1,200 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+)
+
+// oidcTokenAudience is the audience which Azure Active Directory requires for a Federated (OIDC) token
+const oidcTokenAudience = "api://AzureADTokenExchange"
+
+// OIDCConfig configures authenticating as a Service Principal using Workload Identity Federation, where
+// an OIDC token issued by a trusted Identity Provider (for example GitHub Actions) is exchanged for
+// an Access Token - rather than using a long-lived Client Secret or Certificate.
+//
+// One of `Token`, `TokenFilePath` or both `RequestURL` and `RequestToken` must be specified.
+type OIDCConfig struct {
+	// Token is the OIDC token itself
+	Token string
+
+	// TokenFilePath is the path to a file containing the OIDC token, which is re-read each time
+	// an Access Token is requested, since the token within the file may be rotated
+	TokenFilePath string
+
+	// RequestURL is the URL used to request an OIDC token (e.g. `ACTIONS_ID_TOKEN_REQUEST_URL` in GitHub Actions)
+	RequestURL string
+
+	// RequestToken is the Bearer token used to authenticate when requesting an OIDC token from the RequestURL
+	RequestToken string
+}
+
+// Validate ensures that the OIDCConfig specifies where the OIDC token should be obtained from
+func (c OIDCConfig) Validate() error {
+	if c.Token != "" || c.TokenFilePath != "" {
+		return nil
+	}
+
+	if c.RequestURL != "" && c.RequestToken != "" {
+		return nil
+	}
+
+	return fmt.Errorf("one of `oidc_token`, `oidc_token_file_path` or both `oidc_request_url` and `oidc_request_token` must be specified when authenticating using OIDC")
+}
+
+// authorizerSource builds the Authorizers used to authenticate against each Endpoint, this is
+// implemented by both `authentication.Config` and `oidcAuth`
+type authorizerSource interface {
+	GetAuthorizationToken(sender autorest.Sender, oauthConfig *authentication.OAuthConfig, endpoint string) (autorest.Authorizer, error)
+}
+
+var _ authorizerSource = authentication.Config{}
+var _ authorizerSource = oidcAuth{}
+
+type oidcAuth struct {
+	clientId string
+	config   OIDCConfig
+}
+
+func (a oidcAuth) GetAuthorizationToken(sender autorest.Sender, oauthConfig *authentication.OAuthConfig, endpoint string) (autorest.Authorizer, error) {
+	if oauthConfig == nil || oauthConfig.OAuth == nil {
+		return nil, fmt.Errorf("getting Authorization Token for OIDC auth: an OAuth token wasn't configured correctly")
+	}
+
+	secret := &federatedTokenSecret{
+		config: a.config,
+		sender: sender,
+	}
+	spt, err := adal.NewServicePrincipalTokenWithSecret(*oauthConfig.OAuth, a.clientId, endpoint, secret)
+	if err != nil {
+		return nil, err
+	}
+	spt.SetSender(sender)
+
+	return autorest.NewBearerAuthorizer(spt), nil
+}
+
+// federatedTokenSecret implements adal.ServicePrincipalSecret, sending the OIDC token as the Client Assertion
+type federatedTokenSecret struct {
+	config OIDCConfig
+	sender autorest.Sender
+}
+
+// SetAuthenticationValues is a method of the interface adal.ServicePrincipalSecret
+func (s *federatedTokenSecret) SetAuthenticationValues(_ *adal.ServicePrincipalToken, v *url.Values) error {
+	// the OIDC token is obtained each time, since these are short-lived (and may be rotated)
+	token, err := s.federatedToken()
+	if err != nil {
+		return fmt.Errorf("obtaining OIDC token: %+v", err)
+	}
+
+	v.Set("client_assertion", token)
+	v.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, the OIDC token is intentionally not output
+func (s federatedTokenSecret) MarshalJSON() ([]byte, error) {
+	return nil, fmt.Errorf("marshalling a federatedTokenSecret is not supported")
+}
+
+func (s *federatedTokenSecret) federatedToken() (string, error) {
+	if s.config.Token != "" {
+		return s.config.Token, nil
+	}
+
+	if s.config.TokenFilePath != "" {
+		contents, err := ioutil.ReadFile(s.config.TokenFilePath)
+		if err != nil {
+			return "", fmt.Errorf("reading OIDC token from %q: %+v", s.config.TokenFilePath, err)
+		}
+		return strings.TrimSpace(string(contents)), nil
+	}
+
+	return s.requestToken()
+}
+
+// requestToken requests an OIDC token from the RequestURL, using the format used by GitHub Actions
+func (s *federatedTokenSecret) requestToken() (string, error) {
+	requestUrl, err := url.Parse(s.config.RequestURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing OIDC request URL %q: %+v", s.config.RequestURL, err)
+	}
+	query := requestUrl.Query()
+	query.Set("audience", oidcTokenAudience)
+	requestUrl.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, requestUrl.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("building request: %+v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.RequestToken))
+
+	sender := s.sender
+	if sender == nil {
+		sender = http.DefaultClient
+	}
+	resp, err := sender.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting OIDC token: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading OIDC token response: %+v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting OIDC token: unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Value *string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("parsing OIDC token response: %+v", err)
+	}
+	if result.Value == nil || *result.Value == "" {
+		return "", fmt.Errorf("the OIDC token response didn't contain a token")
+	}
+
+	return *result.Value, nil
+}
+
+// servicePrincipalObjectIDFunc returns a function which looks up the Object ID of the Service Principal
+// with the specified Client ID, since this can't be determined by go-azure-helpers when using OIDC
+func servicePrincipalObjectIDFunc(graphEndpoint, tenantId, clientId string, sender autorest.Sender, graphAuth autorest.Authorizer) func(ctx context.Context) (string, error) {
+	objectId := ""
+	return func(ctx context.Context) (string, error) {
+		if objectId != "" {
+			return objectId, nil
+		}
+
+		client := graphrbac.NewServicePrincipalsClientWithBaseURI(graphEndpoint, tenantId)
+		client.Authorizer = graphAuth
+		client.Sender = sender
+
+		filter := fmt.Sprintf("appId eq '%s'", clientId)
+		result, err := client.List(ctx, filter)
+		if err != nil {
+			return "", fmt.Errorf("listing Service Principals: %+v", err)
+		}
+
+		if len(result.Values()) != 1 || result.Values()[0].ObjectID == nil {
+			return "", fmt.Errorf("expected a single Service Principal with the Client ID %q but got %d", clientId, len(result.Values()))
+		}
+
+		objectId = *result.Values()[0].ObjectID
+		return objectId, nil
+	}
+}
@@ -0,0 +1,272 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+)
+
+const (
+	testOIDCClientId     = "11111111-1111-1111-1111-111111111111"
+	testOIDCTenantId     = "22222222-2222-2222-2222-222222222222"
+	testOIDCToken        = "federated.id.token"
+	testOIDCRequestToken = "request-token"
+)
+
+// stubTokenEndpoint is a stub of both an OIDC Identity Provider (issuing ID tokens in the format used
+// by GitHub Actions) and the Azure Active Directory Token Endpoint (exchanging these for Access Tokens)
+type stubTokenEndpoint struct {
+	lock      sync.Mutex
+	resources []string
+}
+
+func (s *stubTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.URL.Path {
+	case "/oidc":
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer %s", testOIDCRequestToken) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("audience") != oidcTokenAudience {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"count": 1, "value": %q}`, testOIDCToken)
+
+	case fmt.Sprintf("/%s/oauth2/token", testOIDCTenantId):
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("client_id") != testOIDCClientId || r.PostForm.Get("client_assertion") != testOIDCToken || r.PostForm.Get("client_assertion_type") != "urn:ietf:params:oauth:client-assertion-type:jwt-bearer" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, `{"error": "invalid_client", "error_description": "unexpected credentials: %s"}`, r.PostForm.Encode())
+			return
+		}
+
+		resource := r.PostForm.Get("resource")
+		s.lock.Lock()
+		s.resources = append(s.resources, resource)
+		s.lock.Unlock()
+
+		fmt.Fprintf(w, `{"access_token": "access-token-for-%s", "token_type": "Bearer", "expires_in": "3600", "expires_on": "%d", "resource": %q}`, resource, 4102444800, resource)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testOIDCEnvironment(endpoint string) azure.Environment {
+	return azure.Environment{
+		ActiveDirectoryEndpoint: endpoint,
+		GraphEndpoint:           "https://graph.example.com/",
+		ResourceManagerEndpoint: "https://management.example.com/",
+		TokenAudience:           "https://management.example.com/",
+		ResourceIdentifiers: azure.ResourceIdentifier{
+			Storage: "https://storage.example.com/",
+			Synapse: "https://synapse.example.com/",
+		},
+	}
+}
+
+func authorizationHeader(t *testing.T, authorizer autorest.Authorizer) string {
+	req, err := autorest.Prepare(&http.Request{}, authorizer.WithAuthorization())
+	if err != nil {
+		t.Fatalf("authorizing request: %+v", err)
+	}
+	return req.Header.Get("Authorization")
+}
+
+func TestOIDCAuthEndpointAuthorizers(t *testing.T) {
+	stub := &stubTokenEndpoint{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	tokenFilePath := filepath.Join(t.TempDir(), "token")
+	if err := ioutil.WriteFile(tokenFilePath, []byte(testOIDCToken+"\n"), 0600); err != nil {
+		t.Fatalf("writing token file: %+v", err)
+	}
+
+	testData := map[string]OIDCConfig{
+		"token": {
+			Token: testOIDCToken,
+		},
+		"token file path": {
+			TokenFilePath: tokenFilePath,
+		},
+		"request url": {
+			RequestURL:   fmt.Sprintf("%s/oidc?api-version=2.0", server.URL),
+			RequestToken: testOIDCRequestToken,
+		},
+	}
+
+	for name, config := range testData {
+		t.Logf("[DEBUG] Testing %q..", name)
+
+		env := testOIDCEnvironment(server.URL)
+		oauthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, testOIDCTenantId)
+		if err != nil {
+			t.Fatalf("building OAuth Config: %+v", err)
+		}
+
+		source := oidcAuth{
+			clientId: testOIDCClientId,
+			config:   config,
+		}
+		auth, err := buildEndpointAuthorizers(newTokenCache(context.Background(), source, server.Client()), &authentication.OAuthConfig{OAuth: oauthConfig}, env)
+		if err != nil {
+			t.Fatalf("building authorizers: %+v", err)
+		}
+
+		expected := map[string]autorest.Authorizer{
+			env.TokenAudience:                    auth.resourceManager,
+			env.GraphEndpoint:                    auth.graph,
+			env.ResourceIdentifiers.Storage:      auth.storage,
+			env.ResourceIdentifiers.Synapse:      auth.synapse,
+			"https://vault.example.com/keyvault": mustGetAuthorizer(t, source, server.Client(), oauthConfig, "https://vault.example.com/keyvault"),
+		}
+		for resource, authorizer := range expected {
+			if actual := authorizationHeader(t, authorizer); actual != fmt.Sprintf("Bearer access-token-for-%s", resource) {
+				t.Fatalf("expected an Access Token for %q but got %q", resource, actual)
+			}
+		}
+	}
+}
+
+func mustGetAuthorizer(t *testing.T, source authorizerSource, sender autorest.Sender, oauthConfig *adal.OAuthConfig, resource string) autorest.Authorizer {
+	authorizer, err := source.GetAuthorizationToken(sender, &authentication.OAuthConfig{OAuth: oauthConfig}, resource)
+	if err != nil {
+		t.Fatalf("building authorizer for %q: %+v", resource, err)
+	}
+	return authorizer
+}
+
+func TestOIDCAuthInvalidRequestToken(t *testing.T) {
+	server := httptest.NewServer(&stubTokenEndpoint{})
+	defer server.Close()
+
+	oauthConfig, err := adal.NewOAuthConfig(server.URL, testOIDCTenantId)
+	if err != nil {
+		t.Fatalf("building OAuth Config: %+v", err)
+	}
+
+	source := oidcAuth{
+		clientId: testOIDCClientId,
+		config: OIDCConfig{
+			RequestURL:   fmt.Sprintf("%s/oidc", server.URL),
+			RequestToken: "invalid",
+		},
+	}
+	authorizer := mustGetAuthorizer(t, source, server.Client(), oauthConfig, "https://management.example.com/")
+	if _, err := autorest.Prepare(&http.Request{}, authorizer.WithAuthorization()); err == nil || !strings.Contains(err.Error(), "requesting OIDC token") {
+		t.Fatalf("expected an error requesting the OIDC token but got %+v", err)
+	}
+}
+
+func TestOIDCConfigValidate(t *testing.T) {
+	testData := []struct {
+		config OIDCConfig
+		valid  bool
+	}{
+		{
+			config: OIDCConfig{},
+			valid:  false,
+		},
+		{
+			config: OIDCConfig{Token: "abc"},
+			valid:  true,
+		},
+		{
+			config: OIDCConfig{TokenFilePath: filepath.Join(os.TempDir(), "token")},
+			valid:  true,
+		},
+		{
+			config: OIDCConfig{RequestURL: "https://example.com"},
+			valid:  false,
+		},
+		{
+			config: OIDCConfig{RequestURL: "https://example.com", RequestToken: "abc"},
+			valid:  true,
+		},
+	}
+
+	for _, v := range testData {
+		err := v.config.Validate()
+		if v.valid && err != nil {
+			t.Fatalf("expected %+v to be valid but got %+v", v.config, err)
+		}
+		if !v.valid && err == nil {
+			t.Fatalf("expected %+v to be invalid", v.config)
+		}
+	}
+}
+
+func TestValidateOIDCAuthConfig(t *testing.T) {
+	oidcConfig := OIDCConfig{Token: "abc"}
+	testData := []struct {
+		name   string
+		config authentication.Config
+		oidc   OIDCConfig
+		errors []string
+	}{
+		{
+			name: "valid",
+			config: authentication.Config{
+				ClientID:       "11111111-1111-1111-1111-111111111111",
+				SubscriptionID: "22222222-2222-2222-2222-222222222222",
+				TenantID:       "33333333-3333-3333-3333-333333333333",
+			},
+			oidc: oidcConfig,
+		},
+		{
+			name:   "empty",
+			config: authentication.Config{},
+			oidc:   OIDCConfig{},
+			errors: []string{"Subscription ID", "Client ID", "Tenant ID", "`oidc_token`"},
+		},
+		{
+			name: "missing tenant",
+			config: authentication.Config{
+				ClientID:       "11111111-1111-1111-1111-111111111111",
+				SubscriptionID: "22222222-2222-2222-2222-222222222222",
+			},
+			oidc:   oidcConfig,
+			errors: []string{"Tenant ID"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := validateOIDCAuthConfig(v.config, v.oidc)
+		if len(v.errors) == 0 {
+			if err != nil {
+				t.Fatalf("expected no error but got %+v", err)
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		for _, expected := range v.errors {
+			if !strings.Contains(err.Error(), expected) {
+				t.Fatalf("expected the error to contain %q but got %+v", expected, err)
+			}
+		}
+	}
+}
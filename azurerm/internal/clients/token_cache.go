@@ -0,0 +1,307 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+)
+
+// tokenRefreshBefore is how long before a token expires that it's proactively refreshed - which is longer than
+// the 5 minutes used by adal, so that long-running operations aren't polled using a token which is about to expire
+const tokenRefreshBefore = 15 * time.Minute
+
+// maxTokenThrottledRetries is the maximum number of times refreshing a token is retried when throttled by Azure AD
+const maxTokenThrottledRetries = 5
+
+// maxTokenThrottledRetryDelay is the maximum delay between retries when Azure AD doesn't specify a `Retry-After`
+const maxTokenThrottledRetryDelay = 30 * time.Second
+
+// refreshableToken is a token which can be refreshed, implemented by `adal.ServicePrincipalToken`
+type refreshableToken interface {
+	adal.OAuthTokenProvider
+	adal.RefresherWithContext
+	Token() adal.Token
+}
+
+// tokenCache builds and caches the Authorizers for each audience (e.g. Resource Manager, Graph or Storage) so that
+// a single token is shared by every client using that audience - which is proactively refreshed before it expires,
+// and whose refresh is retried when throttled by Azure AD
+type tokenCache struct {
+	// ctx is used to refresh tokens in the background, which stops once it's done (e.g. when the Provider is stopped)
+	ctx context.Context
+
+	source authorizerSource
+	sender autorest.Sender
+
+	refreshBefore time.Duration
+
+	// now, sleep and afterFunc can be overridden in tests
+	now       func() time.Time
+	sleep     func(ctx context.Context, duration time.Duration) error
+	afterFunc func(delay time.Duration, f func()) (stop func() bool)
+
+	lock        sync.Mutex
+	authorizers map[string]autorest.Authorizer
+}
+
+func newTokenCache(ctx context.Context, source authorizerSource, sender autorest.Sender) *tokenCache {
+	return &tokenCache{
+		ctx:           ctx,
+		source:        source,
+		sender:        sender,
+		refreshBefore: tokenRefreshBefore,
+		now:           time.Now,
+		sleep:         sleepWithContext,
+		afterFunc: func(delay time.Duration, f func()) func() bool {
+			return time.AfterFunc(delay, f).Stop
+		},
+		authorizers: make(map[string]autorest.Authorizer),
+	}
+}
+
+// authorizer returns the Authorizer for the specified audience, which is shared with all other callers
+func (c *tokenCache) authorizer(oauthConfig *authentication.OAuthConfig, audience string) (autorest.Authorizer, error) {
+	key := tokenCacheKey(oauthConfig, audience)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if v, ok := c.authorizers[key]; ok {
+		return v, nil
+	}
+
+	authorizer, err := c.source.GetAuthorizationToken(c.sender, oauthConfig, audience)
+	if err != nil {
+		return nil, err
+	}
+
+	if bearer, ok := authorizer.(*autorest.BearerAuthorizer); ok {
+		if token, ok := bearer.TokenProvider().(refreshableToken); ok {
+			authorizer = autorest.NewBearerAuthorizer(&cachedToken{
+				audience: audience,
+				token:    token,
+				cache:    c,
+			})
+		}
+	} else {
+		log.Printf("[DEBUG] Token Cache: the Authorizer for %q can't be cached - using it as-is", audience)
+	}
+
+	c.authorizers[key] = authorizer
+	return authorizer, nil
+}
+
+// bearerAuthorizerCallback returns a BearerAuthorizerCallback (used by Key Vault) whose tokens are cached per audience
+func (c *tokenCache) bearerAuthorizerCallback(oauthConfig *authentication.OAuthConfig) *autorest.BearerAuthorizerCallback {
+	return autorest.NewBearerAuthorizerCallback(c.sender, func(tenantID, resource string) (*autorest.BearerAuthorizer, error) {
+		// a BearerAuthorizer is only valid for the primary tenant
+		primaryOAuthConfig := &authentication.OAuthConfig{
+			OAuth: oauthConfig.OAuth,
+		}
+
+		auth, err := c.authorizer(primaryOAuthConfig, resource)
+		if err != nil {
+			return nil, err
+		}
+
+		cast, ok := auth.(*autorest.BearerAuthorizer)
+		if !ok {
+			return nil, fmt.Errorf("converting %+v to a BearerAuthorizer", auth)
+		}
+
+		return cast, nil
+	})
+}
+
+func tokenCacheKey(oauthConfig *authentication.OAuthConfig, audience string) string {
+	tokenEndpoint := ""
+	if oauthConfig != nil && oauthConfig.OAuth != nil {
+		tokenEndpoint = oauthConfig.OAuth.TokenEndpoint.String()
+	}
+	multiTenant := oauthConfig != nil && oauthConfig.MultiTenantOauth != nil
+	return fmt.Sprintf("%s|%s|%t", tokenEndpoint, audience, multiTenant)
+}
+
+type tokenMetrics struct {
+	requests         int
+	cacheHits        int
+	refreshes        int
+	throttledRetries int
+	failures         int
+}
+
+func (m tokenMetrics) String() string {
+	return fmt.Sprintf("%d requests, %d served from cache, %d refreshes, %d throttled retries, %d failures", m.requests, m.cacheHits, m.refreshes, m.throttledRetries, m.failures)
+}
+
+// cachedToken implements adal.OAuthTokenProvider and adal.RefresherWithContext, refreshing the
+// underlying token when it's due to expire within the refresh window of the tokenCache - both in the
+// background once the refresh window is reached, and when it's requested
+type cachedToken struct {
+	audience string
+	token    refreshableToken
+	cache    *tokenCache
+
+	lock    sync.Mutex
+	metrics tokenMetrics
+
+	// stopScheduledRefresh stops the pending background refresh, if any
+	stopScheduledRefresh func() bool
+}
+
+var _ adal.OAuthTokenProvider = &cachedToken{}
+var _ adal.RefresherWithContext = &cachedToken{}
+
+func (t *cachedToken) OAuthToken() string {
+	return t.token.OAuthToken()
+}
+
+func (t *cachedToken) EnsureFreshWithContext(ctx context.Context) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.metrics.requests++
+	if t.isFresh() {
+		t.metrics.cacheHits++
+		return nil
+	}
+
+	return t.refreshWithRetries(ctx, t.token.RefreshWithContext)
+}
+
+// isFresh returns whether the token doesn't expire within the refresh window
+func (t *cachedToken) isFresh() bool {
+	current := t.token.Token()
+	return !current.IsZero() && t.cache.now().Add(t.cache.refreshBefore).Before(current.Expires())
+}
+
+// scheduleRefresh schedules the token to be refreshed in the background once the refresh window is reached,
+// so that operations don't start with a token which is about to expire
+func (t *cachedToken) scheduleRefresh() {
+	if t.cache.ctx == nil {
+		return
+	}
+
+	if t.stopScheduledRefresh != nil {
+		t.stopScheduledRefresh()
+		t.stopScheduledRefresh = nil
+	}
+
+	delay := t.token.Token().Expires().Sub(t.cache.now()) - t.cache.refreshBefore
+	if delay <= 0 {
+		return
+	}
+
+	t.stopScheduledRefresh = t.cache.afterFunc(delay, t.refreshInBackground)
+}
+
+func (t *cachedToken) refreshInBackground() {
+	ctx := t.cache.ctx
+	if ctx.Err() != nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.stopScheduledRefresh = nil
+
+	// the token may have been refreshed in the meantime, in which case another refresh has been scheduled
+	if t.isFresh() {
+		return
+	}
+
+	log.Printf("[DEBUG] Token Cache: the token for %q is due to expire - refreshing in the background..", t.audience)
+	if err := t.refreshWithRetries(ctx, t.token.RefreshWithContext); err != nil {
+		// the token is refreshed when it's next requested, which surfaces the error
+		log.Printf("[DEBUG] Token Cache: refreshing the token for %q in the background failed: %+v", t.audience, err)
+	}
+}
+
+func (t *cachedToken) RefreshWithContext(ctx context.Context) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.metrics.requests++
+	return t.refreshWithRetries(ctx, t.token.RefreshWithContext)
+}
+
+func (t *cachedToken) RefreshExchangeWithContext(ctx context.Context, resource string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.metrics.requests++
+	return t.refreshWithRetries(ctx, func(ctx context.Context) error {
+		return t.token.RefreshExchangeWithContext(ctx, resource)
+	})
+}
+
+func (t *cachedToken) refreshWithRetries(ctx context.Context, refresh func(ctx context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		err := refresh(ctx)
+		if err == nil {
+			t.metrics.refreshes++
+			log.Printf("[DEBUG] Token Cache: refreshed the token for %q which expires at %s (%s)", t.audience, t.token.Token().Expires().Format(time.RFC3339), t.metrics)
+			t.scheduleRefresh()
+			return nil
+		}
+
+		delay, throttled := throttledRetryDelay(err, attempt)
+		if !throttled || attempt >= maxTokenThrottledRetries {
+			t.metrics.failures++
+			log.Printf("[DEBUG] Token Cache: refreshing the token for %q failed (%s): %+v", t.audience, t.metrics, err)
+			return err
+		}
+
+		t.metrics.throttledRetries++
+		log.Printf("[DEBUG] Token Cache: throttled refreshing the token for %q - retrying in %s..", t.audience, delay)
+		if err := t.cache.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// throttledRetryDelay returns how long to wait before retrying when refreshing a token was throttled by Azure AD
+func throttledRetryDelay(err error, attempt int) (time.Duration, bool) {
+	refreshErr, ok := err.(adal.TokenRefreshError)
+	if !ok || refreshErr.Response() == nil {
+		return 0, false
+	}
+
+	resp := refreshErr.Response()
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	delay := time.Duration(1<<uint(attempt)) * time.Second
+	if delay > maxTokenThrottledRetryDelay {
+		delay = maxTokenThrottledRetryDelay
+	}
+	return delay, true
+}
+
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,321 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+)
+
+// fakeToken is a refreshableToken which issues tokens valid for an hour, after returning the specified errors
+type fakeToken struct {
+	now       func() time.Time
+	token     adal.Token
+	refreshes int
+	errors    []error
+}
+
+func (f *fakeToken) OAuthToken() string {
+	return f.token.AccessToken
+}
+
+func (f *fakeToken) Token() adal.Token {
+	return f.token
+}
+
+func (f *fakeToken) EnsureFreshWithContext(ctx context.Context) error {
+	return fmt.Errorf("EnsureFreshWithContext shouldn't be called on the underlying token")
+}
+
+func (f *fakeToken) RefreshWithContext(_ context.Context) error {
+	if len(f.errors) > 0 {
+		err := f.errors[0]
+		f.errors = f.errors[1:]
+		return err
+	}
+
+	f.refreshes++
+	f.token = adal.Token{
+		AccessToken: fmt.Sprintf("token-%d", f.refreshes),
+		ExpiresOn:   json.Number(strconv.FormatInt(f.now().Add(time.Hour).Unix(), 10)),
+	}
+	return nil
+}
+
+func (f *fakeToken) RefreshExchangeWithContext(ctx context.Context, _ string) error {
+	return f.RefreshWithContext(ctx)
+}
+
+type fakeTokenRefreshError struct {
+	resp *http.Response
+}
+
+func (e fakeTokenRefreshError) Error() string {
+	return fmt.Sprintf("refreshing token: status %d", e.resp.StatusCode)
+}
+
+func (e fakeTokenRefreshError) Response() *http.Response {
+	return e.resp
+}
+
+func throttledError(retryAfter string) error {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{},
+	}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return fakeTokenRefreshError{resp: resp}
+}
+
+// fakeTokenSource is an authorizerSource which counts the number of Authorizers built for each audience
+type fakeTokenSource struct {
+	now    func() time.Time
+	tokens map[string]*fakeToken
+}
+
+func (s *fakeTokenSource) GetAuthorizationToken(_ autorest.Sender, _ *authentication.OAuthConfig, endpoint string) (autorest.Authorizer, error) {
+	if _, ok := s.tokens[endpoint]; ok {
+		return nil, fmt.Errorf("an Authorizer has already been built for %q", endpoint)
+	}
+
+	token := &fakeToken{now: s.now}
+	s.tokens[endpoint] = token
+	return autorest.NewBearerAuthorizer(token), nil
+}
+
+type fakeClock struct {
+	current   time.Time
+	slept     []time.Duration
+	scheduled []scheduledFunc
+}
+
+type scheduledFunc struct {
+	delay   time.Duration
+	f       func()
+	stopped bool
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.current
+}
+
+func (c *fakeClock) sleep(_ context.Context, duration time.Duration) error {
+	c.slept = append(c.slept, duration)
+	c.current = c.current.Add(duration)
+	return nil
+}
+
+func (c *fakeClock) afterFunc(delay time.Duration, f func()) func() bool {
+	c.scheduled = append(c.scheduled, scheduledFunc{
+		delay: delay,
+		f:     f,
+	})
+	i := len(c.scheduled) - 1
+	return func() bool {
+		c.scheduled[i].stopped = true
+		return true
+	}
+}
+
+func testTokenCache(clock *fakeClock) (*tokenCache, *fakeTokenSource) {
+	return testTokenCacheWithContext(context.Background(), clock)
+}
+
+func testTokenCacheWithContext(ctx context.Context, clock *fakeClock) (*tokenCache, *fakeTokenSource) {
+	source := &fakeTokenSource{
+		now:    clock.now,
+		tokens: make(map[string]*fakeToken),
+	}
+	cache := newTokenCache(ctx, source, nil)
+	cache.now = clock.now
+	cache.sleep = clock.sleep
+	cache.afterFunc = clock.afterFunc
+	return cache, source
+}
+
+func TestTokenCacheSharesTokensPerAudience(t *testing.T) {
+	clock := &fakeClock{current: time.Now()}
+	cache, source := testTokenCache(clock)
+
+	for i := 0; i < 3; i++ {
+		for _, audience := range []string{"https://management.example.com/", "https://graph.example.com/"} {
+			authorizer, err := cache.authorizer(&authentication.OAuthConfig{}, audience)
+			if err != nil {
+				t.Fatalf("building authorizer for %q: %+v", audience, err)
+			}
+			if actual := authorizationHeader(t, authorizer); actual != "Bearer token-1" {
+				t.Fatalf("expected the cached token `Bearer token-1` but got %q", actual)
+			}
+		}
+	}
+
+	if len(source.tokens) != 2 {
+		t.Fatalf("expected 2 tokens to be built but got %d", len(source.tokens))
+	}
+	for audience, token := range source.tokens {
+		if token.refreshes != 1 {
+			t.Fatalf("expected the token for %q to be obtained once but got %d", audience, token.refreshes)
+		}
+	}
+}
+
+func TestTokenCacheRefreshesBeforeExpiry(t *testing.T) {
+	clock := &fakeClock{current: time.Now()}
+	cache, source := testTokenCache(clock)
+
+	authorizer, err := cache.authorizer(&authentication.OAuthConfig{}, "https://management.example.com/")
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+
+	testData := []struct {
+		advance  time.Duration
+		expected string
+	}{
+		{
+			advance:  0,
+			expected: "Bearer token-1",
+		},
+		{
+			// expires in 40 minutes
+			advance:  20 * time.Minute,
+			expected: "Bearer token-1",
+		},
+		{
+			// expires in 10 minutes, which is within the refresh window
+			advance:  30 * time.Minute,
+			expected: "Bearer token-2",
+		},
+		{
+			advance:  10 * time.Minute,
+			expected: "Bearer token-2",
+		},
+	}
+
+	for _, v := range testData {
+		clock.current = clock.current.Add(v.advance)
+		if actual := authorizationHeader(t, authorizer); actual != v.expected {
+			t.Fatalf("expected %q after %s but got %q", v.expected, v.advance, actual)
+		}
+	}
+
+	if refreshes := source.tokens["https://management.example.com/"].refreshes; refreshes != 2 {
+		t.Fatalf("expected 2 refreshes but got %d", refreshes)
+	}
+}
+
+func TestTokenCacheRefreshesInBackground(t *testing.T) {
+	// the expiry of a token has a precision of a second
+	clock := &fakeClock{current: time.Now().Truncate(time.Second)}
+	cache, source := testTokenCache(clock)
+
+	authorizer, err := cache.authorizer(&authentication.OAuthConfig{}, "https://management.example.com/")
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+	if actual := authorizationHeader(t, authorizer); actual != "Bearer token-1" {
+		t.Fatalf("expected `Bearer token-1` but got %q", actual)
+	}
+
+	// the token expires in an hour, so should be refreshed 15 minutes beforehand
+	if len(clock.scheduled) != 1 {
+		t.Fatalf("expected 1 scheduled refresh but got %d", len(clock.scheduled))
+	}
+	if delay := clock.scheduled[0].delay; delay != 45*time.Minute {
+		t.Fatalf("expected the refresh to be scheduled in 45m0s but got %s", delay)
+	}
+
+	clock.current = clock.current.Add(clock.scheduled[0].delay)
+	clock.scheduled[0].f()
+
+	token := source.tokens["https://management.example.com/"]
+	if token.refreshes != 2 {
+		t.Fatalf("expected the token to be refreshed in the background but got %d refreshes", token.refreshes)
+	}
+	if len(clock.scheduled) != 2 || clock.scheduled[1].delay != 45*time.Minute {
+		t.Fatalf("expected the next refresh to be scheduled in 45m0s")
+	}
+
+	// the refreshed token is served from the cache
+	if actual := authorizationHeader(t, authorizer); actual != "Bearer token-2" {
+		t.Fatalf("expected `Bearer token-2` but got %q", actual)
+	}
+	if token.refreshes != 2 {
+		t.Fatalf("expected 2 refreshes but got %d", token.refreshes)
+	}
+}
+
+func TestTokenCacheStopsRefreshingInBackground(t *testing.T) {
+	// the expiry of a token has a precision of a second
+	clock := &fakeClock{current: time.Now().Truncate(time.Second)}
+	ctx, cancel := context.WithCancel(context.Background())
+	cache, source := testTokenCacheWithContext(ctx, clock)
+
+	authorizer, err := cache.authorizer(&authentication.OAuthConfig{}, "https://management.example.com/")
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+	authorizationHeader(t, authorizer)
+
+	cancel()
+	clock.current = clock.current.Add(clock.scheduled[0].delay)
+	clock.scheduled[0].f()
+
+	if refreshes := source.tokens["https://management.example.com/"].refreshes; refreshes != 1 {
+		t.Fatalf("expected no refreshes in the background once the context is done but got %d refreshes", refreshes)
+	}
+}
+
+func TestTokenCacheRetriesWhenThrottled(t *testing.T) {
+	clock := &fakeClock{current: time.Now()}
+	cache, source := testTokenCache(clock)
+
+	authorizer, err := cache.authorizer(&authentication.OAuthConfig{}, "https://management.example.com/")
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+	source.tokens["https://management.example.com/"].errors = []error{throttledError("7"), throttledError("")}
+
+	if actual := authorizationHeader(t, authorizer); actual != "Bearer token-1" {
+		t.Fatalf("expected `Bearer token-1` but got %q", actual)
+	}
+
+	expected := []time.Duration{7 * time.Second, 2 * time.Second}
+	if len(clock.slept) != len(expected) {
+		t.Fatalf("expected %d retries but got %d", len(expected), len(clock.slept))
+	}
+	for i, v := range expected {
+		if clock.slept[i] != v {
+			t.Fatalf("expected retry %d to wait %s but got %s", i, v, clock.slept[i])
+		}
+	}
+}
+
+func TestTokenCacheDoesNotRetryOtherErrors(t *testing.T) {
+	clock := &fakeClock{current: time.Now()}
+	cache, source := testTokenCache(clock)
+
+	authorizer, err := cache.authorizer(&authentication.OAuthConfig{}, "https://management.example.com/")
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+	source.tokens["https://management.example.com/"].errors = []error{
+		fakeTokenRefreshError{resp: &http.Response{StatusCode: http.StatusUnauthorized}},
+	}
+
+	if _, err := autorest.Prepare(&http.Request{}, authorizer.WithAuthorization()); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if len(clock.slept) != 0 {
+		t.Fatalf("expected no retries but got %d", len(clock.slept))
+	}
+}
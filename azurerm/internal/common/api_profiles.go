@@ -0,0 +1,140 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// APIProfile20190301Hybrid is the `2019-03-01-hybrid` API Profile supported by Azure Stack Hub 1904 and later
+const APIProfile20190301Hybrid = "2019-03-01-hybrid"
+
+// apiProfiles is a map of API Profile to the API Version which should be used for each Resource Provider
+// (or, where it differs from the Resource Provider, the Resource Type) when using that API Profile
+var apiProfiles = map[string]map[string]string{
+	APIProfile20190301Hybrid: {
+		"Microsoft.Compute":                 "2017-12-01",
+		"Microsoft.Compute/disks":           "2017-03-30",
+		"Microsoft.KeyVault":                "2016-10-01",
+		"Microsoft.Network":                 "2017-10-01",
+		"Microsoft.Resources":               "2018-05-01",
+		"Microsoft.Resources/subscriptions": "2016-06-01",
+		"Microsoft.Storage":                 "2017-10-01",
+	},
+}
+
+// IsAPIProfileSupported returns whether the specified API Profile is supported
+func IsAPIProfileSupported(profile string) bool {
+	_, ok := apiProfiles[profile]
+	return ok
+}
+
+// SupportedAPIProfiles returns a sorted list of the supported API Profiles
+func SupportedAPIProfiles() []string {
+	output := make([]string, 0)
+	for k := range apiProfiles {
+		output = append(output, k)
+	}
+	sort.Strings(output)
+	return output
+}
+
+// withAPIProfile returns a PrepareDecorator which replaces the `api-version` of requests to Azure Resource
+// Manager with the API Version defined for the Resource Provider by the specified API Profile.
+//
+// Requests to a Resource Provider which isn't defined by the API Profile are rejected, rather than being sent
+// using the API Version for the Azure Public Clouds, since that API Version won't be available in Azure Stack Hub
+func withAPIProfile(profile string) autorest.PrepareDecorator {
+	versions := apiProfiles[profile]
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil || r.URL == nil {
+				return r, err
+			}
+
+			query := r.URL.Query()
+			if query.Get("api-version") == "" {
+				return r, nil
+			}
+
+			if !isResourceManagerPath(r.URL.Path) {
+				return r, nil
+			}
+
+			apiVersion, ok := apiVersionForPath(versions, r.URL.Path)
+			if !ok {
+				return r, fmt.Errorf("the API Profile %q doesn't define an API Version for %q - as such this isn't supported when using an API Profile", profile, r.URL.Path)
+			}
+			log.Printf("[DEBUG] Using the API Version %q from the API Profile %q for %q", apiVersion, profile, r.URL.Path)
+
+			query.Set("api-version", apiVersion)
+			r.URL.RawQuery = query.Encode()
+			return r, nil
+		})
+	}
+}
+
+// isResourceManagerPath returns whether the specified path is an Azure Resource Manager path (rather than a data plane path)
+func isResourceManagerPath(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	return strings.EqualFold(segments[0], "subscriptions") || strings.EqualFold(segments[0], "providers")
+}
+
+// apiVersionForPath returns the API Version defined for the Resource Provider (or Resource Type) referenced
+// by the specified Azure Resource Manager path
+func apiVersionForPath(versions map[string]string, path string) (string, bool) {
+	if !isResourceManagerPath(path) {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	// the last `providers` segment determines the Resource Provider, since Resources can be nested
+	resourceProvider := ""
+	resourceType := ""
+	for i := 0; i < len(segments)-1; i++ {
+		if strings.EqualFold(segments[i], "providers") {
+			resourceProvider = segments[i+1]
+			resourceType = ""
+			if i+2 < len(segments) {
+				resourceType = segments[i+2]
+			}
+		}
+	}
+
+	// retrieving (or registering) a Resource Provider is handled by `Microsoft.Resources` rather than the Resource Provider
+	if len(segments) >= 4 && strings.EqualFold(segments[0], "subscriptions") && strings.EqualFold(segments[2], "providers") {
+		if len(segments) == 4 || (len(segments) == 5 && (strings.EqualFold(segments[4], "register") || strings.EqualFold(segments[4], "unregister"))) {
+			resourceProvider = ""
+			resourceType = ""
+		}
+	}
+
+	if resourceProvider == "" {
+		// Subscriptions, Resource Groups and Resource Providers don't include a `providers` segment
+		resourceProvider = "Microsoft.Resources"
+		if len(segments) <= 3 && !strings.EqualFold(segments[len(segments)-1], "providers") {
+			resourceType = "subscriptions"
+		}
+		if len(segments) >= 3 && strings.EqualFold(segments[2], "resourceGroups") {
+			resourceType = ""
+		}
+	}
+
+	for key, apiVersion := range versions {
+		if resourceType != "" && strings.EqualFold(key, resourceProvider+"/"+resourceType) {
+			return apiVersion, true
+		}
+	}
+	for key, apiVersion := range versions {
+		if strings.EqualFold(key, resourceProvider) {
+			return apiVersion, true
+		}
+	}
+
+	return "", false
+}
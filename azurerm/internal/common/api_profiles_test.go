@@ -0,0 +1,111 @@
+package common
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestAPIVersionForPath(t *testing.T) {
+	testData := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000",
+			expected: "2016-06-01",
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/locations",
+			expected: "2016-06-01",
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example",
+			expected: "2018-05-01",
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/providers",
+			expected: "2018-05-01",
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.ContainerService/register",
+			expected: "2018-05-01",
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Compute",
+			expected: "2018-05-01",
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Compute/locations/westus/usages",
+			expected: "2017-12-01",
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example/subnets/internal",
+			expected: "2017-10-01",
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Compute/disks/example",
+			expected: "2017-03-30",
+		},
+		{
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/microsoft.compute/availabilitySets/example",
+			expected: "2017-12-01",
+		},
+		{
+			// not defined by the API Profile
+			path:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.ContainerService/managedClusters/example",
+			expected: "",
+		},
+		{
+			// Key Vault data plane
+			path:     "/secrets/example",
+			expected: "",
+		},
+	}
+
+	versions := apiProfiles[APIProfile20190301Hybrid]
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.path)
+
+		actual, ok := apiVersionForPath(versions, v.path)
+		if ok != (v.expected != "") {
+			t.Fatalf("expected ok to be %t but got %t", v.expected != "", ok)
+		}
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestWithAPIProfile(t *testing.T) {
+	uri, _ := url.Parse("https://management.local.azurestack.external/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example?api-version=2020-05-01")
+	req, err := autorest.Prepare(&http.Request{URL: uri}, withAPIProfile(APIProfile20190301Hybrid))
+	if err != nil {
+		t.Fatalf("preparing request: %+v", err)
+	}
+
+	if actual := req.URL.Query().Get("api-version"); actual != "2017-10-01" {
+		t.Fatalf("expected the api-version `2017-10-01` but got %q", actual)
+	}
+}
+
+func TestWithAPIProfileUnsupportedResourceProvider(t *testing.T) {
+	uri, _ := url.Parse("https://management.local.azurestack.external/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.ContainerService/managedClusters/example?api-version=2020-12-01")
+	if _, err := autorest.Prepare(&http.Request{URL: uri}, withAPIProfile(APIProfile20190301Hybrid)); err == nil {
+		t.Fatalf("expected an error for a Resource Provider not defined by the API Profile but didn't get one")
+	}
+}
+
+func TestWithAPIProfileDataPlane(t *testing.T) {
+	uri, _ := url.Parse("https://example.vault.local.azurestack.external/secrets/example?api-version=7.1")
+	req, err := autorest.Prepare(&http.Request{URL: uri}, withAPIProfile(APIProfile20190301Hybrid))
+	if err != nil {
+		t.Fatalf("preparing request: %+v", err)
+	}
+
+	if actual := req.URL.Query().Get("api-version"); actual != "7.1" {
+		t.Fatalf("expected the api-version `7.1` but got %q", actual)
+	}
+}
@@ -0,0 +1,21 @@
+package common
+
+import "context"
+
+type auxiliaryResourceIDsKey struct{}
+
+// WithAuxiliaryResourceIDs returns a Context which specifies the Resource IDs referenced by requests made
+// using it - which may exist in other (Auxiliary) Tenants, for example the Remote Virtual Network within a
+// Virtual Network Peering. This is used to determine which Auxiliary Tenants require a token for a request.
+func WithAuxiliaryResourceIDs(ctx context.Context, resourceIds ...string) context.Context {
+	ids := append(AuxiliaryResourceIDs(ctx), resourceIds...)
+	return context.WithValue(ctx, auxiliaryResourceIDsKey{}, ids)
+}
+
+// AuxiliaryResourceIDs returns the Resource IDs specified for this Context using `WithAuxiliaryResourceIDs`
+func AuxiliaryResourceIDs(ctx context.Context) []string {
+	if v, ok := ctx.Value(auxiliaryResourceIDsKey{}).([]string); ok {
+		return append([]string{}, v...)
+	}
+	return []string{}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/terraform-plugin-sdk/meta"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/auditlog"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 	"github.com/terraform-providers/terraform-provider-azurerm/version"
 )
@@ -34,6 +35,13 @@ type ClientOptions struct {
 	Environment                 azure.Environment
 	Features                    features.UserFeatures
 	StorageUseAzureAD           bool
+
+	// APIProfile is the API Profile (e.g. `2019-03-01-hybrid`) whose API Versions should be used for
+	// requests to Azure Resource Manager, when empty the API Versions defined by each SDK are used
+	APIProfile string
+
+	// AuditLog is used to record each request made to (and response from) the Azure API's, when enabled
+	AuditLog *auditlog.Logger
 }
 
 func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.Authorizer) {
@@ -41,13 +49,26 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 
 	c.Authorizer = authorizer
 	c.Sender = sender.BuildSender("AzureRM")
+	if o.AuditLog != nil {
+		c.Sender = o.AuditLog.Sender(c.Sender)
+	}
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
+	decorators := make([]autorest.PrepareDecorator, 0)
 	if !o.DisableCorrelationRequestID {
-		id := o.CustomCorrelationRequestID
-		if id == "" {
-			id = correlationRequestID()
-		}
-		c.RequestInspector = withCorrelationRequestID(id)
+		// an Operation-level Correlation Request ID takes precedence, when one is present in the request context
+		decorators = append(decorators, withCorrelationRequestIDFromContext(RunCorrelationRequestID(o.CustomCorrelationRequestID)))
+	}
+	if o.APIProfile != "" {
+		decorators = append(decorators, withAPIProfile(o.APIProfile))
+	}
+	if len(decorators) > 0 {
+		c.RequestInspector = withPrepareDecorators(decorators...)
+	}
+}
+
+func withPrepareDecorators(decorators ...autorest.PrepareDecorator) autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.DecoratePreparer(p, decorators...)
 	}
 }
 
@@ -1,7 +1,12 @@
 package common
 
 import (
+	"context"
+	"crypto/sha1"
+	"fmt"
 	"log"
+	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -24,6 +29,83 @@ func withCorrelationRequestID(uuid string) autorest.PrepareDecorator {
 	return autorest.WithHeader(HeaderCorrelationRequestID, uuid)
 }
 
+// withCorrelationRequestIDFromContext returns a PrepareDecorator that adds an HTTP extension header of
+// `x-ms-correlation-request-id` using the Correlation Request ID for the current Operation (when one is
+// present in the context of the request) - otherwise the specified (Provider-level) ID is used.
+func withCorrelationRequestIDFromContext(fallback string) autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+
+			id := fallback
+			if v, ok := OperationCorrelationRequestIDFromContext(r.Context()); ok {
+				id = v
+			}
+			return autorest.Prepare(r, withCorrelationRequestID(id))
+		})
+	}
+}
+
+type operationCorrelationRequestIDKey struct{}
+
+// WithOperationCorrelationRequestID returns a copy of the specified context containing the Correlation Request ID
+// which should be used for all requests made during the current Operation (e.g. the Create of a Resource)
+func WithOperationCorrelationRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, operationCorrelationRequestIDKey{}, id)
+}
+
+// OperationCorrelationRequestIDFromContext returns the Correlation Request ID for the current Operation, if any
+func OperationCorrelationRequestIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+
+	id, ok := ctx.Value(operationCorrelationRequestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// OperationCorrelationRequestID returns the Correlation Request ID for an Operation (e.g. `Create`) on the specified
+// Resource (e.g. `azurerm_resource_group (/subscriptions/.../resourceGroups/example)`) - which is derived from the
+// Correlation Request ID for the Terraform run, such that it's stable for the same Operation on the same Resource
+// within a Terraform run. The first segment is the prefix returned by `RunCorrelationRequestIDPrefix`, so that the
+// requests made during a Terraform run can be found by this prefix.
+func OperationCorrelationRequestID(runId, operation, resource string) string {
+	hash := sha1.Sum([]byte(fmt.Sprintf("%s\n%s\n%s", runId, operation, resource)))
+
+	// format this as a name-based (Version 5) UUID, since that's what the API expects
+	hash[6] = (hash[6] & 0x0f) | 0x50
+	hash[8] = (hash[8] & 0x3f) | 0x80
+	output := fmt.Sprintf("%x-%x-%x-%x-%x", hash[0:4], hash[4:6], hash[6:8], hash[8:10], hash[10:16])
+
+	return RunCorrelationRequestIDPrefix(runId) + output[8:]
+}
+
+// RunCorrelationRequestIDPrefix returns the prefix of the Correlation Request IDs for each Operation within a
+// Terraform run. When the Correlation Request ID for the run is a UUID this is its first segment (e.g. `7f5a6223`
+// for `7f5a6223-f475-4a9c-b9d5-12575aa6b11b`) - otherwise the Correlation Request ID for the run isn't valid within
+// a UUID, and so the first 8 characters of the hex-encoded SHA1 hash of it are used instead.
+func RunCorrelationRequestIDPrefix(runId string) string {
+	if _, err := uuid.ParseUUID(runId); err == nil {
+		return strings.ToLower(runId[:8])
+	}
+
+	hash := sha1.Sum([]byte(runId))
+	return fmt.Sprintf("%x", hash[0:4])
+}
+
+// RunCorrelationRequestID returns the Correlation Request ID used for this Terraform run, which is the
+// custom Correlation Request ID when specified - otherwise one is generated for this Provider process.
+func RunCorrelationRequestID(custom string) string {
+	if custom != "" {
+		return custom
+	}
+
+	return correlationRequestID()
+}
+
 // correlationRequestID generates an UUID to pass through `x-ms-correlation-request-id` header.
 func correlationRequestID() string {
 	msCorrelationRequestIDOnce.Do(func() {
@@ -1,10 +1,13 @@
 package common
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-uuid"
 )
 
 func TestCorrelationRequestID(t *testing.T) {
@@ -29,3 +32,88 @@ func TestWithCorrelationRequestID(t *testing.T) {
 			HeaderCorrelationRequestID, uuid, req.Header.Get(HeaderCorrelationRequestID))
 	}
 }
+
+func TestOperationCorrelationRequestID(t *testing.T) {
+	first := OperationCorrelationRequestID("run", "Create", "azurerm_resource_group (example)")
+	if _, err := uuid.ParseUUID(first); err != nil {
+		t.Fatalf("expected %q to be a UUID: %+v", first, err)
+	}
+	if first[14] != '5' {
+		t.Fatalf("expected %q to be a Version 5 UUID", first)
+	}
+
+	if second := OperationCorrelationRequestID("run", "Create", "azurerm_resource_group (example)"); first != second {
+		t.Fatalf("expected the same Operation to use the same ID but got %q and %q", first, second)
+	}
+
+	for _, v := range []string{
+		OperationCorrelationRequestID("other", "Create", "azurerm_resource_group (example)"),
+		OperationCorrelationRequestID("run", "Delete", "azurerm_resource_group (example)"),
+		OperationCorrelationRequestID("run", "Create", "azurerm_resource_group (other)"),
+	} {
+		if v == first {
+			t.Fatalf("expected a different Operation to use a different ID but got %q", v)
+		}
+	}
+}
+
+func TestOperationCorrelationRequestIDRetainsRunPrefix(t *testing.T) {
+	runId := "7F5A6223-F475-4A9C-B9D5-12575AA6B11B"
+
+	actual := OperationCorrelationRequestID(runId, "Create", "azurerm_resource_group (example)")
+	if _, err := uuid.ParseUUID(actual); err != nil {
+		t.Fatalf("expected %q to be a UUID: %+v", actual, err)
+	}
+	if !strings.HasPrefix(actual, "7f5a6223-") {
+		t.Fatalf("expected %q to retain the prefix of the Correlation Request ID for the run %q", actual, runId)
+	}
+	if actual == strings.ToLower(runId) {
+		t.Fatalf("expected the Correlation Request ID for the Operation to differ from the run")
+	}
+}
+
+func TestOperationCorrelationRequestIDRetainsRunPrefixForNonUUID(t *testing.T) {
+	runId := "nightly-build-1234"
+
+	actual := OperationCorrelationRequestID(runId, "Create", "azurerm_resource_group (example)")
+	if _, err := uuid.ParseUUID(actual); err != nil {
+		t.Fatalf("expected %q to be a UUID: %+v", actual, err)
+	}
+
+	// sha1("nightly-build-1234") is deterministic, so the prefix is stable for the run
+	prefix := RunCorrelationRequestIDPrefix(runId)
+	if len(prefix) != 8 {
+		t.Fatalf("expected the prefix %q to be 8 characters", prefix)
+	}
+	if !strings.HasPrefix(actual, prefix+"-") {
+		t.Fatalf("expected %q to start with the prefix %q derived from the Correlation Request ID for the run %q", actual, prefix, runId)
+	}
+
+	other := OperationCorrelationRequestID(runId, "Delete", "azurerm_resource_group (other)")
+	if !strings.HasPrefix(other, prefix+"-") {
+		t.Fatalf("expected %q to start with the prefix %q", other, prefix)
+	}
+	if other == actual {
+		t.Fatalf("expected a different Operation to use a different ID")
+	}
+
+	if RunCorrelationRequestIDPrefix("another-run") == prefix {
+		t.Fatalf("expected a different run to use a different prefix")
+	}
+}
+
+func TestWithCorrelationRequestIDFromContext(t *testing.T) {
+	fallback := correlationRequestID()
+
+	req, _ := autorest.Prepare(&http.Request{}, withCorrelationRequestIDFromContext(fallback))
+	if req.Header.Get(HeaderCorrelationRequestID) != fallback {
+		t.Fatalf("expected %q but got %q", fallback, req.Header.Get(HeaderCorrelationRequestID))
+	}
+
+	operation := OperationCorrelationRequestID(fallback, "Read", "azurerm_resource_group (example)")
+	ctx := WithOperationCorrelationRequestID(context.Background(), operation)
+	req, _ = autorest.Prepare((&http.Request{}).WithContext(ctx), withCorrelationRequestIDFromContext(fallback))
+	if req.Header.Get(HeaderCorrelationRequestID) != operation {
+		t.Fatalf("expected %q but got %q", operation, req.Header.Get(HeaderCorrelationRequestID))
+	}
+}
@@ -0,0 +1,17 @@
+package features
+
+import (
+	"os"
+	"strings"
+)
+
+// APIProfile returns the API Profile (e.g. `2019-03-01-hybrid`) which should be used, or an empty
+// string when the latest API Versions should be used (the default).
+//
+// API Profiles allow the subset of Data Sources and Resources supported by the specified Profile to
+// be used against Azure Stack Hub. Since this determines which Data Sources and Resources are available
+// this is read when the Provider is instantiated (rather than from the Provider block) and can be set
+// using the Environment Variable `ARM_API_PROFILE`.
+func APIProfile() string {
+	return strings.TrimSpace(os.Getenv("ARM_API_PROFILE"))
+}
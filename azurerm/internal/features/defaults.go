@@ -13,6 +13,9 @@ func Default() UserFeatures {
 		Network: NetworkFeatures{
 			RelaxedLocking: false,
 		},
+		Policy: PolicyFeatures{
+			DenyPreflight: PolicyDenyPreflightDisabled,
+		},
 		TemplateDeployment: TemplateDeploymentFeatures{
 			DeleteNestedItemsDuringDeletion: true,
 		},
@@ -7,6 +7,7 @@ type UserFeatures struct {
 	Network                NetworkFeatures
 	TemplateDeployment     TemplateDeploymentFeatures
 	LogAnalyticsWorkspace  LogAnalyticsWorkspaceFeatures
+	Policy                 PolicyFeatures
 }
 
 type VirtualMachineFeatures struct {
@@ -34,3 +35,15 @@ type TemplateDeploymentFeatures struct {
 type LogAnalyticsWorkspaceFeatures struct {
 	PermanentlyDeleteOnDestroy bool
 }
+
+const (
+	PolicyDenyPreflightDisabled = "Disabled"
+	PolicyDenyPreflightWarning  = "Warning"
+	PolicyDenyPreflightError    = "Error"
+)
+
+type PolicyFeatures struct {
+	// DenyPreflight specifies whether Resources which would be denied by an Azure Policy are
+	// surfaced during the plan - either as a Warning (in the log) or as an Error
+	DenyPreflight string
+}
@@ -0,0 +1,149 @@
+package identity
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/parse"
+)
+
+// ExpandTo populates `output` - a pointer to the Managed Identity model for a Service Package within the
+// Azure SDK (for example `*web.ManagedServiceIdentity`) - from the ExpandedConfig, using reflection since
+// each Service Package uses a different type.
+//
+// The `Type` field is required, the `UserAssignedIdentities` field (a map of Identity ID to an empty value)
+// is populated when the type includes `UserAssigned` - `PrincipalID` and `TenantID` are read-only, so are ignored.
+func ExpandTo(input *ExpandedConfig, output interface{}) error {
+	if input == nil {
+		return fmt.Errorf("expanding identity: `input` was nil")
+	}
+
+	value := reflect.ValueOf(output)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expanding identity: expected a pointer to a struct but got %T", output)
+	}
+	model := value.Elem()
+
+	typeField := model.FieldByName("Type")
+	if !typeField.IsValid() || typeField.Kind() != reflect.String {
+		return fmt.Errorf("expanding identity: %T doesn't contain a `Type` field", output)
+	}
+	typeField.Set(reflect.ValueOf(input.Type).Convert(typeField.Type()))
+
+	identityIds := make([]string, 0)
+	if input.UserAssignedIdentityIds != nil {
+		identityIds = *input.UserAssignedIdentityIds
+	}
+	if !strings.Contains(input.Type, userAssigned) {
+		if len(identityIds) > 0 {
+			return fmt.Errorf("expanding identity: User Assigned Identities can only be specified when the type includes %q", userAssigned)
+		}
+		return nil
+	}
+
+	field := model.FieldByName("UserAssignedIdentities")
+	if !field.IsValid() || field.Kind() != reflect.Map || field.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("expanding identity: %T doesn't support User Assigned Identities", output)
+	}
+
+	identities := reflect.MakeMap(field.Type())
+	for _, id := range identityIds {
+		identities.SetMapIndex(reflect.ValueOf(id).Convert(field.Type().Key()), emptyValueOf(field.Type().Elem()))
+	}
+	field.Set(identities)
+
+	return nil
+}
+
+// FlattenFrom returns the ExpandedConfig for the Managed Identity model for a Service Package within the
+// Azure SDK (for example `*web.ManagedServiceIdentity`), using reflection since each Service Package uses
+// a different type - returning nil when the model is nil.
+func FlattenFrom(input interface{}) (*ExpandedConfig, error) {
+	value := reflect.ValueOf(input)
+	if !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return nil, nil
+	}
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("flattening identity: expected a struct but got %T", input)
+	}
+
+	typeField := value.FieldByName("Type")
+	if !typeField.IsValid() || typeField.Kind() != reflect.String {
+		return nil, fmt.Errorf("flattening identity: %T doesn't contain a `Type` field", input)
+	}
+
+	identityIds := make([]string, 0)
+	if field := value.FieldByName("UserAssignedIdentities"); field.IsValid() && field.Kind() == reflect.Map {
+		for _, key := range field.MapKeys() {
+			id, err := parse.UserAssignedIdentityID(key.String())
+			if err != nil {
+				return nil, fmt.Errorf("flattening identity: parsing %q: %+v", key.String(), err)
+			}
+			identityIds = append(identityIds, id.ID())
+		}
+	}
+	sort.Strings(identityIds)
+
+	identityType := normalizeType(typeField.String())
+	if identityType == "" {
+		identityType = none
+	}
+
+	return &ExpandedConfig{
+		Type:                    identityType,
+		PrincipalId:             stringValueOf(value.FieldByName("PrincipalID")),
+		TenantId:                stringValueOf(value.FieldByName("TenantID")),
+		UserAssignedIdentityIds: &identityIds,
+	}, nil
+}
+
+// normalizeType returns the canonical casing and format of the identity type, since the Azure
+// API's differ in how `SystemAssigned, UserAssigned` is formatted (e.g. `SystemAssigned,UserAssigned`)
+func normalizeType(input string) string {
+	for _, v := range []string{none, systemAssigned, userAssigned, systemAssignedUserAssigned} {
+		if strings.EqualFold(strings.ReplaceAll(input, " ", ""), strings.ReplaceAll(v, " ", "")) {
+			return v
+		}
+	}
+
+	return input
+}
+
+// emptyValueOf returns an empty value of the specified type, which for pointers and interfaces is an
+// empty (rather than nil) object - such that it's serialized as `{}` in the request
+func emptyValueOf(t reflect.Type) reflect.Value {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return reflect.New(t.Elem())
+	case reflect.Interface:
+		return reflect.ValueOf(map[string]interface{}{})
+	default:
+		return reflect.Zero(t)
+	}
+}
+
+// stringValueOf returns the value of a `*string` or `*uuid.UUID` (or other fmt.Stringer) field as a `*string`
+func stringValueOf(field reflect.Value) *string {
+	if !field.IsValid() || (field.Kind() == reflect.Ptr && field.IsNil()) {
+		return nil
+	}
+
+	var output string
+	switch v := field.Interface().(type) {
+	case *string:
+		output = *v
+	case string:
+		output = v
+	case fmt.Stringer:
+		output = v.String()
+	default:
+		return nil
+	}
+
+	return &output
+}
@@ -0,0 +1,169 @@
+package identity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gofrs/uuid"
+)
+
+// these types mirror the different Managed Identity models used within the Azure SDK
+type testIdentityType string
+
+type testUserAssignedIdentity struct {
+	PrincipalID *string `json:"principalId,omitempty"`
+	ClientID    *string `json:"clientId,omitempty"`
+}
+
+type testStringIdentity struct {
+	Type                   testIdentityType
+	PrincipalID            *string
+	TenantID               *string
+	UserAssignedIdentities map[string]*testUserAssignedIdentity
+}
+
+type testUUIDIdentity struct {
+	Type                   testIdentityType
+	PrincipalID            *uuid.UUID
+	TenantID               *uuid.UUID
+	UserAssignedIdentities map[string]interface{}
+}
+
+type testSystemAssignedIdentity struct {
+	Type        testIdentityType
+	PrincipalID *string
+	TenantID    *string
+}
+
+const testIdentityId = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"
+
+func TestExpandTo(t *testing.T) {
+	identityIds := []string{testIdentityId}
+
+	var stringIdentity testStringIdentity
+	if err := ExpandTo(&ExpandedConfig{Type: systemAssignedUserAssigned, UserAssignedIdentityIds: &identityIds}, &stringIdentity); err != nil {
+		t.Fatalf("expanding: %+v", err)
+	}
+	if stringIdentity.Type != testIdentityType(systemAssignedUserAssigned) {
+		t.Fatalf("expected the type to be %q but got %q", systemAssignedUserAssigned, stringIdentity.Type)
+	}
+	if v, ok := stringIdentity.UserAssignedIdentities[testIdentityId]; !ok || v == nil {
+		t.Fatalf("expected an empty value for %q but got %+v", testIdentityId, stringIdentity.UserAssignedIdentities)
+	}
+
+	var uuidIdentity testUUIDIdentity
+	if err := ExpandTo(&ExpandedConfig{Type: userAssigned, UserAssignedIdentityIds: &identityIds}, &uuidIdentity); err != nil {
+		t.Fatalf("expanding: %+v", err)
+	}
+	if !reflect.DeepEqual(uuidIdentity.UserAssignedIdentities[testIdentityId], map[string]interface{}{}) {
+		t.Fatalf("expected an empty object for %q but got %+v", testIdentityId, uuidIdentity.UserAssignedIdentities)
+	}
+
+	var systemAssignedIdentity testSystemAssignedIdentity
+	if err := ExpandTo(&ExpandedConfig{Type: systemAssigned}, &systemAssignedIdentity); err != nil {
+		t.Fatalf("expanding: %+v", err)
+	}
+	if systemAssignedIdentity.Type != testIdentityType(systemAssigned) {
+		t.Fatalf("expected the type to be %q but got %q", systemAssigned, systemAssignedIdentity.Type)
+	}
+
+	if err := ExpandTo(&ExpandedConfig{Type: userAssigned, UserAssignedIdentityIds: &identityIds}, &systemAssignedIdentity); err == nil {
+		t.Fatalf("expected an error since User Assigned Identities aren't supported")
+	}
+	if err := ExpandTo(&ExpandedConfig{Type: systemAssigned, UserAssignedIdentityIds: &identityIds}, &stringIdentity); err == nil {
+		t.Fatalf("expected an error since User Assigned Identities can't be specified for %q", systemAssigned)
+	}
+	if err := ExpandTo(&ExpandedConfig{Type: systemAssigned}, stringIdentity); err == nil {
+		t.Fatalf("expected an error since the output isn't a pointer")
+	}
+}
+
+func TestFlattenFrom(t *testing.T) {
+	principalId := "11111111-1111-1111-1111-111111111111"
+	tenantId := "22222222-2222-2222-2222-222222222222"
+	principalUUID := uuid.FromStringOrNil(principalId)
+	tenantUUID := uuid.FromStringOrNil(tenantId)
+
+	// Azure can return a different casing for the Resource ID
+	lowerCasedIdentityId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"
+
+	testData := []struct {
+		name     string
+		input    interface{}
+		expected *ExpandedConfig
+	}{
+		{
+			name:     "nil",
+			input:    (*testStringIdentity)(nil),
+			expected: nil,
+		},
+		{
+			name: "string",
+			input: &testStringIdentity{
+				Type:        "SystemAssigned,UserAssigned",
+				PrincipalID: &principalId,
+				TenantID:    &tenantId,
+				UserAssignedIdentities: map[string]*testUserAssignedIdentity{
+					lowerCasedIdentityId: {},
+				},
+			},
+			expected: &ExpandedConfig{
+				Type:                    systemAssignedUserAssigned,
+				PrincipalId:             &principalId,
+				TenantId:                &tenantId,
+				UserAssignedIdentityIds: &[]string{testIdentityId},
+			},
+		},
+		{
+			name: "uuid",
+			input: &testUUIDIdentity{
+				Type:        "systemassigned",
+				PrincipalID: &principalUUID,
+				TenantID:    &tenantUUID,
+			},
+			expected: &ExpandedConfig{
+				Type:                    systemAssigned,
+				PrincipalId:             &principalId,
+				TenantId:                &tenantId,
+				UserAssignedIdentityIds: &[]string{},
+			},
+		},
+		{
+			name:  "empty type",
+			input: testSystemAssignedIdentity{},
+			expected: &ExpandedConfig{
+				Type:                    none,
+				UserAssignedIdentityIds: &[]string{},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := FlattenFrom(v.input)
+		if err != nil {
+			t.Fatalf("flattening: %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestNormalizeType(t *testing.T) {
+	testData := map[string]string{
+		"None":                         none,
+		"systemassigned":               systemAssigned,
+		"SystemAssigned,UserAssigned":  systemAssignedUserAssigned,
+		"systemAssigned, userAssigned": systemAssignedUserAssigned,
+		"UserAssigned":                 userAssigned,
+		"Other":                        "Other",
+	}
+
+	for input, expected := range testData {
+		if actual := normalizeType(input); actual != expected {
+			t.Fatalf("expected %q to be normalized to %q but got %q", input, expected, actual)
+		}
+	}
+}
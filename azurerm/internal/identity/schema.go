@@ -7,9 +7,7 @@ import (
 const none = "None"
 const systemAssigned = "SystemAssigned"
 const userAssigned = "UserAssigned"
-
-// TODO: support SystemAssigned, UserAssigned
-// const systemAssignedUserAssigned = "SystemAssigned, UserAssigned"
+const systemAssignedUserAssigned = "SystemAssigned, UserAssigned"
 
 type ExpandedConfig struct {
 	// Type is the type of User Assigned Identity, either `None`, `SystemAssigned`, `UserAssigned`
@@ -0,0 +1,219 @@
+package identity
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/suppress"
+)
+
+var _ Identity = SystemAssignedUserAssigned{}
+
+type SystemAssignedUserAssigned struct{}
+
+func (s SystemAssignedUserAssigned) Expand(input []interface{}) (*ExpandedConfig, error) {
+	if len(input) == 0 || input[0] == nil {
+		return &ExpandedConfig{
+			Type: none,
+		}, nil
+	}
+
+	v := input[0].(map[string]interface{})
+	identityType := normalizeType(v["type"].(string))
+
+	identityIds := make([]string, 0)
+	for _, id := range identityIdsFromRaw(v["identity_ids"]) {
+		identityIds = append(identityIds, id.(string))
+	}
+
+	switch identityType {
+	case userAssigned, systemAssignedUserAssigned:
+		if len(identityIds) == 0 {
+			return nil, fmt.Errorf("`identity_ids` must be specified when `type` includes `UserAssigned`")
+		}
+
+	default:
+		if len(identityIds) > 0 {
+			return nil, fmt.Errorf("`identity_ids` can only be specified when `type` includes `UserAssigned` but `type` is %q", identityType)
+		}
+	}
+
+	return &ExpandedConfig{
+		Type:                    identityType,
+		UserAssignedIdentityIds: &identityIds,
+	}, nil
+}
+
+// ExpandWithoutValidation expands the `identity` block in the same manner as Expand, however (prior to 3.0) the
+// `identity_ids` aren't validated against the `type`. This is for resources which previously passed these through
+// to the API as-is - where `identity_ids` are omitted when the `type` doesn't include `UserAssigned`, and can be
+// omitted when it does.
+func (s SystemAssignedUserAssigned) ExpandWithoutValidation(input []interface{}) (*ExpandedConfig, error) {
+	if features.ThreePointOh() || len(input) == 0 || input[0] == nil {
+		return s.Expand(input)
+	}
+
+	v := input[0].(map[string]interface{})
+	identityType := normalizeType(v["type"].(string))
+
+	identityIds := make([]string, 0)
+	switch identityType {
+	case userAssigned, systemAssignedUserAssigned:
+		for _, id := range identityIdsFromRaw(v["identity_ids"]) {
+			identityIds = append(identityIds, id.(string))
+		}
+
+		if len(identityIds) == 0 {
+			log.Printf("[WARN] `identity_ids` should be specified when `type` includes `UserAssigned` - this will be required in version 3.0 of the Azure Provider")
+		}
+
+	default:
+		if len(identityIdsFromRaw(v["identity_ids"])) > 0 {
+			log.Printf("[WARN] `identity_ids` are ignored since `type` is %q - specifying these will be an error in version 3.0 of the Azure Provider", identityType)
+		}
+	}
+
+	return &ExpandedConfig{
+		Type:                    identityType,
+		UserAssignedIdentityIds: &identityIds,
+	}, nil
+}
+
+// identityIdsFromRaw returns the `identity_ids` - which is a Set, however some resources previously exposed this as a List
+func identityIdsFromRaw(input interface{}) []interface{} {
+	switch raw := input.(type) {
+	case *schema.Set:
+		return raw.List()
+	case []interface{}:
+		return raw
+	}
+
+	return []interface{}{}
+}
+
+func (s SystemAssignedUserAssigned) Flatten(input *ExpandedConfig) []interface{} {
+	if input == nil || input.Type == none {
+		return []interface{}{}
+	}
+
+	var coalesce = func(input *string) string {
+		if input == nil {
+			return ""
+		}
+
+		return *input
+	}
+
+	identityIds := make([]interface{}, 0)
+	if input.UserAssignedIdentityIds != nil {
+		for _, id := range *input.UserAssignedIdentityIds {
+			identityIds = append(identityIds, id)
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":         normalizeType(input.Type),
+			"identity_ids": identityIds,
+			"principal_id": coalesce(input.PrincipalId),
+			"tenant_id":    coalesce(input.TenantId),
+		},
+	}
+}
+
+// FlattenIncludingNone flattens the ExpandedConfig in the same manner as Flatten, however (prior to 3.0) the `None`
+// type is flattened into the `identity` block rather than omitting it. This is for resources which previously
+// supported `type = "None"`, where the `identity` block is Computed - so omitting the block doesn't cause a diff.
+func (s SystemAssignedUserAssigned) FlattenIncludingNone(input *ExpandedConfig) []interface{} {
+	if input == nil || input.Type != none || features.ThreePointOh() {
+		return s.Flatten(input)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"type":         none,
+			"identity_ids": []interface{}{},
+			"principal_id": "",
+			"tenant_id":    "",
+		},
+	}
+}
+
+func (s SystemAssignedUserAssigned) Schema() *schema.Schema {
+	types := []string{
+		systemAssigned,
+		userAssigned,
+		systemAssignedUserAssigned,
+	}
+	if !features.ThreePointOh() {
+		// some resources previously supported `None` rather than omitting the `identity` block
+		types = append(types, none)
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateFunc:     validation.StringInSlice(types, true),
+					DiffSuppressFunc: suppress.CaseDifference,
+				},
+				"identity_ids": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validate.UserAssignedIdentityID,
+					},
+				},
+				"principal_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"tenant_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func (s SystemAssignedUserAssigned) SchemaDataSource() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"identity_ids": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"principal_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"tenant_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,242 @@
+package identity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestSystemAssignedUserAssignedExpand(t *testing.T) {
+	testData := []struct {
+		name        string
+		input       []interface{}
+		expected    *ExpandedConfig
+		expectError bool
+	}{
+		{
+			name:  "omitted",
+			input: []interface{}{},
+			expected: &ExpandedConfig{
+				Type: none,
+			},
+		},
+		{
+			name: "none",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "None",
+					"identity_ids": []interface{}{},
+				},
+			},
+			expected: &ExpandedConfig{
+				Type:                    none,
+				UserAssignedIdentityIds: &[]string{},
+			},
+		},
+		{
+			name: "system assigned with a different casing",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "systemassigned",
+					"identity_ids": schema.NewSet(schema.HashString, []interface{}{}),
+				},
+			},
+			expected: &ExpandedConfig{
+				Type:                    systemAssigned,
+				UserAssignedIdentityIds: &[]string{},
+			},
+		},
+		{
+			name: "system assigned, user assigned from a list",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned,UserAssigned",
+					"identity_ids": []interface{}{testIdentityId},
+				},
+			},
+			expected: &ExpandedConfig{
+				Type:                    systemAssignedUserAssigned,
+				UserAssignedIdentityIds: &[]string{testIdentityId},
+			},
+		},
+		{
+			name: "user assigned without identity ids",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "UserAssigned",
+					"identity_ids": schema.NewSet(schema.HashString, []interface{}{}),
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "system assigned with identity ids",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned",
+					"identity_ids": schema.NewSet(schema.HashString, []interface{}{testIdentityId}),
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := SystemAssignedUserAssigned{}.Expand(v.input)
+		if v.expectError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestSystemAssignedUserAssignedExpandWithoutValidation(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    []interface{}
+		expected *ExpandedConfig
+	}{
+		{
+			name:  "omitted",
+			input: []interface{}{},
+			expected: &ExpandedConfig{
+				Type: none,
+			},
+		},
+		{
+			name: "user assigned",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "UserAssigned",
+					"identity_ids": []interface{}{testIdentityId},
+				},
+			},
+			expected: &ExpandedConfig{
+				Type:                    userAssigned,
+				UserAssignedIdentityIds: &[]string{testIdentityId},
+			},
+		},
+		{
+			name: "user assigned without identity ids",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "UserAssigned",
+					"identity_ids": []interface{}{},
+				},
+			},
+			expected: &ExpandedConfig{
+				Type:                    userAssigned,
+				UserAssignedIdentityIds: &[]string{},
+			},
+		},
+		{
+			name: "system assigned with identity ids",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned",
+					"identity_ids": []interface{}{testIdentityId},
+				},
+			},
+			expected: &ExpandedConfig{
+				Type:                    systemAssigned,
+				UserAssignedIdentityIds: &[]string{},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := SystemAssignedUserAssigned{}.ExpandWithoutValidation(v.input)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestSystemAssignedUserAssignedFlatten(t *testing.T) {
+	principalId := "11111111-1111-1111-1111-111111111111"
+	tenantId := "22222222-2222-2222-2222-222222222222"
+
+	testData := []struct {
+		name                  string
+		input                 *ExpandedConfig
+		expected              []interface{}
+		expectedIncludingNone []interface{}
+	}{
+		{
+			name:                  "nil",
+			input:                 nil,
+			expected:              []interface{}{},
+			expectedIncludingNone: []interface{}{},
+		},
+		{
+			name: "none",
+			input: &ExpandedConfig{
+				Type: none,
+			},
+			expected: []interface{}{},
+			expectedIncludingNone: []interface{}{
+				map[string]interface{}{
+					"type":         none,
+					"identity_ids": []interface{}{},
+					"principal_id": "",
+					"tenant_id":    "",
+				},
+			},
+		},
+		{
+			name: "system assigned, user assigned",
+			input: &ExpandedConfig{
+				Type:                    systemAssignedUserAssigned,
+				PrincipalId:             &principalId,
+				TenantId:                &tenantId,
+				UserAssignedIdentityIds: &[]string{testIdentityId},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"type":         systemAssignedUserAssigned,
+					"identity_ids": []interface{}{testIdentityId},
+					"principal_id": principalId,
+					"tenant_id":    tenantId,
+				},
+			},
+			expectedIncludingNone: []interface{}{
+				map[string]interface{}{
+					"type":         systemAssignedUserAssigned,
+					"identity_ids": []interface{}{testIdentityId},
+					"principal_id": principalId,
+					"tenant_id":    tenantId,
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := SystemAssignedUserAssigned{}.Flatten(v.input)
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+
+		actual = SystemAssignedUserAssigned{}.FlattenIncludingNone(v.input)
+		if !reflect.DeepEqual(actual, v.expectedIncludingNone) {
+			t.Fatalf("expected %+v when including `None` but got %+v", v.expectedIncludingNone, actual)
+		}
+	}
+}
@@ -16,8 +16,15 @@ func (u UserAssigned) Expand(input []interface{}) (*ExpandedConfig, error) {
 		}, nil
 	}
 
+	v := input[0].(map[string]interface{})
+	identityIds := make([]string, 0)
+	for _, id := range v["identity_ids"].([]interface{}) {
+		identityIds = append(identityIds, id.(string))
+	}
+
 	return &ExpandedConfig{
-		Type: systemAssigned,
+		Type:                    userAssigned,
+		UserAssignedIdentityIds: &identityIds,
 	}, nil
 }
 
@@ -26,19 +33,17 @@ func (u UserAssigned) Flatten(input *ExpandedConfig) []interface{} {
 		return []interface{}{}
 	}
 
-	var coalesce = func(input *string) string {
-		if input == nil {
-			return ""
+	identityIds := make([]interface{}, 0)
+	if input.UserAssignedIdentityIds != nil {
+		for _, id := range *input.UserAssignedIdentityIds {
+			identityIds = append(identityIds, id)
 		}
-
-		return *input
 	}
 
 	return []interface{}{
 		map[string]interface{}{
 			"type":         input.Type,
-			"principal_id": coalesce(input.PrincipalId),
-			"tenant_id":    coalesce(input.TenantId),
+			"identity_ids": identityIds,
 		},
 	}
 }
@@ -0,0 +1,158 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	policyInsightsPreview "github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// PolicyDenial is an Azure Policy which would deny the Resource being provisioned
+type PolicyDenial struct {
+	// PolicyAssignmentId is the ID of the Policy Assignment, e.g. `/subscriptions/.../policyAssignments/allowed-locations`
+	PolicyAssignmentId string
+
+	// PolicyDefinitionId is the ID of the Policy Definition which denies the Resource
+	PolicyDefinitionId string
+
+	// PolicySetDefinitionId is the ID of the Policy Set Definition (Initiative), when assigned as part of one
+	PolicySetDefinitionId string
+
+	// Reason is the reason this Resource would be denied, e.g. the field and the denied values
+	Reason string
+}
+
+// PolicyChecker evaluates the would-be content of a Resource against the Azure Policies assigned
+// to the Scope it'll be provisioned within, using the Policy Restrictions API
+type PolicyChecker struct {
+	client         *policyInsightsPreview.PolicyRestrictionsClient
+	subscriptionId string
+}
+
+func NewPolicyChecker(client *clients.Client) *PolicyChecker {
+	return &PolicyChecker{
+		client:         client.Policy.RestrictionsClient,
+		subscriptionId: client.Account.SubscriptionId,
+	}
+}
+
+// Check returns the Azure Policies which would deny the specified Resource Content (in the same format as
+// the body of the request to Azure Resource Manager) from being provisioned within the specified Resource
+// Group - or the Subscription, when no Resource Group is specified
+func (c *PolicyChecker) Check(ctx context.Context, resourceGroup string, content map[string]interface{}, apiVersion string) ([]PolicyDenial, error) {
+	details := &policyInsightsPreview.CheckRestrictionsResourceDetails{
+		ResourceContent: content,
+	}
+	if apiVersion != "" {
+		details.APIVersion = utils.String(apiVersion)
+	}
+	request := policyInsightsPreview.CheckRestrictionsRequest{
+		ResourceDetails: details,
+	}
+
+	var result policyInsightsPreview.CheckRestrictionsResult
+	var err error
+	if resourceGroup != "" {
+		result, err = c.client.CheckAtResourceGroupScope(ctx, c.subscriptionId, resourceGroup, request)
+	} else {
+		result, err = c.client.CheckAtSubscriptionScope(ctx, c.subscriptionId, request)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("checking Policy Restrictions: %+v", err)
+	}
+
+	return PolicyDenials(result, content), nil
+}
+
+// PolicyDenials returns the Azure Policies which deny the specified Resource Content, from both the evaluation
+// of the Resource Content and any restrictions on the (top-level) fields within the Resource Content
+func PolicyDenials(result policyInsightsPreview.CheckRestrictionsResult, content map[string]interface{}) []PolicyDenial {
+	output := make([]PolicyDenial, 0)
+
+	if v := result.ContentEvaluationResult; v != nil && v.PolicyEvaluations != nil {
+		for _, evaluation := range *v.PolicyEvaluations {
+			if !strings.EqualFold(utils.NormalizeNilableString(evaluation.EvaluationResult), "NonCompliant") {
+				continue
+			}
+
+			denial := policyDenialFromReference(evaluation.PolicyInfo)
+			denial.Reason = "the resource is non-compliant with this policy"
+			output = append(output, denial)
+		}
+	}
+
+	if result.FieldRestrictions != nil {
+		for _, field := range *result.FieldRestrictions {
+			if field.Field == nil || field.Restrictions == nil {
+				continue
+			}
+
+			value, ok := content[*field.Field].(string)
+			if !ok {
+				continue
+			}
+
+			for _, restriction := range *field.Restrictions {
+				if restriction.Result != policyInsightsPreview.Deny || restriction.Values == nil {
+					continue
+				}
+
+				if !containsValue(*restriction.Values, value) {
+					continue
+				}
+
+				denial := policyDenialFromReference(restriction.Policy)
+				denial.Reason = fmt.Sprintf("the value %q for the field %q is denied", value, *field.Field)
+				output = append(output, denial)
+			}
+		}
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].PolicyAssignmentId+output[i].Reason < output[j].PolicyAssignmentId+output[j].Reason
+	})
+	return output
+}
+
+// PolicyDenialsError returns a single error listing each of the Azure Policies which deny the specified Resource
+func PolicyDenialsError(resourceType string, denials []PolicyDenial) error {
+	lines := make([]string, 0)
+	for _, v := range denials {
+		lines = append(lines, fmt.Sprintf("* Policy Assignment %q: %s", v.PolicyAssignmentId, v.Reason))
+		if v.PolicySetDefinitionId != "" {
+			lines = append(lines, fmt.Sprintf("  Policy Set Definition: %s", v.PolicySetDefinitionId))
+		}
+		if v.PolicyDefinitionId != "" {
+			lines = append(lines, fmt.Sprintf("  Policy Definition: %s", v.PolicyDefinitionId))
+		}
+	}
+
+	return fmt.Errorf(`this %s would be denied by %d Azure Policy evaluation(s):
+
+%s`, resourceType, len(denials), strings.Join(lines, "\n"))
+}
+
+func policyDenialFromReference(input *policyInsightsPreview.PolicyReference) PolicyDenial {
+	if input == nil {
+		return PolicyDenial{}
+	}
+
+	return PolicyDenial{
+		PolicyAssignmentId:    utils.NormalizeNilableString(input.PolicyAssignmentID),
+		PolicyDefinitionId:    utils.NormalizeNilableString(input.PolicyDefinitionID),
+		PolicySetDefinitionId: utils.NormalizeNilableString(input.PolicySetDefinitionID),
+	}
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package preflight
+
+import (
+	"strings"
+	"testing"
+
+	policyInsightsPreview "github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestPolicyDenials(t *testing.T) {
+	allowedLocations := "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/policyAssignments/allowed-locations"
+	requireTags := "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/policyAssignments/require-tags"
+
+	result := policyInsightsPreview.CheckRestrictionsResult{
+		FieldRestrictions: &[]policyInsightsPreview.FieldRestrictions{
+			{
+				Field: utils.String("location"),
+				Restrictions: &[]policyInsightsPreview.FieldRestriction{
+					{
+						Result: policyInsightsPreview.Deny,
+						Values: &[]string{"westeurope", "northeurope"},
+						Policy: &policyInsightsPreview.PolicyReference{
+							PolicyAssignmentID: utils.String(allowedLocations),
+						},
+					},
+					{
+						Result: policyInsightsPreview.Required,
+						Values: &[]string{"westus"},
+					},
+				},
+			},
+			{
+				Field: utils.String("type"),
+				Restrictions: &[]policyInsightsPreview.FieldRestriction{
+					{
+						Result: policyInsightsPreview.Deny,
+						Values: &[]string{"Microsoft.Compute/virtualMachines"},
+					},
+				},
+			},
+		},
+		ContentEvaluationResult: &policyInsightsPreview.CheckRestrictionsResultContentEvaluationResult{
+			PolicyEvaluations: &[]policyInsightsPreview.PolicyEvaluationResult{
+				{
+					EvaluationResult: utils.String("NonCompliant"),
+					PolicyInfo: &policyInsightsPreview.PolicyReference{
+						PolicyAssignmentID: utils.String(requireTags),
+						PolicyDefinitionID: utils.String("/providers/Microsoft.Authorization/policyDefinitions/require-tags"),
+					},
+				},
+				{
+					EvaluationResult: utils.String("Compliant"),
+				},
+			},
+		},
+	}
+	content := map[string]interface{}{
+		"type":     "Microsoft.Storage/storageAccounts",
+		"location": "WestEurope",
+	}
+
+	denials := PolicyDenials(result, content)
+	if len(denials) != 2 {
+		t.Fatalf("expected 2 denials but got %d: %+v", len(denials), denials)
+	}
+	if denials[0].PolicyAssignmentId != allowedLocations || !strings.Contains(denials[0].Reason, `"location"`) {
+		t.Fatalf("expected the first denial to be for the location but got %+v", denials[0])
+	}
+	if denials[1].PolicyAssignmentId != requireTags {
+		t.Fatalf("expected the second denial to be for the tags but got %+v", denials[1])
+	}
+
+	err := PolicyDenialsError("azurerm_storage_account", denials)
+	if !strings.Contains(err.Error(), allowedLocations) || !strings.Contains(err.Error(), "/policyDefinitions/require-tags") {
+		t.Fatalf("expected the error to include each Policy but got %q", err.Error())
+	}
+}
@@ -0,0 +1,248 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2020-04-01-preview/authorization"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/parse"
+)
+
+// the SDK in use doesn't support listing the permissions at the Subscription scope, so this
+// request is made directly using the same API Version used for the Resource Group scope
+const permissionsAPIVersion = "2018-01-01-preview"
+
+var subscriptionScopeRegex = regexp.MustCompile(`^/subscriptions/([^/]+)/?$`)
+
+// MissingPermission is an RBAC Action required by a Resource which isn't granted at the Scope
+type MissingPermission struct {
+	// ResourceType is the type of the Resource which requires this Action, e.g. `azurerm_subnet`
+	ResourceType string
+
+	// Action is the RBAC Action which is missing, e.g. `Microsoft.Network/virtualNetworks/subnets/join/action`
+	Action string
+}
+
+// Checker checks the RBAC Actions granted to the credentials being used, caching the
+// permissions granted at each Scope, since these are requested for each Resource
+type Checker struct {
+	client *authorization.PermissionsClient
+
+	lock        sync.Mutex
+	permissions map[string][]authorization.Permission
+}
+
+func NewChecker(client *clients.Client) *Checker {
+	return &Checker{
+		client:      client.Authorization.PermissionsClient,
+		permissions: make(map[string][]authorization.Permission),
+	}
+}
+
+// Check checks that the credentials being used are granted each of the RBAC Actions required (a map of Resource
+// Type to RBAC Actions) at the specified Scope (a Subscription or Resource Group ID) - returning a single error
+// listing all of the missing permissions
+func (c *Checker) Check(ctx context.Context, scope string, required map[string][]string) error {
+	missing, err := c.Missing(ctx, scope, required)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0)
+	for _, v := range missing {
+		lines = append(lines, fmt.Sprintf("* %s: %s", v.ResourceType, v.Action))
+	}
+
+	return fmt.Errorf(`the credentials being used are missing %d permission(s) at the scope %q which are required to provision these resources:
+
+%s`, len(missing), scope, strings.Join(lines, "\n"))
+}
+
+// Missing returns the RBAC Actions required (a map of Resource Type to RBAC Actions) which the credentials
+// being used aren't granted at the specified Scope (a Subscription or Resource Group ID)
+func (c *Checker) Missing(ctx context.Context, scope string, required map[string][]string) ([]MissingPermission, error) {
+	if len(required) == 0 {
+		return []MissingPermission{}, nil
+	}
+
+	permissions, err := c.permissionsForScope(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the permissions granted at the scope %q: %+v", scope, err)
+	}
+
+	return MissingPermissions(permissions, required), nil
+}
+
+func (c *Checker) permissionsForScope(ctx context.Context, scope string) ([]authorization.Permission, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := strings.ToLower(scope)
+	if v, ok := c.permissions[key]; ok {
+		return v, nil
+	}
+
+	permissions, err := listPermissions(ctx, c.client, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	c.permissions[key] = permissions
+	return permissions, nil
+}
+
+// MissingPermissions returns the required RBAC Actions which aren't granted by the specified Permissions
+func MissingPermissions(permissions []authorization.Permission, required map[string][]string) []MissingPermission {
+	output := make([]MissingPermission, 0)
+	for resourceType, actions := range required {
+		for _, action := range actions {
+			if !isActionAllowed(permissions, action) {
+				output = append(output, MissingPermission{
+					ResourceType: resourceType,
+					Action:       action,
+				})
+			}
+		}
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].ResourceType != output[j].ResourceType {
+			return output[i].ResourceType < output[j].ResourceType
+		}
+		return output[i].Action < output[j].Action
+	})
+
+	return output
+}
+
+// isActionAllowed returns whether any of the Permissions (e.g. from a Role Assignment) allow the specified
+// Action - where it must be matched by one of the Actions and none of the NotActions of that Permission
+func isActionAllowed(permissions []authorization.Permission, action string) bool {
+	for _, permission := range permissions {
+		if permission.Actions == nil || !matchesAny(*permission.Actions, action) {
+			continue
+		}
+		if permission.NotActions != nil && matchesAny(*permission.NotActions, action) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func matchesAny(patterns []string, action string) bool {
+	for _, pattern := range patterns {
+		if matchesAction(pattern, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAction returns whether the RBAC Action pattern (which can contain wildcards, e.g.
+// `Microsoft.Network/*/read`) matches the specified Action, which is compared case-insensitively
+func matchesAction(pattern, action string) bool {
+	segments := strings.Split(pattern, "*")
+	for i, v := range segments {
+		segments[i] = regexp.QuoteMeta(v)
+	}
+	expression := fmt.Sprintf("(?i)^%s$", strings.Join(segments, ".*"))
+
+	matched, err := regexp.MatchString(expression, action)
+	return err == nil && matched
+}
+
+func listPermissions(ctx context.Context, client *authorization.PermissionsClient, scope string) ([]authorization.Permission, error) {
+	if subscriptionScopeRegex.MatchString(scope) {
+		return listPermissionsForSubscription(ctx, client, subscriptionScopeRegex.FindStringSubmatch(scope)[1])
+	}
+
+	id, err := parse.ResourceGroupID(scope)
+	if err != nil {
+		return nil, fmt.Errorf("the scope must be a Subscription or Resource Group ID: %+v", err)
+	}
+	if !strings.EqualFold(id.SubscriptionId, client.SubscriptionID) {
+		return nil, fmt.Errorf("the Resource Group must exist within the Subscription %q", client.SubscriptionID)
+	}
+
+	output := make([]authorization.Permission, 0)
+	iterator, err := client.ListForResourceGroupComplete(ctx, id.ResourceGroup)
+	if err != nil {
+		if v, ok := err.(autorest.DetailedError); ok && v.StatusCode == http.StatusNotFound {
+			// the Resource Group will be created as a part of this plan, so will inherit the permissions from the Subscription
+			log.Printf("[DEBUG] %s was not found - checking the permissions granted at the Subscription scope", id)
+			return listPermissionsForSubscription(ctx, client, id.SubscriptionId)
+		}
+		return nil, fmt.Errorf("listing permissions for %s: %+v", id, err)
+	}
+	for iterator.NotDone() {
+		output = append(output, iterator.Value())
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing permissions for %s: %+v", id, err)
+		}
+	}
+
+	return output, nil
+}
+
+func listPermissionsForSubscription(ctx context.Context, client *authorization.PermissionsClient, subscriptionId string) ([]authorization.Permission, error) {
+	pathParameters := map[string]interface{}{
+		"subscriptionId": autorest.Encode("path", subscriptionId),
+	}
+	queryParameters := map[string]interface{}{
+		"api-version": permissionsAPIVersion,
+	}
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/subscriptions/{subscriptionId}/providers/Microsoft.Authorization/permissions", pathParameters),
+		autorest.WithQueryParameters(queryParameters))
+
+	output := make([]authorization.Permission, 0)
+	for preparer != nil {
+		req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("preparing request to list permissions for Subscription %q: %+v", subscriptionId, err)
+		}
+
+		resp, err := client.Send(req, azure.DoRetryWithRegistration(client.Client))
+		if err != nil {
+			return nil, fmt.Errorf("listing permissions for Subscription %q: %+v", subscriptionId, err)
+		}
+
+		var result authorization.PermissionGetResult
+		err = autorest.Respond(
+			resp,
+			azure.WithErrorUnlessStatusCode(http.StatusOK),
+			autorest.ByUnmarshallingJSON(&result),
+			autorest.ByClosing())
+		if err != nil {
+			return nil, fmt.Errorf("listing permissions for Subscription %q: %+v", subscriptionId, err)
+		}
+
+		if result.Value != nil {
+			output = append(output, *result.Value...)
+		}
+
+		preparer = nil
+		if result.NextLink != nil && *result.NextLink != "" {
+			preparer = autorest.CreatePreparer(autorest.AsGet(), autorest.WithBaseURL(*result.NextLink))
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,219 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2020-04-01-preview/authorization"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	authorizationClient "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/authorization/client"
+)
+
+const testSubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+func TestMatchesAction(t *testing.T) {
+	testData := []struct {
+		pattern  string
+		action   string
+		expected bool
+	}{
+		{
+			pattern:  "*",
+			action:   "Microsoft.Network/virtualNetworks/write",
+			expected: true,
+		},
+		{
+			pattern:  "Microsoft.Network/*",
+			action:   "Microsoft.Network/virtualNetworks/subnets/join/action",
+			expected: true,
+		},
+		{
+			pattern:  "*/read",
+			action:   "Microsoft.Network/virtualNetworks/read",
+			expected: true,
+		},
+		{
+			pattern:  "*/read",
+			action:   "Microsoft.Network/virtualNetworks/write",
+			expected: false,
+		},
+		{
+			pattern:  "microsoft.network/virtualnetworks/subnets/join/action",
+			action:   "Microsoft.Network/virtualNetworks/subnets/join/action",
+			expected: true,
+		},
+		{
+			pattern:  "Microsoft.Network/virtualNetworks/subnets/*/action",
+			action:   "Microsoft.Network/virtualNetworks/subnets/join/action",
+			expected: true,
+		},
+		{
+			pattern:  "Microsoft.Network/virtualNetworks.write",
+			action:   "Microsoft.Network/virtualNetworks/write",
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q matches %q..", v.pattern, v.action)
+
+		if actual := matchesAction(v.pattern, v.action); actual != v.expected {
+			t.Fatalf("expected %t but got %t", v.expected, actual)
+		}
+	}
+}
+
+func TestMissingPermissions(t *testing.T) {
+	permissions := []authorization.Permission{
+		{
+			// Network Contributor, without the ability to join Subnets
+			Actions:    &[]string{"Microsoft.Network/*", "Microsoft.Resources/subscriptions/resourceGroups/read"},
+			NotActions: &[]string{"Microsoft.Network/virtualNetworks/subnets/join/action"},
+		},
+		{
+			Actions: &[]string{"*/read"},
+		},
+	}
+	required := map[string][]string{
+		"azurerm_network_interface": {
+			"Microsoft.Network/networkInterfaces/write",
+			"Microsoft.Network/virtualNetworks/subnets/join/action",
+		},
+		"azurerm_storage_account": {
+			"Microsoft.Storage/storageAccounts/read",
+			"Microsoft.Storage/storageAccounts/write",
+			"Microsoft.Storage/storageAccounts/listKeys/action",
+		},
+	}
+
+	expected := []MissingPermission{
+		{
+			ResourceType: "azurerm_network_interface",
+			Action:       "Microsoft.Network/virtualNetworks/subnets/join/action",
+		},
+		{
+			ResourceType: "azurerm_storage_account",
+			Action:       "Microsoft.Storage/storageAccounts/listKeys/action",
+		},
+		{
+			ResourceType: "azurerm_storage_account",
+			Action:       "Microsoft.Storage/storageAccounts/write",
+		},
+	}
+	if actual := MissingPermissions(permissions, required); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+// fakePermissions is a fake of the Azure Resource Manager Permissions API, where the Resource Group `example`
+// grants Contributor and the Subscription grants Reader
+type fakePermissions struct {
+	requests []string
+}
+
+func (f *fakePermissions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests = append(f.requests, r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	subscriptionPrefix := fmt.Sprintf("/subscriptions/%s", testSubscriptionId)
+	switch strings.ToLower(r.URL.Path) {
+	case strings.ToLower(subscriptionPrefix + "/resourceGroups/example/providers/Microsoft.Authorization/permissions"):
+		fmt.Fprint(w, `{"value": [{"actions": ["*"], "notActions": ["Microsoft.Authorization/*/Write", "Microsoft.Authorization/*/Delete"]}]}`)
+
+	case strings.ToLower(subscriptionPrefix + "/providers/Microsoft.Authorization/permissions"):
+		fmt.Fprint(w, `{"value": [{"actions": ["*/read"], "notActions": []}]}`)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"error": {"code": "ResourceGroupNotFound", "message": "%s"}}`, r.URL.Path)
+	}
+}
+
+func testChecker(endpoint string) *Checker {
+	o := &common.ClientOptions{
+		SubscriptionId:              testSubscriptionId,
+		ResourceManagerAuthorizer:   autorest.NullAuthorizer{},
+		ResourceManagerEndpoint:     endpoint,
+		SkipProviderReg:             true,
+		DisableCorrelationRequestID: true,
+	}
+	return NewChecker(&clients.Client{
+		Authorization: authorizationClient.NewClient(o),
+	})
+}
+
+func TestCheckerCheck(t *testing.T) {
+	fake := &fakePermissions{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	checker := testChecker(server.URL)
+	required := map[string][]string{
+		"azurerm_role_assignment": {
+			"Microsoft.Authorization/roleAssignments/read",
+			"Microsoft.Authorization/roleAssignments/write",
+		},
+		"azurerm_virtual_network": {
+			"Microsoft.Network/virtualNetworks/read",
+			"Microsoft.Network/virtualNetworks/write",
+		},
+	}
+
+	testData := []struct {
+		scope   string
+		missing []string
+	}{
+		{
+			scope:   fmt.Sprintf("/subscriptions/%s/resourceGroups/example", testSubscriptionId),
+			missing: []string{"azurerm_role_assignment: Microsoft.Authorization/roleAssignments/write"},
+		},
+		{
+			scope: fmt.Sprintf("/subscriptions/%s", testSubscriptionId),
+			missing: []string{
+				"azurerm_role_assignment: Microsoft.Authorization/roleAssignments/write",
+				"azurerm_virtual_network: Microsoft.Network/virtualNetworks/write",
+			},
+		},
+		{
+			// a Resource Group which doesn't exist yet inherits the permissions from the Subscription
+			scope: fmt.Sprintf("/subscriptions/%s/resourceGroups/new", testSubscriptionId),
+			missing: []string{
+				"azurerm_role_assignment: Microsoft.Authorization/roleAssignments/write",
+				"azurerm_virtual_network: Microsoft.Network/virtualNetworks/write",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.scope)
+
+		err := checker.Check(context.TODO(), v.scope, required)
+		if err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !strings.Contains(err.Error(), fmt.Sprintf("missing %d permission(s)", len(v.missing))) {
+			t.Fatalf("expected %d missing permissions but got %+v", len(v.missing), err)
+		}
+		for _, missing := range v.missing {
+			if !strings.Contains(err.Error(), fmt.Sprintf("* %s", missing)) {
+				t.Fatalf("expected %q to be missing but got %+v", missing, err)
+			}
+		}
+	}
+
+	// the permissions for each scope should only be retrieved once
+	requests := len(fake.requests)
+	if err := checker.Check(context.TODO(), fmt.Sprintf("/subscriptions/%s", testSubscriptionId), required); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if len(fake.requests) != requests {
+		t.Fatalf("expected the permissions to be cached but got %d requests", len(fake.requests)-requests)
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+)
+
+// apiProfileSupport returns the names of the Data Sources and Resources which the Service Registrations
+// declare are supported on the specified API Profile
+func apiProfileSupport(profile string) (dataSources map[string]struct{}, resources map[string]struct{}) {
+	dataSources = make(map[string]struct{})
+	resources = make(map[string]struct{})
+
+	registrations := make([]interface{}, 0)
+	for _, service := range SupportedTypedServices() {
+		registrations = append(registrations, service)
+	}
+	for _, service := range SupportedUntypedServices() {
+		registrations = append(registrations, service)
+	}
+
+	for _, registration := range registrations {
+		v, ok := registration.(sdk.ServiceRegistrationWithAPIProfiles)
+		if !ok {
+			continue
+		}
+
+		support, ok := v.APIProfiles()[profile]
+		if !ok {
+			continue
+		}
+
+		for _, name := range support.DataSources {
+			dataSources[name] = struct{}{}
+		}
+		for _, name := range support.Resources {
+			resources[name] = struct{}{}
+		}
+	}
+
+	return dataSources, resources
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+)
+
+func TestAPIProfilesReferenceExistingDataSourcesAndResources(t *testing.T) {
+	provider := AzureProvider().(*schema.Provider)
+
+	for _, profile := range common.SupportedAPIProfiles() {
+		t.Logf("API Profile %q..", profile)
+		dataSources, resources := apiProfileSupport(profile)
+		if len(dataSources) == 0 || len(resources) == 0 {
+			t.Fatalf("expected the API Profile %q to support at least one Data Source and Resource", profile)
+		}
+
+		for name := range dataSources {
+			if _, ok := provider.DataSourcesMap[name]; !ok {
+				t.Fatalf("the API Profile %q references the Data Source %q which doesn't exist", profile, name)
+			}
+		}
+		for name := range resources {
+			if _, ok := provider.ResourcesMap[name]; !ok {
+				t.Fatalf("the API Profile %q references the Resource %q which doesn't exist", profile, name)
+			}
+		}
+	}
+}
+
+func TestAPIProfileOnlyRegistersSupportedResources(t *testing.T) {
+	existing, wasSet := os.LookupEnv("ARM_API_PROFILE")
+	defer func() {
+		if wasSet {
+			os.Setenv("ARM_API_PROFILE", existing)
+		} else {
+			os.Unsetenv("ARM_API_PROFILE")
+		}
+	}()
+	os.Setenv("ARM_API_PROFILE", common.APIProfile20190301Hybrid)
+
+	provider := AzureProvider().(*schema.Provider)
+	dataSources, resources := apiProfileSupport(common.APIProfile20190301Hybrid)
+
+	if len(provider.DataSourcesMap) != len(dataSources) {
+		t.Fatalf("expected %d Data Sources but got %d", len(dataSources), len(provider.DataSourcesMap))
+	}
+	if len(provider.ResourcesMap) != len(resources) {
+		t.Fatalf("expected %d Resources but got %d", len(resources), len(provider.ResourcesMap))
+	}
+	if _, ok := provider.ResourcesMap["azurerm_virtual_network"]; !ok {
+		t.Fatalf("expected `azurerm_virtual_network` to be registered")
+	}
+	if _, ok := provider.ResourcesMap["azurerm_kubernetes_cluster"]; ok {
+		t.Fatalf("expected `azurerm_kubernetes_cluster` not to be registered")
+	}
+}
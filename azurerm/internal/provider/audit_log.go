@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/auditlog"
+)
+
+// buildAuditLog returns the Audit Log which each request made to (and response from) the Azure API's is recorded
+// in when a path is specified - redacting both the known sensitive fields and those marked as Sensitive in the Schema
+func buildAuditLog(p *schema.Provider, path string) (*auditlog.Logger, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	return auditlog.New(path, auditlog.NewRedactor(sensitiveFields(p)...))
+}
+
+// closeAuditLogOnStop closes the Audit Log (if any) once the specified context is done - which is when
+// the Provider is stopped
+func closeAuditLogOnStop(ctx context.Context, auditLog *auditlog.Logger) {
+	if auditLog == nil {
+		return
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := auditLog.Close(); err != nil {
+			log.Printf("[WARN] %+v", err)
+		}
+	}()
+}
+
+// sensitiveFields returns the names of each field marked as Sensitive within the Schema of the Data Sources and Resources
+func sensitiveFields(p *schema.Provider) []string {
+	fields := make(map[string]struct{})
+	for _, resource := range p.DataSourcesMap {
+		appendSensitiveFields(fields, resource.Schema)
+	}
+	for _, resource := range p.ResourcesMap {
+		appendSensitiveFields(fields, resource.Schema)
+	}
+
+	output := make([]string, 0)
+	for field := range fields {
+		output = append(output, field)
+	}
+	sort.Strings(output)
+	return output
+}
+
+func appendSensitiveFields(fields map[string]struct{}, input map[string]*schema.Schema) {
+	for key, value := range input {
+		if value.Sensitive {
+			fields[key] = struct{}{}
+		}
+
+		if nested, ok := value.Elem.(*schema.Resource); ok {
+			appendSensitiveFields(fields, nested.Schema)
+		}
+	}
+}
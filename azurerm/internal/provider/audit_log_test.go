@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestSensitiveFields(t *testing.T) {
+	provider := AzureProvider().(*schema.Provider)
+
+	fields := sensitiveFields(provider)
+	for _, expected := range []string{"primary_access_key", "primary_connection_string"} {
+		found := false
+		for _, v := range fields {
+			if v == expected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("expected %q to be a sensitive field", expected)
+		}
+	}
+}
+
+func TestCloseAuditLogOnStop(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auditlog")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	auditLog, err := buildAuditLog(AzureProvider().(*schema.Provider), path)
+	if err != nil {
+		t.Fatalf("building the Audit Log: %+v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	closeAuditLogOnStop(ctx, auditLog)
+	cancel()
+
+	sender := auditLog.Sender(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+
+	// the Audit Log is closed asynchronously once the Provider is stopped, after which requests aren't recorded
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		req, err := http.NewRequest(http.MethodGet, "https://management.azure.com/example?api-version=2021-01-01", nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		if _, err := sender.Do(req); err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+
+		before, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading the Audit Log: %+v", err)
+		}
+		if _, err := sender.Do(req); err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+		after, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading the Audit Log: %+v", err)
+		}
+		if len(before) == len(after) {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the Audit Log to be closed once the Provider was stopped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
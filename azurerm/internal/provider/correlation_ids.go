@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+)
+
+// withOperationCorrelationRequestIDs wraps the CRUD functions for the specified Resource or Data Source, such that
+// (when enabled) each operation uses a separate Correlation Request ID derived from the Correlation Request ID for the
+// Terraform run and the Resource - which is logged (alongside the run-level Correlation Request ID) so that it can be
+// included in Support Requests.
+//
+// NOTE: the Terraform address (e.g. `module.example.azurerm_resource_group.test`) isn't available to the Provider, as
+// such the Resource Type and ID (or Resource Group and Name, prior to the Resource being created) is used instead.
+func withOperationCorrelationRequestIDs(resourceType string, resource *schema.Resource) {
+	var wrap = func(operation string, f func(d *schema.ResourceData, meta interface{}) error) func(d *schema.ResourceData, meta interface{}) error {
+		if f == nil {
+			return nil
+		}
+
+		return func(d *schema.ResourceData, meta interface{}) error {
+			client, ok := meta.(*clients.Client)
+			if !ok || !client.CorrelationRequestIDPerOperation {
+				return f(d, meta)
+			}
+
+			target := operationResource(resourceType, resource.Schema, d)
+			id := common.OperationCorrelationRequestID(client.CorrelationRequestID, operation, target)
+			log.Printf("[INFO] Using Correlation Request ID %q for the %s of %s (derived from the Correlation Request ID %q for this run, with the prefix %q)", id, operation, target, client.CorrelationRequestID, common.RunCorrelationRequestIDPrefix(client.CorrelationRequestID))
+
+			// the Service Clients are shared, so the Client is copied to scope the Stop Context to this operation
+			operationClient := *client
+			operationClient.StopContext = common.WithOperationCorrelationRequestID(client.StopContext, id)
+			return f(d, &operationClient)
+		}
+	}
+
+	resource.Create = wrap("Create", resource.Create)
+	resource.Read = wrap("Read", resource.Read)
+	resource.Update = wrap("Update", resource.Update)
+	resource.Delete = wrap("Delete", resource.Delete)
+}
+
+// operationResource returns the Resource Type and ID of the Resource being operated on, when known - otherwise the
+// Resource Group and Name (if any) are used, since the Resource ID isn't known until it's created
+func operationResource(resourceType string, resourceSchema map[string]*schema.Schema, d *schema.ResourceData) string {
+	if id := d.Id(); id != "" {
+		return fmt.Sprintf("%s (%s)", resourceType, id)
+	}
+
+	segments := make([]string, 0)
+	for _, key := range []string{"resource_group_name", "name"} {
+		if _, ok := resourceSchema[key]; !ok {
+			continue
+		}
+		if v, ok := d.Get(key).(string); ok && v != "" {
+			segments = append(segments, v)
+		}
+	}
+	if len(segments) == 0 {
+		return resourceType
+	}
+
+	return fmt.Sprintf("%s (%s)", resourceType, strings.Join(segments, "/"))
+}
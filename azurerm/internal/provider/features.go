@@ -2,6 +2,7 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
 )
 
@@ -54,6 +55,25 @@ func schemaFeatures(supportLegacyTestSuite bool) *schema.Schema {
 			},
 		},
 
+		"policy": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"deny_preflight": {
+						Type:     schema.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							features.PolicyDenyPreflightDisabled,
+							features.PolicyDenyPreflightWarning,
+							features.PolicyDenyPreflightError,
+						}, false),
+					},
+				},
+			},
+		},
+
 		"template_deployment": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -167,6 +187,16 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["policy"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 {
+			policyRaw := items[0].(map[string]interface{})
+			if v, ok := policyRaw["deny_preflight"]; ok {
+				features.Policy.DenyPreflight = v.(string)
+			}
+		}
+	}
+
 	if raw, ok := val["template_deployment"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 {
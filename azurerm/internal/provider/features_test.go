@@ -25,6 +25,9 @@ func TestExpandFeatures(t *testing.T) {
 				Network: features.NetworkFeatures{
 					RelaxedLocking: false,
 				},
+				Policy: features.PolicyFeatures{
+					DenyPreflight: features.PolicyDenyPreflightDisabled,
+				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: true,
 				},
@@ -59,6 +62,11 @@ func TestExpandFeatures(t *testing.T) {
 							"relaxed_locking": true,
 						},
 					},
+					"policy": []interface{}{
+						map[string]interface{}{
+							"deny_preflight": "Error",
+						},
+					},
 					"template_deployment": []interface{}{
 						map[string]interface{}{
 							"delete_nested_items_during_deletion": true,
@@ -88,6 +96,9 @@ func TestExpandFeatures(t *testing.T) {
 				Network: features.NetworkFeatures{
 					RelaxedLocking: true,
 				},
+				Policy: features.PolicyFeatures{
+					DenyPreflight: features.PolicyDenyPreflightError,
+				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: true,
 				},
@@ -115,6 +126,11 @@ func TestExpandFeatures(t *testing.T) {
 							"relaxed_locking": false,
 						},
 					},
+					"policy": []interface{}{
+						map[string]interface{}{
+							"deny_preflight": "Disabled",
+						},
+					},
 					"template_deployment": []interface{}{
 						map[string]interface{}{
 							"delete_nested_items_during_deletion": false,
@@ -149,6 +165,9 @@ func TestExpandFeatures(t *testing.T) {
 				Network: features.NetworkFeatures{
 					RelaxedLocking: false,
 				},
+				Policy: features.PolicyFeatures{
+					DenyPreflight: features.PolicyDenyPreflightDisabled,
+				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: false,
 				},
@@ -307,6 +326,71 @@ func TestExpandFeaturesNetwork(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesPolicy(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"policy": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				Policy: features.PolicyFeatures{
+					DenyPreflight: features.PolicyDenyPreflightDisabled,
+				},
+			},
+		},
+		{
+			Name: "Deny Preflight Warning",
+			Input: []interface{}{
+				map[string]interface{}{
+					"policy": []interface{}{
+						map[string]interface{}{
+							"deny_preflight": "Warning",
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Policy: features.PolicyFeatures{
+					DenyPreflight: features.PolicyDenyPreflightWarning,
+				},
+			},
+		},
+		{
+			Name: "Deny Preflight Error",
+			Input: []interface{}{
+				map[string]interface{}{
+					"policy": []interface{}{
+						map[string]interface{}{
+							"deny_preflight": "Error",
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				Policy: features.PolicyFeatures{
+					DenyPreflight: features.PolicyDenyPreflightError,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.Policy, testCase.Expected.Policy) {
+			t.Fatalf("Expected %+v but got %+v", result.Policy, testCase.Expected.Policy)
+		}
+	}
+}
+
 func TestExpandFeaturesTemplateDeployment(t *testing.T) {
 	testData := []struct {
 		Name     string
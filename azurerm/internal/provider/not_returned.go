@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+)
+
+// FieldsNotReturnedByAPI returns a map of Resource to the fields which aren't returned from the API,
+// as declared by the Service Registrations
+func FieldsNotReturnedByAPI() map[string][]string {
+	registrations := make([]interface{}, 0)
+	for _, service := range SupportedTypedServices() {
+		registrations = append(registrations, service)
+	}
+	for _, service := range SupportedUntypedServices() {
+		registrations = append(registrations, service)
+	}
+
+	output := make(map[string][]string)
+	for _, registration := range registrations {
+		if v, ok := registration.(sdk.ServiceRegistrationWithFieldsNotReturnedByAPI); ok {
+			for resourceType, fields := range v.FieldsNotReturnedByAPI() {
+				output[resourceType] = append(output[resourceType], fields...)
+			}
+		}
+	}
+
+	return output
+}
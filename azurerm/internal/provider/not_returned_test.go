@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	azSchema "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/schema"
+)
+
+func TestFieldsNotReturnedByAPIReferenceExistingFields(t *testing.T) {
+	provider := AzureProvider().(*schema.Provider)
+
+	for resourceType, fields := range FieldsNotReturnedByAPI() {
+		resource, ok := provider.ResourcesMap[resourceType]
+		if !ok {
+			t.Fatalf("the fields not returned by the API reference the Resource %q which doesn't exist", resourceType)
+		}
+		if _, err := azSchema.ImportVerifyIgnoreFields(resource.Schema, fields); err != nil {
+			t.Fatalf("the fields not returned by the API for %q are invalid: %+v", resourceType, err)
+		}
+	}
+}
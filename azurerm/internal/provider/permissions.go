@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/preflight"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	resourceParse "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/parse"
+)
+
+// permissionCheckers contains a preflight.Checker for each Client, since multiple (aliased) providers
+// can use different credentials - each of which caches the permissions granted at each Scope
+var permissionCheckers sync.Map
+
+// permissionReports contains a permissionReport for each Client, which collects the missing permissions
+// across all of the Resources in the plan
+var permissionReports sync.Map
+
+// permissionReportQuietPeriod is how long to wait for other Resources in the plan to be checked, before
+// reporting all of the missing permissions which have been found
+const permissionReportQuietPeriod = 2 * time.Second
+
+// RequiredPermissions returns a map of Resource to the RBAC Actions required to provision it,
+// as declared by the Service Registrations
+func RequiredPermissions() map[string][]string {
+	registrations := make([]interface{}, 0)
+	for _, service := range SupportedTypedServices() {
+		registrations = append(registrations, service)
+	}
+	for _, service := range SupportedUntypedServices() {
+		registrations = append(registrations, service)
+	}
+
+	output := make(map[string][]string)
+	for _, registration := range registrations {
+		if v, ok := registration.(sdk.ServiceRegistrationWithPermissions); ok {
+			for resourceType, actions := range v.RequiredPermissions() {
+				output[resourceType] = actions
+			}
+		}
+	}
+
+	return output
+}
+
+// withPermissionPreflight returns a CustomizeDiffFunc which (when enabled in the Provider block) checks that the
+// credentials being used are granted the RBAC Actions required to provision this Resource, before calling the
+// existing CustomizeDiffFunc of the Resource (if any). The missing permissions are collected across the plan
+// and reported in a single error, rather than by each Resource.
+func withPermissionPreflight(resourceType string, actions []string, resource *schema.Resource) schema.CustomizeDiffFunc {
+	existing := resource.CustomizeDiff
+	_, hasResourceGroup := resource.Schema["resource_group_name"]
+
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*clients.Client)
+		if ok && client.PermissionPreflight && permissionPreflightRequired(d) {
+			if scope, ok := permissionPreflightScope(d, client.Account.SubscriptionId, resourceType, hasResourceGroup); ok {
+				checker, _ := permissionCheckers.LoadOrStore(client, preflight.NewChecker(client))
+				required := map[string][]string{
+					resourceType: actions,
+				}
+				missing, err := checker.(*preflight.Checker).Missing(permissionPreflightContext(client), scope, required)
+				if err != nil {
+					return err
+				}
+
+				report, _ := permissionReports.LoadOrStore(client, newPermissionReport(permissionReportQuietPeriod))
+				if err := report.(*permissionReport).add(scope, missing); err != nil {
+					return err
+				}
+			}
+		}
+
+		if existing != nil {
+			return existing(d, meta)
+		}
+
+		return nil
+	}
+}
+
+// permissionPreflightRequired returns whether the permissions need to be checked for this Resource - which is
+// only when it's being created or changed, so that a plan without any changes doesn't require any permissions
+func permissionPreflightRequired(d *schema.ResourceDiff) bool {
+	return d.Id() == "" || len(d.GetChangedKeysPrefix("")) > 0
+}
+
+// permissionPreflightScope returns the Scope at which the permissions for this Resource should be checked - which
+// is the Resource Group (when known) or the Subscription (for Resource Groups themselves)
+func permissionPreflightScope(d *schema.ResourceDiff, subscriptionId, resourceType string, hasResourceGroup bool) (string, bool) {
+	if resourceType == "azurerm_resource_group" {
+		return fmt.Sprintf("/subscriptions/%s", subscriptionId), true
+	}
+
+	if !hasResourceGroup || !d.NewValueKnown("resource_group_name") {
+		return "", false
+	}
+
+	resourceGroup, ok := d.Get("resource_group_name").(string)
+	if !ok || resourceGroup == "" {
+		return "", false
+	}
+
+	return resourceParse.NewResourceGroupID(subscriptionId, resourceGroup).ID(), true
+}
+
+func permissionPreflightContext(client *clients.Client) context.Context {
+	if client.StopContext != nil {
+		return client.StopContext
+	}
+	return context.Background()
+}
+
+// permissionReport collects the missing permissions for each of the Resources in a plan, so that these can be
+// reported in a single error - rather than the plan stopping at the first Resource with missing permissions
+type permissionReport struct {
+	// quietPeriod is how long to wait after the last Resource was checked before reporting the missing permissions
+	quietPeriod time.Duration
+
+	lock        sync.Mutex
+	lastChecked time.Time
+	reporting   bool
+
+	// missing is a map of Scope to the missing permissions at that Scope
+	missing map[string]map[preflight.MissingPermission]struct{}
+}
+
+func newPermissionReport(quietPeriod time.Duration) *permissionReport {
+	return &permissionReport{
+		quietPeriod: quietPeriod,
+		missing:     make(map[string]map[preflight.MissingPermission]struct{}),
+	}
+}
+
+// add records the permissions missing for a Resource at the specified Scope. The first Resource with missing
+// permissions waits until no other Resources have been checked for the quiet period and then returns a single error
+// listing all of the missing permissions found across the plan - other Resources don't return an error, since
+// their missing permissions are included in this error.
+func (r *permissionReport) add(scope string, missing []preflight.MissingPermission) error {
+	r.lock.Lock()
+	r.lastChecked = time.Now()
+	if len(missing) == 0 {
+		r.lock.Unlock()
+		return nil
+	}
+
+	key := strings.ToLower(scope)
+	if _, ok := r.missing[key]; !ok {
+		r.missing[key] = make(map[preflight.MissingPermission]struct{})
+	}
+	for _, v := range missing {
+		r.missing[key][v] = struct{}{}
+	}
+
+	if r.reporting {
+		r.lock.Unlock()
+		return nil
+	}
+	r.reporting = true
+	r.lock.Unlock()
+
+	for {
+		r.lock.Lock()
+		wait := r.quietPeriod - time.Since(r.lastChecked)
+		if wait <= 0 {
+			err := r.errorLocked()
+			r.missing = make(map[string]map[preflight.MissingPermission]struct{})
+			r.reporting = false
+			r.lock.Unlock()
+			return err
+		}
+		r.lock.Unlock()
+
+		time.Sleep(wait)
+	}
+}
+
+func (r *permissionReport) errorLocked() error {
+	scopes := make([]string, 0)
+	for scope := range r.missing {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+
+	count := 0
+	lines := make([]string, 0)
+	for _, scope := range scopes {
+		missing := make([]string, 0)
+		for v := range r.missing[scope] {
+			missing = append(missing, fmt.Sprintf("  * %s: %s", v.ResourceType, v.Action))
+		}
+		sort.Strings(missing)
+
+		count += len(missing)
+		lines = append(lines, fmt.Sprintf("* %s", scope))
+		lines = append(lines, missing...)
+	}
+
+	return fmt.Errorf(`the credentials being used are missing %d permission(s) which are required to provision the resources in this plan:
+
+%s`, count, strings.Join(lines, "\n"))
+}
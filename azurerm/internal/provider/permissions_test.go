@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/preflight"
+)
+
+func TestRequiredPermissionsReferenceExistingResources(t *testing.T) {
+	provider := AzureProvider().(*schema.Provider)
+
+	for resourceType, actions := range RequiredPermissions() {
+		if _, ok := provider.ResourcesMap[resourceType]; !ok {
+			t.Fatalf("the required permissions reference the Resource %q which doesn't exist", resourceType)
+		}
+		if len(actions) == 0 {
+			t.Fatalf("expected at least one required permission for %q", resourceType)
+		}
+		for _, action := range actions {
+			if strings.Count(action, "/") < 2 || strings.Contains(action, "*") {
+				t.Fatalf("expected %q for %q to be an RBAC Action (e.g. `Microsoft.Network/virtualNetworks/write`)", action, resourceType)
+			}
+		}
+	}
+}
+
+func TestPermissionReportCollectsAcrossResources(t *testing.T) {
+	report := newPermissionReport(50 * time.Millisecond)
+	scope := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+
+	resources := []struct {
+		resourceType string
+		missing      []preflight.MissingPermission
+	}{
+		{
+			resourceType: "azurerm_network_interface",
+			missing: []preflight.MissingPermission{
+				{ResourceType: "azurerm_network_interface", Action: "Microsoft.Network/virtualNetworks/subnets/join/action"},
+			},
+		},
+		{
+			resourceType: "azurerm_resource_group",
+		},
+		{
+			resourceType: "azurerm_virtual_network",
+			missing: []preflight.MissingPermission{
+				{ResourceType: "azurerm_virtual_network", Action: "Microsoft.Network/virtualNetworks/write"},
+			},
+		},
+		{
+			// a second instance of the same Resource shouldn't be reported twice
+			resourceType: "azurerm_virtual_network",
+			missing: []preflight.MissingPermission{
+				{ResourceType: "azurerm_virtual_network", Action: "Microsoft.Network/virtualNetworks/write"},
+			},
+		},
+	}
+
+	errors := make(chan error, len(resources))
+	var wg sync.WaitGroup
+	for _, v := range resources {
+		wg.Add(1)
+		go func(missing []preflight.MissingPermission) {
+			defer wg.Done()
+			if err := report.add(scope, missing); err != nil {
+				errors <- err
+			}
+		}(v.missing)
+	}
+	wg.Wait()
+	close(errors)
+
+	actual := make([]error, 0)
+	for err := range errors {
+		actual = append(actual, err)
+	}
+	if len(actual) != 1 {
+		t.Fatalf("expected a single error but got %d: %+v", len(actual), actual)
+	}
+
+	message := actual[0].Error()
+	if !strings.Contains(message, "missing 2 permission(s)") {
+		t.Fatalf("expected 2 missing permissions to be reported but got %q", message)
+	}
+	for _, action := range []string{"Microsoft.Network/virtualNetworks/subnets/join/action", "Microsoft.Network/virtualNetworks/write"} {
+		if !strings.Contains(message, action) {
+			t.Fatalf("expected %q to be reported but got %q", action, message)
+		}
+	}
+}
+
+func TestPermissionReportNoMissingPermissions(t *testing.T) {
+	report := newPermissionReport(50 * time.Millisecond)
+	if err := report.add("/subscriptions/00000000-0000-0000-0000-000000000000", []preflight.MissingPermission{}); err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+}
+
+func TestPolicyContentReferencesExistingResources(t *testing.T) {
+	provider := AzureProvider().(*schema.Provider)
+
+	for resourceType, content := range PolicyContent() {
+		if _, ok := provider.ResourcesMap[resourceType]; !ok {
+			t.Fatalf("the policy content references the Resource %q which doesn't exist", resourceType)
+		}
+		if strings.Count(content.ResourceType, "/") != 1 {
+			t.Fatalf("expected the Resource Type %q for %q to be a top-level Resource Type (e.g. `Microsoft.Storage/storageAccounts`)", content.ResourceType, resourceType)
+		}
+		if content.APIVersion == "" {
+			t.Fatalf("expected an API Version for %q", resourceType)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"log"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/preflight"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+)
+
+// policyCheckers contains a preflight.PolicyChecker for each Client, since multiple (aliased) providers
+// can use different credentials and Subscriptions
+var policyCheckers sync.Map
+
+// PolicyContent returns a map of Resource to the Azure Resource it provisions, which is evaluated
+// against Azure Policy during the plan, as declared by the Service Registrations
+func PolicyContent() map[string]sdk.PolicyContent {
+	registrations := make([]interface{}, 0)
+	for _, service := range SupportedTypedServices() {
+		registrations = append(registrations, service)
+	}
+	for _, service := range SupportedUntypedServices() {
+		registrations = append(registrations, service)
+	}
+
+	output := make(map[string]sdk.PolicyContent)
+	for _, registration := range registrations {
+		if v, ok := registration.(sdk.ServiceRegistrationWithPolicyContent); ok {
+			for resourceType, content := range v.PolicyContent() {
+				output[resourceType] = content
+			}
+		}
+	}
+
+	return output
+}
+
+// withPolicyPreflight returns a CustomizeDiffFunc which (when enabled in the `features` block) evaluates the would-be
+// content of this Resource against the Azure Policies assigned to its Resource Group (or Subscription) - surfacing
+// any which would deny it as either a Warning (which is only written to the Terraform Log, since the Plugin SDK doesn't
+// support surfacing Warnings from a CustomizeDiff) or an Error - before calling the existing CustomizeDiffFunc (if any)
+func withPolicyPreflight(resourceType string, content sdk.PolicyContent, resource *schema.Resource) schema.CustomizeDiffFunc {
+	existing := resource.CustomizeDiff
+	_, hasResourceGroup := resource.Schema["resource_group_name"]
+
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*clients.Client)
+		mode := features.PolicyDenyPreflightDisabled
+		if ok {
+			mode = client.Features.Policy.DenyPreflight
+		}
+
+		if mode == features.PolicyDenyPreflightWarning || mode == features.PolicyDenyPreflightError {
+			if resourceGroup, ok := policyPreflightResourceGroup(d, resourceType, hasResourceGroup); ok && policyPreflightRequired(d) {
+				checker, _ := policyCheckers.LoadOrStore(client, preflight.NewPolicyChecker(client))
+				body := policyPreflightContent(d, content)
+
+				denials, err := checker.(*preflight.PolicyChecker).Check(permissionPreflightContext(client), resourceGroup, body, content.APIVersion)
+				if err != nil {
+					// the Policy Preflight is best-effort, for example the credentials may not have access to this API
+					log.Printf("[WARN] Unable to evaluate Azure Policy for %s: %+v", resourceType, err)
+				} else if len(denials) > 0 {
+					err := preflight.PolicyDenialsError(resourceType, denials)
+					if mode == features.PolicyDenyPreflightError {
+						return err
+					}
+					log.Printf("[WARN] %+v", err)
+				}
+			}
+		}
+
+		if existing != nil {
+			return existing(d, meta)
+		}
+
+		return nil
+	}
+}
+
+// policyPreflightRequired returns whether the Policy Preflight needs to run for this Resource - which is the case when
+// it's being created, or when any field is changing - since the `properties` evaluated by Azure Policy can be derived
+// from any field (as defined in the PolicyContent for this Resource), rather than only the `location` and `tags`
+func policyPreflightRequired(d *schema.ResourceDiff) bool {
+	return d.Id() == "" || len(d.GetChangedKeysPrefix("")) > 0
+}
+
+// policyPreflightResourceGroup returns the Resource Group which this Resource will be provisioned within - which
+// is empty for Resource Groups themselves, which are evaluated against the Subscription
+func policyPreflightResourceGroup(d *schema.ResourceDiff, resourceType string, hasResourceGroup bool) (string, bool) {
+	if resourceType == "azurerm_resource_group" {
+		return "", true
+	}
+
+	if !hasResourceGroup || !d.NewValueKnown("resource_group_name") {
+		return "", false
+	}
+
+	resourceGroup, ok := d.Get("resource_group_name").(string)
+	return resourceGroup, ok && resourceGroup != ""
+}
+
+// policyPreflightContent returns the would-be content of the Azure Resource, containing the known values
+func policyPreflightContent(d *schema.ResourceDiff, content sdk.PolicyContent) map[string]interface{} {
+	output := map[string]interface{}{
+		"type": content.ResourceType,
+	}
+
+	if d.NewValueKnown("name") {
+		if v, ok := d.Get("name").(string); ok && v != "" {
+			output["name"] = v
+		}
+	}
+
+	if d.NewValueKnown("location") {
+		if v, ok := d.Get("location").(string); ok && v != "" {
+			output["location"] = azure.NormalizeLocation(v)
+		}
+	}
+
+	if d.NewValueKnown("tags") {
+		if v, ok := d.Get("tags").(map[string]interface{}); ok && len(v) > 0 {
+			tags := make(map[string]interface{})
+			for key, value := range v {
+				tags[key] = value
+			}
+			output["tags"] = tags
+		}
+	}
+
+	if content.Properties != nil {
+		if properties := content.Properties(d); len(properties) > 0 {
+			output["properties"] = properties
+		}
+	}
+
+	return output
+}
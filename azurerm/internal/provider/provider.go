@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceproviders"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
@@ -43,11 +44,26 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 	dataSources := make(map[string]*schema.Resource)
 	resources := make(map[string]*schema.Resource)
 
+	// when an API Profile is used, only the Data Sources and Resources supported by it are registered
+	apiProfile := features.APIProfile()
+	apiProfileDataSources, apiProfileResources := apiProfileSupport(apiProfile)
+	isSupported := func(supported map[string]struct{}, key string) bool {
+		if apiProfile == "" {
+			return true
+		}
+
+		_, ok := supported[key]
+		return ok
+	}
+
 	// first handle the typed services
 	for _, service := range SupportedTypedServices() {
 		debugLog("[DEBUG] Registering Data Sources for %q..", service.Name())
 		for _, ds := range service.DataSources() {
 			key := ds.ResourceType()
+			if !isSupported(apiProfileDataSources, key) {
+				continue
+			}
 			if existing := dataSources[key]; existing != nil {
 				panic(fmt.Sprintf("An existing Data Source exists for %q", key))
 			}
@@ -64,6 +80,9 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 		debugLog("[DEBUG] Registering Resources for %q..", service.Name())
 		for _, r := range service.Resources() {
 			key := r.ResourceType()
+			if !isSupported(apiProfileResources, key) {
+				continue
+			}
 			if existing := resources[key]; existing != nil {
 				panic(fmt.Sprintf("An existing Resource exists for %q", key))
 			}
@@ -81,23 +100,54 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 	for _, service := range SupportedUntypedServices() {
 		debugLog("[DEBUG] Registering Data Sources for %q..", service.Name())
 		for k, v := range service.SupportedDataSources() {
+			if !isSupported(apiProfileDataSources, k) {
+				continue
+			}
 			if existing := dataSources[k]; existing != nil {
 				panic(fmt.Sprintf("An existing Data Source exists for %q", k))
 			}
 
+			tf.WithAzureAPIErrors(v)
 			dataSources[k] = v
 		}
 
 		debugLog("[DEBUG] Registering Resources for %q..", service.Name())
 		for k, v := range service.SupportedResources() {
+			if !isSupported(apiProfileResources, k) {
+				continue
+			}
 			if existing := resources[k]; existing != nil {
 				panic(fmt.Sprintf("An existing Resource exists for %q", k))
 			}
 
+			// errors returned from the Azure API are returned in a concise format (which Typed Resources do automatically)
+			tf.WithAzureAPIErrors(v)
 			resources[k] = v
 		}
 	}
 
+	// when enabled, the permissions required to provision each Resource are checked during the plan
+	for resourceType, actions := range RequiredPermissions() {
+		if resource, ok := resources[resourceType]; ok {
+			resource.CustomizeDiff = withPermissionPreflight(resourceType, actions, resource)
+		}
+	}
+
+	// when enabled, Resources are evaluated against Azure Policy during the plan
+	for resourceType, content := range PolicyContent() {
+		if resource, ok := resources[resourceType]; ok {
+			resource.CustomizeDiff = withPolicyPreflight(resourceType, content, resource)
+		}
+	}
+
+	// when enabled, each operation uses a separate Correlation Request ID
+	for resourceType, resource := range resources {
+		withOperationCorrelationRequestIDs(resourceType, resource)
+	}
+	for dataSourceType, dataSource := range dataSources {
+		withOperationCorrelationRequestIDs(dataSourceType, dataSource)
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -124,7 +174,6 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 			"auxiliary_tenant_ids": {
 				Type:     schema.TypeList,
 				Optional: true,
-				MaxItems: 3,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -189,6 +238,40 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 				Description: "The path to a custom endpoint for Managed Service Identity - in most circumstances this should be detected automatically. ",
 			},
 
+			// OIDC specific fields
+			"use_oidc": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_OIDC", false),
+				Description: "Allow OpenID Connect (Workload Identity Federation) to be used for Authentication.",
+			},
+			"oidc_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_OIDC_TOKEN", ""),
+				Description: "The OIDC ID token for use when authenticating as a Service Principal using OpenID Connect.",
+			},
+			"oidc_token_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_OIDC_TOKEN_FILE_PATH", ""),
+				Description: "The path to a file containing an OIDC ID token for use when authenticating as a Service Principal using OpenID Connect.",
+			},
+			"oidc_request_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"ARM_OIDC_REQUEST_URL", "ACTIONS_ID_TOKEN_REQUEST_URL"}, ""),
+				Description: "The URL for the OIDC provider from which to request an ID token. For use when authenticating as a Service Principal using OpenID Connect.",
+			},
+			"oidc_request_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"ARM_OIDC_REQUEST_TOKEN", "ACTIONS_ID_TOKEN_REQUEST_TOKEN"}, ""),
+				Description: "The bearer token for the request to the OIDC provider. For use when authenticating as a Service Principal using OpenID Connect.",
+			},
+
 			// Managed Tracking GUID for User-agent
 			"partner_id": {
 				Type:         schema.TypeString,
@@ -205,6 +288,13 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 				Description: "This will disable the x-ms-correlation-request-id header.",
 			},
 
+			"correlation_request_id_per_operation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_CORRELATION_REQUEST_ID_PER_OPERATION", false),
+				Description: "Should a separate x-ms-correlation-request-id be sent for each operation on a Resource or Data Source, rather than one for all requests?",
+			},
+
 			"disable_terraform_partner_id": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -228,6 +318,20 @@ func azureProvider(supportLegacyTestSuite bool) terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("ARM_STORAGE_USE_AZUREAD", false),
 				Description: "Should the AzureRM Provider use AzureAD to access the Storage Data Plane API's?",
 			},
+
+			"permission_preflight": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_PERMISSION_PREFLIGHT", false),
+				Description: "Should the AzureRM Provider check that the credentials being used have the permissions required to provision each Resource during the plan?",
+			},
+
+			"audit_log_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_AUDIT_LOG_PATH", ""),
+				Description: "The path to a file which each request made to (and response from) the Azure API's should be recorded in, with any sensitive values redacted.",
+			},
 		},
 
 		DataSourcesMap: dataSources,
@@ -257,8 +361,14 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			auxTenants = strings.Split(v, ";")
 		}
 
-		if len(auxTenants) > 3 {
-			return nil, fmt.Errorf("The provider only supports 3 auxiliary tenant IDs")
+		// Azure Resource Manager only supports 3 Auxiliary Tenants per request - when more are specified (or when
+		// using OIDC, which go-azure-helpers doesn't support) the Auxiliary Tenants required for each request are
+		// instead determined from the Resource IDs it references, with tokens obtained when they're first required
+		useOIDC := d.Get("use_oidc").(bool)
+		var onDemandAuxTenants []string
+		if len(auxTenants) > 3 || useOIDC {
+			onDemandAuxTenants = auxTenants
+			auxTenants = nil
 		}
 
 		metadataHost := d.Get("metadata_host").(string)
@@ -293,9 +403,34 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			ClientSecretDocsLink: "https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/guides/service_principal_client_secret",
 		}
 
-		config, err := builder.Build()
+		var config *authentication.Config
+		var oidcConfig *clients.OIDCConfig
+		if useOIDC {
+			// OIDC isn't supported by go-azure-helpers, so the Authorizers are built in `clients.Build`
+			config = &authentication.Config{
+				ClientID:       builder.ClientID,
+				SubscriptionID: builder.SubscriptionID,
+				TenantID:       builder.TenantID,
+				Environment:    builder.Environment,
+				MetadataHost:   builder.MetadataHost,
+			}
+			oidcConfig = &clients.OIDCConfig{
+				Token:         d.Get("oidc_token").(string),
+				TokenFilePath: d.Get("oidc_token_file_path").(string),
+				RequestURL:    d.Get("oidc_request_url").(string),
+				RequestToken:  d.Get("oidc_request_token").(string),
+			}
+		} else {
+			var err error
+			config, err = builder.Build()
+			if err != nil {
+				return nil, fmt.Errorf("Error building AzureRM Client: %s", err)
+			}
+		}
+
+		auditLog, err := buildAuditLog(p, d.Get("audit_log_path").(string))
 		if err != nil {
-			return nil, fmt.Errorf("Error building AzureRM Client: %s", err)
+			return nil, err
 		}
 
 		terraformVersion := p.TerraformVersion
@@ -315,6 +450,13 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
 			Features:                    expandFeatures(d.Get("features").([]interface{})),
 			StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
+			PermissionPreflight:         d.Get("permission_preflight").(bool),
+			OIDCConfig:                  oidcConfig,
+			AuxiliaryTenantIDs:          onDemandAuxTenants,
+			APIProfile:                  features.APIProfile(),
+
+			CorrelationRequestIDPerOperation: d.Get("correlation_request_id_per_operation").(bool),
+			AuditLog:                         auditLog,
 
 			// this field is intentionally not exposed in the provider block, since it's only used for
 			// platform level tracing
@@ -322,8 +464,14 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 		}
 		client, err := clients.Build(p.StopContext(), clientBuilder)
 		if err != nil {
+			if auditLog != nil {
+				if closeErr := auditLog.Close(); closeErr != nil {
+					log.Printf("[WARN] %+v", closeErr)
+				}
+			}
 			return nil, err
 		}
+		closeAuditLogOnStop(p.StopContext(), auditLog)
 
 		client.StopContext = p.StopContext()
 
@@ -0,0 +1,19 @@
+package sdk
+
+// ServiceRegistrationWithAPIProfiles is an optional interface which can be implemented by a
+// Service Registration (either Typed or Untyped) to declare which of its Data Sources and
+// Resources are supported when using an API Profile (for example against Azure Stack Hub)
+type ServiceRegistrationWithAPIProfiles interface {
+	// APIProfiles returns a map of API Profile (e.g. `2019-03-01-hybrid`) to the Data Sources
+	// and Resources within this Service which are supported when using it
+	APIProfiles() map[string]APIProfileSupport
+}
+
+// APIProfileSupport defines the Data Sources and Resources supported on an API Profile
+type APIProfileSupport struct {
+	// DataSources is a list of the names of the supported Data Sources, e.g. `azurerm_resource_group`
+	DataSources []string
+
+	// Resources is a list of the names of the supported Resources, e.g. `azurerm_resource_group`
+	Resources []string
+}
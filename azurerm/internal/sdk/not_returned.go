@@ -0,0 +1,12 @@
+package sdk
+
+// ServiceRegistrationWithFieldsNotReturnedByAPI is an optional interface which can be implemented by a
+// Service Registration (either Typed or Untyped) to declare the fields of its Resources which aren't
+// returned from the API (for example, a local file path used to upload content) - meaning that the value
+// in the State can't be determined during an Import
+type ServiceRegistrationWithFieldsNotReturnedByAPI interface {
+	// FieldsNotReturnedByAPI returns a map of Resource (e.g. `azurerm_storage_blob`) to the fields which
+	// aren't returned from the API, where fields within a nested block are separated by a `.`
+	// (e.g. `site_config.password`)
+	FieldsNotReturnedByAPI() map[string][]string
+}
@@ -0,0 +1,10 @@
+package sdk
+
+// ServiceRegistrationWithPermissions is an optional interface which can be implemented by a
+// Service Registration (either Typed or Untyped) to declare the RBAC Actions required to
+// provision its Resources, which are checked by the Permission Preflight
+type ServiceRegistrationWithPermissions interface {
+	// RequiredPermissions returns a map of Resource (e.g. `azurerm_subnet`) to the RBAC Actions
+	// (e.g. `Microsoft.Network/virtualNetworks/subnets/write`) required to provision it
+	RequiredPermissions() map[string][]string
+}
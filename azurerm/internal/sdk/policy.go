@@ -0,0 +1,24 @@
+package sdk
+
+import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+// ServiceRegistrationWithPolicyContent is an optional interface which can be implemented by a
+// Service Registration (either Typed or Untyped) to describe the Azure Resource provisioned by
+// each of its Resources, which is evaluated against Azure Policy by the Policy Deny Preflight
+type ServiceRegistrationWithPolicyContent interface {
+	// PolicyContent returns a map of Resource (e.g. `azurerm_storage_account`) to the Azure Resource
+	// which it provisions
+	PolicyContent() map[string]PolicyContent
+}
+
+type PolicyContent struct {
+	// ResourceType is the type of the Azure Resource, e.g. `Microsoft.Storage/storageAccounts`
+	ResourceType string
+
+	// APIVersion is the API Version of the Azure Resource which the Properties are defined in
+	APIVersion string
+
+	// Properties optionally returns the known `properties` of the Azure Resource from the planned
+	// values, which are evaluated in addition to the `name`, `location` and `tags`
+	Properties func(d *schema.ResourceDiff) map[string]interface{}
+}
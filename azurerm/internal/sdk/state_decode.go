@@ -0,0 +1,44 @@
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// DecodeInstanceState decodes the Terraform State for an instance of a Typed Resource/Data Source
+// into a new instance of the Model Object (as defined by `ModelObject()`) - returning a pointer
+// to the populated Model Object.
+//
+// This is intended for use in the Acceptance Tests, where only the State is available.
+func DecodeInstanceState(state *terraform.InstanceState, arguments, attributes map[string]*schema.Schema, modelObject interface{}) (interface{}, error) {
+	if state == nil {
+		return nil, fmt.Errorf("`state` was nil")
+	}
+	if modelObject == nil {
+		return nil, fmt.Errorf("`modelObject` was nil")
+	}
+
+	resourceSchema, err := combineSchema(arguments, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("building Schema: %+v", err)
+	}
+
+	resource := schema.Resource{
+		Schema: *resourceSchema,
+	}
+	resourceData := resource.Data(state)
+
+	modelType := reflect.TypeOf(modelObject)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	model := reflect.New(modelType).Interface()
+	if err := decodeReflectedType(model, resourceData, NullLogger{}); err != nil {
+		return nil, fmt.Errorf("decoding State into %s: %+v", modelType.Name(), err)
+	}
+
+	return model, nil
+}
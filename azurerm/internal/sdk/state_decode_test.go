@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestDecodeInstanceState(t *testing.T) {
+	type NestedType struct {
+		Key   string `tfschema:"key"`
+		Value int    `tfschema:"value"`
+	}
+	type SimpleType struct {
+		Name    string            `tfschema:"name"`
+		Enabled bool              `tfschema:"enabled"`
+		Tags    map[string]string `tfschema:"tags"`
+		Nested  []NestedType      `tfschema:"nested"`
+		Id      string            `tfschema:"computed_id"`
+	}
+
+	arguments := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"nested": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"value": {
+						Type:     schema.TypeInt,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+	attributes := map[string]*schema.Schema{
+		"computed_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	state := &terraform.InstanceState{
+		ID: "some-id",
+		Attributes: map[string]string{
+			"id":             "some-id",
+			"name":           "example",
+			"enabled":        "true",
+			"tags.%":         "1",
+			"tags.hello":     "world",
+			"nested.#":       "2",
+			"nested.0.key":   "first",
+			"nested.0.value": "1",
+			"nested.1.key":   "second",
+			"nested.1.value": "2",
+			"computed_id":    "/some/id",
+		},
+	}
+
+	actual, err := DecodeInstanceState(state, arguments, attributes, SimpleType{})
+	if err != nil {
+		t.Fatalf("decoding: %+v", err)
+	}
+
+	expected := &SimpleType{
+		Name:    "example",
+		Enabled: true,
+		Tags: map[string]string{
+			"hello": "world",
+		},
+		Nested: []NestedType{
+			{
+				Key:   "first",
+				Value: 1,
+			},
+			{
+				Key:   "second",
+				Value: 2,
+			},
+		},
+		Id: "/some/id",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+)
+
+// ServiceRegistrationWithSweepers is an optional interface which can be implemented by
+// a Service Registration (either Typed or Untyped) to declare Sweepers, which are used
+// to clean up resources leaked by failed Acceptance Test runs
+type ServiceRegistrationWithSweepers interface {
+	// Sweepers returns a list of Sweepers supported by this Service
+	Sweepers() []Sweeper
+}
+
+// Sweeper defines how to find and delete resources of a given type which have been
+// leaked by the Acceptance Tests
+type Sweeper struct {
+	// Name is a human-friendly name for this Sweeper, e.g. `Soft-Deleted Key Vaults`
+	Name string
+
+	// Prefixes is a list of prefixes which the Name of a resource must begin with
+	// to be swept, e.g. `acctestRG-` - which is compared case-insensitively
+	Prefixes []string
+
+	// List returns all of the resources of this type (which are filtered by Prefix
+	// and Age by the caller)
+	List func(ctx context.Context, client *clients.Client) (*[]SweepableResource, error)
+
+	// Delete deletes (or purges) the specified resource
+	Delete func(ctx context.Context, client *clients.Client, resource SweepableResource) error
+}
+
+// SweepableResource is a resource which could be swept
+type SweepableResource struct {
+	// ID is the Resource ID of this resource
+	ID string
+
+	// Name is the Name of this resource, which is compared against the Prefixes
+	Name string
+
+	// Location is the Azure Region this resource exists in, which is required
+	// to purge some soft-deleted resources
+	Location string
+
+	// Timestamp is when this resource was created (or, for soft-deleted resources,
+	// deleted) - when omitted the age is determined from the timestamp embedded
+	// in the Name by `acceptance.RandTimeInt`
+	Timestamp *time.Time
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/apierrors"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 )
 
@@ -45,7 +46,7 @@ func (rw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 			ctx, metaData := runArgs(d, meta, rw.logger)
 			wrappedCtx, cancel := timeouts.ForRead(ctx, d)
 			defer cancel()
-			return rw.dataSource.Read().Func(wrappedCtx, metaData)
+			return apierrors.Format(rw.dataSource.Read().Func(wrappedCtx, metaData))
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Read: d(rw.dataSource.Read().Timeout),
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/apierrors"
 	azSchema "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 )
@@ -49,12 +50,12 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			defer cancel()
 			err := rw.resource.Create().Func(wrappedCtx, metaData)
 			if err != nil {
-				return err
+				return apierrors.Format(err)
 			}
 			// NOTE: whilst this may look like we should use the Read
 			// functions timeout here, we're still /technically/ in the
 			// Create function so reusing that timeout should be sufficient
-			return rw.resource.Read().Func(wrappedCtx, metaData)
+			return apierrors.Format(rw.resource.Read().Func(wrappedCtx, metaData))
 		},
 
 		// looks like these could be reused, easiest if they're not
@@ -62,13 +63,13 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			ctx, metaData := runArgs(d, meta, rw.logger)
 			wrappedCtx, cancel := timeouts.ForRead(ctx, d)
 			defer cancel()
-			return rw.resource.Read().Func(wrappedCtx, metaData)
+			return apierrors.Format(rw.resource.Read().Func(wrappedCtx, metaData))
 		},
 		Delete: func(d *schema.ResourceData, meta interface{}) error {
 			ctx, metaData := runArgs(d, meta, rw.logger)
 			wrappedCtx, cancel := timeouts.ForDelete(ctx, d)
 			defer cancel()
-			return rw.resource.Delete().Func(wrappedCtx, metaData)
+			return apierrors.Format(rw.resource.Delete().Func(wrappedCtx, metaData))
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -101,7 +102,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 
 				err := v.CustomImporter()(wrappedCtx, metaData)
 				if err != nil {
-					return nil, err
+					return nil, apierrors.Format(err)
 				}
 
 				return []*schema.ResourceData{metaData.ResourceData}, nil
@@ -121,12 +122,12 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 
 			err := v.Update().Func(wrappedCtx, metaData)
 			if err != nil {
-				return err
+				return apierrors.Format(err)
 			}
 			// whilst this may look like we should use the Update timeout here
 			// we're still "technically" in the update method, so reusing the
 			// Update's timeout should be fine
-			return rw.resource.Read().Func(wrappedCtx, metaData)
+			return apierrors.Format(rw.resource.Read().Func(wrappedCtx, metaData))
 		}
 		resource.Timeouts.Update = d(v.Update().Timeout)
 	}
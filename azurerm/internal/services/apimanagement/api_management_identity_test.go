@@ -0,0 +1,122 @@
+package apimanagement
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/apimanagement/mgmt/2019-12-01/apimanagement"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const testUserAssignedIdentityId = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"
+
+func TestExpandApiManagementIdentity(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    []interface{}
+		expected *apimanagement.ServiceIdentity
+	}{
+		{
+			name:  "omitted",
+			input: []interface{}{},
+			expected: &apimanagement.ServiceIdentity{
+				Type: apimanagement.None,
+			},
+		},
+		{
+			name: "none",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "None",
+					"identity_ids": schema.NewSet(schema.HashString, []interface{}{}),
+				},
+			},
+			expected: &apimanagement.ServiceIdentity{
+				Type: apimanagement.None,
+			},
+		},
+		{
+			name: "user assigned",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "UserAssigned",
+					"identity_ids": schema.NewSet(schema.HashString, []interface{}{testUserAssignedIdentityId}),
+				},
+			},
+			expected: &apimanagement.ServiceIdentity{
+				Type: apimanagement.UserAssigned,
+				UserAssignedIdentities: map[string]*apimanagement.UserIdentityProperties{
+					testUserAssignedIdentityId: {},
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := expandAzureRmApiManagementIdentity(v.input)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestFlattenApiManagementIdentity(t *testing.T) {
+	principalId := uuid.FromStringOrNil("11111111-1111-1111-1111-111111111111")
+	tenantId := uuid.FromStringOrNil("22222222-2222-2222-2222-222222222222")
+
+	testData := []struct {
+		name     string
+		input    *apimanagement.ServiceIdentity
+		expected []interface{}
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: []interface{}{},
+		},
+		{
+			name: "none",
+			input: &apimanagement.ServiceIdentity{
+				Type: apimanagement.None,
+			},
+			expected: []interface{}{},
+		},
+		{
+			name: "system assigned, user assigned",
+			input: &apimanagement.ServiceIdentity{
+				Type:        apimanagement.SystemAssignedUserAssigned,
+				PrincipalID: &principalId,
+				TenantID:    &tenantId,
+				UserAssignedIdentities: map[string]*apimanagement.UserIdentityProperties{
+					testUserAssignedIdentityId: {},
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned, UserAssigned",
+					"identity_ids": []interface{}{testUserAssignedIdentityId},
+					"principal_id": principalId.String(),
+					"tenant_id":    tenantId.String(),
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := flattenAzureRmApiManagementMachineIdentity(v.input)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
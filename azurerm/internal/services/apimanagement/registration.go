@@ -2,6 +2,7 @@ package apimanagement
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 )
 
 type Registration struct{}
@@ -68,3 +69,10 @@ func (r Registration) SupportedResources() map[string]*schema.Resource {
 		"azurerm_api_management_user":                        resourceApiManagementUser(),
 	}
 }
+
+// Sweepers returns a list of Sweepers which clean up resources leaked by the Acceptance Tests
+func (r Registration) Sweepers() []sdk.Sweeper {
+	return []sdk.Sweeper{
+		softDeletedServiceSweeper(),
+	}
+}
@@ -0,0 +1,144 @@
+package apimanagement
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+)
+
+// the version of the SDK in use doesn't support Soft-Deleted API Management Services
+// so these requests are made directly using the newer API Version which does
+const deletedServicesAPIVersion = "2020-06-01-preview"
+
+type deletedServiceList struct {
+	Value    *[]deletedService `json:"value,omitempty"`
+	NextLink *string           `json:"nextLink,omitempty"`
+}
+
+type deletedService struct {
+	ID         *string                   `json:"id,omitempty"`
+	Name       *string                   `json:"name,omitempty"`
+	Location   *string                   `json:"location,omitempty"`
+	Properties *deletedServiceProperties `json:"properties,omitempty"`
+}
+
+type deletedServiceProperties struct {
+	DeletionDate *date.Time `json:"deletionDate,omitempty"`
+}
+
+func softDeletedServiceSweeper() sdk.Sweeper {
+	return sdk.Sweeper{
+		Name: "Soft-Deleted API Management Services",
+		Prefixes: []string{
+			"acctestAM-",
+			"amtestAM-",
+		},
+		List: func(ctx context.Context, client *clients.Client) (*[]sdk.SweepableResource, error) {
+			serviceClient := client.ApiManagement.ServiceClient
+			pathParameters := map[string]interface{}{
+				"subscriptionId": autorest.Encode("path", serviceClient.SubscriptionID),
+			}
+			queryParameters := map[string]interface{}{
+				"api-version": deletedServicesAPIVersion,
+			}
+			preparer := autorest.CreatePreparer(
+				autorest.AsGet(),
+				autorest.WithBaseURL(serviceClient.BaseURI),
+				autorest.WithPathParameters("/subscriptions/{subscriptionId}/providers/Microsoft.ApiManagement/deletedservices", pathParameters),
+				autorest.WithQueryParameters(queryParameters))
+
+			output := make([]sdk.SweepableResource, 0)
+			for preparer != nil {
+				req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+				if err != nil {
+					return nil, fmt.Errorf("preparing request to list Soft-Deleted API Management Services: %+v", err)
+				}
+
+				resp, err := serviceClient.Send(req, azure.DoRetryWithRegistration(serviceClient.Client))
+				if err != nil {
+					return nil, fmt.Errorf("listing Soft-Deleted API Management Services: %+v", err)
+				}
+
+				var result deletedServiceList
+				err = autorest.Respond(
+					resp,
+					azure.WithErrorUnlessStatusCode(http.StatusOK),
+					autorest.ByUnmarshallingJSON(&result),
+					autorest.ByClosing())
+				if err != nil {
+					return nil, fmt.Errorf("listing Soft-Deleted API Management Services: %+v", err)
+				}
+
+				if result.Value != nil {
+					for _, service := range *result.Value {
+						if service.ID == nil || service.Name == nil || service.Location == nil {
+							continue
+						}
+
+						var timestamp *time.Time
+						if props := service.Properties; props != nil && props.DeletionDate != nil {
+							timestamp = &props.DeletionDate.Time
+						}
+
+						output = append(output, sdk.SweepableResource{
+							ID:        *service.ID,
+							Name:      *service.Name,
+							Location:  *service.Location,
+							Timestamp: timestamp,
+						})
+					}
+				}
+
+				preparer = nil
+				if result.NextLink != nil && *result.NextLink != "" {
+					preparer = autorest.CreatePreparer(
+						autorest.AsGet(),
+						autorest.WithBaseURL(*result.NextLink))
+				}
+			}
+
+			return &output, nil
+		},
+		Delete: func(ctx context.Context, client *clients.Client, resource sdk.SweepableResource) error {
+			serviceClient := client.ApiManagement.ServiceClient
+			pathParameters := map[string]interface{}{
+				"location":       autorest.Encode("path", resource.Location),
+				"serviceName":    autorest.Encode("path", resource.Name),
+				"subscriptionId": autorest.Encode("path", serviceClient.SubscriptionID),
+			}
+			queryParameters := map[string]interface{}{
+				"api-version": deletedServicesAPIVersion,
+			}
+			req, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+				autorest.AsDelete(),
+				autorest.WithBaseURL(serviceClient.BaseURI),
+				autorest.WithPathParameters("/subscriptions/{subscriptionId}/providers/Microsoft.ApiManagement/locations/{location}/deletedservices/{serviceName}", pathParameters),
+				autorest.WithQueryParameters(queryParameters))
+			if err != nil {
+				return fmt.Errorf("preparing request to purge Soft-Deleted API Management Service %q (Location %q): %+v", resource.Name, resource.Location, err)
+			}
+
+			resp, err := serviceClient.Send(req, azure.DoRetryWithRegistration(serviceClient.Client))
+			if err != nil {
+				return fmt.Errorf("purging Soft-Deleted API Management Service %q (Location %q): %+v", resource.Name, resource.Location, err)
+			}
+
+			future, err := azure.NewFutureFromResponse(resp)
+			if err != nil {
+				return fmt.Errorf("purging Soft-Deleted API Management Service %q (Location %q): %+v", resource.Name, resource.Location, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, serviceClient.Client); err != nil {
+				return fmt.Errorf("waiting for purge of Soft-Deleted API Management Service %q (Location %q): %+v", resource.Name, resource.Location, err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 type Client struct {
 	GroupsClient            *graphrbac.GroupsClient
+	PermissionsClient       *authorization.PermissionsClient
 	RoleAssignmentsClient   *authorization.RoleAssignmentsClient
 	RoleDefinitionsClient   *authorization.RoleDefinitionsClient
 	ServicePrincipalsClient *graphrbac.ServicePrincipalsClient
@@ -17,6 +18,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	groupsClient := graphrbac.NewGroupsClientWithBaseURI(o.GraphEndpoint, o.TenantID)
 	o.ConfigureClient(&groupsClient.Client, o.GraphAuthorizer)
 
+	permissionsClient := authorization.NewPermissionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&permissionsClient.Client, o.ResourceManagerAuthorizer)
+
 	roleAssignmentsClient := authorization.NewRoleAssignmentsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&roleAssignmentsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -28,6 +32,7 @@ func NewClient(o *common.ClientOptions) *Client {
 
 	return &Client{
 		GroupsClient:            &groupsClient,
+		PermissionsClient:       &permissionsClient,
 		RoleAssignmentsClient:   &roleAssignmentsClient,
 		RoleDefinitionsClient:   &roleDefinitionsClient,
 		ServicePrincipalsClient: &servicePrincipalsClient,
@@ -33,3 +33,19 @@ func (r Registration) SupportedResources() map[string]*schema.Resource {
 		"azurerm_role_definition": resourceArmRoleDefinition(),
 	}
 }
+
+// RequiredPermissions returns the RBAC Actions required to provision each Resource within this Service
+func (r Registration) RequiredPermissions() map[string][]string {
+	return map[string][]string{
+		"azurerm_role_assignment": {
+			"Microsoft.Authorization/roleAssignments/read",
+			"Microsoft.Authorization/roleAssignments/write",
+			"Microsoft.Authorization/roleAssignments/delete",
+		},
+		"azurerm_role_definition": {
+			"Microsoft.Authorization/roleDefinitions/read",
+			"Microsoft.Authorization/roleDefinitions/write",
+			"Microsoft.Authorization/roleDefinitions/delete",
+		},
+	}
+}
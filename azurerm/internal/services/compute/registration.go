@@ -2,6 +2,8 @@ package compute
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 )
 
 type Registration struct{}
@@ -73,3 +75,74 @@ func (r Registration) SupportedResources() map[string]*schema.Resource {
 
 	return resources
 }
+
+// APIProfiles returns the Data Sources and Resources supported by this Service on each API Profile
+func (r Registration) APIProfiles() map[string]sdk.APIProfileSupport {
+	return map[string]sdk.APIProfileSupport{
+		common.APIProfile20190301Hybrid: {
+			DataSources: []string{
+				"azurerm_availability_set",
+				"azurerm_managed_disk",
+			},
+			Resources: []string{
+				"azurerm_availability_set",
+				"azurerm_managed_disk",
+			},
+		},
+	}
+}
+
+// RequiredPermissions returns the RBAC Actions required to provision each Resource within this Service
+func (r Registration) RequiredPermissions() map[string][]string {
+	return map[string][]string{
+		"azurerm_availability_set": {
+			"Microsoft.Compute/availabilitySets/read",
+			"Microsoft.Compute/availabilitySets/write",
+			"Microsoft.Compute/availabilitySets/delete",
+		},
+		"azurerm_linux_virtual_machine": {
+			"Microsoft.Compute/virtualMachines/read",
+			"Microsoft.Compute/virtualMachines/write",
+			"Microsoft.Compute/virtualMachines/delete",
+			"Microsoft.Network/networkInterfaces/join/action",
+		},
+		"azurerm_managed_disk": {
+			"Microsoft.Compute/disks/read",
+			"Microsoft.Compute/disks/write",
+			"Microsoft.Compute/disks/delete",
+		},
+		"azurerm_virtual_machine_data_disk_attachment": {
+			"Microsoft.Compute/virtualMachines/read",
+			"Microsoft.Compute/virtualMachines/write",
+			"Microsoft.Compute/disks/read",
+		},
+		"azurerm_windows_virtual_machine": {
+			"Microsoft.Compute/virtualMachines/read",
+			"Microsoft.Compute/virtualMachines/write",
+			"Microsoft.Compute/virtualMachines/delete",
+			"Microsoft.Network/networkInterfaces/join/action",
+		},
+	}
+}
+
+// PolicyContent returns the Azure Resource provisioned by each Resource within this Service
+func (r Registration) PolicyContent() map[string]sdk.PolicyContent {
+	virtualMachine := sdk.PolicyContent{
+		ResourceType: "Microsoft.Compute/virtualMachines",
+		APIVersion:   "2020-12-01",
+		Properties: func(d *schema.ResourceDiff) map[string]interface{} {
+			properties := make(map[string]interface{})
+			if d.NewValueKnown("size") {
+				properties["hardwareProfile"] = map[string]interface{}{
+					"vmSize": d.Get("size").(string),
+				}
+			}
+			return properties
+		},
+	}
+
+	return map[string]sdk.PolicyContent{
+		"azurerm_linux_virtual_machine":   virtualMachine,
+		"azurerm_windows_virtual_machine": virtualMachine,
+	}
+}
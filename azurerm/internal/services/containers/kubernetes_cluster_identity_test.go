@@ -0,0 +1,188 @@
+package containers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-02-01/containerservice"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const testUserAssignedIdentityId = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"
+
+func TestExpandKubernetesClusterManagedClusterIdentity(t *testing.T) {
+	testData := []struct {
+		name        string
+		input       []interface{}
+		expected    *containerservice.ManagedClusterIdentity
+		expectError bool
+	}{
+		{
+			name:  "omitted",
+			input: []interface{}{},
+			expected: &containerservice.ManagedClusterIdentity{
+				Type: containerservice.ResourceIdentityTypeNone,
+			},
+		},
+		{
+			name: "system assigned",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned",
+					"identity_ids": schema.NewSet(schema.HashString, []interface{}{}),
+				},
+			},
+			expected: &containerservice.ManagedClusterIdentity{
+				Type: containerservice.ResourceIdentityTypeSystemAssigned,
+			},
+		},
+		{
+			name: "user assigned",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "UserAssigned",
+					"identity_ids": schema.NewSet(schema.HashString, []interface{}{testUserAssignedIdentityId}),
+				},
+			},
+			expected: &containerservice.ManagedClusterIdentity{
+				Type: containerservice.ResourceIdentityTypeUserAssigned,
+				UserAssignedIdentities: map[string]*containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{
+					testUserAssignedIdentityId: {},
+				},
+			},
+		},
+		{
+			name: "user assigned without an identity",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "UserAssigned",
+					"identity_ids": schema.NewSet(schema.HashString, []interface{}{}),
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := expandKubernetesClusterManagedClusterIdentity(v.input)
+		if v.expectError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestExpandKubernetesClusterManagedClusterIdentityDeprecatedIdentityId(t *testing.T) {
+	if features.ThreePointOh() {
+		t.Skip("`user_assigned_identity_id` is removed in 3.0")
+	}
+
+	input := []interface{}{
+		map[string]interface{}{
+			"type":                      "UserAssigned",
+			"identity_ids":              schema.NewSet(schema.HashString, []interface{}{}),
+			"user_assigned_identity_id": testUserAssignedIdentityId,
+		},
+	}
+	expected := &containerservice.ManagedClusterIdentity{
+		Type: containerservice.ResourceIdentityTypeUserAssigned,
+		UserAssignedIdentities: map[string]*containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{
+			testUserAssignedIdentityId: {},
+		},
+	}
+
+	actual, err := expandKubernetesClusterManagedClusterIdentity(input)
+	if err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestFlattenKubernetesClusterManagedClusterIdentity(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    *containerservice.ManagedClusterIdentity
+		expected []interface{}
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: []interface{}{},
+		},
+		{
+			name: "none",
+			input: &containerservice.ManagedClusterIdentity{
+				Type: containerservice.ResourceIdentityTypeNone,
+			},
+			expected: []interface{}{},
+		},
+		{
+			name: "system assigned",
+			input: &containerservice.ManagedClusterIdentity{
+				Type:        containerservice.ResourceIdentityTypeSystemAssigned,
+				PrincipalID: utils.String("11111111-1111-1111-1111-111111111111"),
+				TenantID:    utils.String("22222222-2222-2222-2222-222222222222"),
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned",
+					"identity_ids": []interface{}{},
+					"principal_id": "11111111-1111-1111-1111-111111111111",
+					"tenant_id":    "22222222-2222-2222-2222-222222222222",
+				},
+			},
+		},
+		{
+			name: "user assigned",
+			input: &containerservice.ManagedClusterIdentity{
+				Type: containerservice.ResourceIdentityTypeUserAssigned,
+				UserAssignedIdentities: map[string]*containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{
+					testUserAssignedIdentityId: {},
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"type":         "UserAssigned",
+					"identity_ids": []interface{}{testUserAssignedIdentityId},
+					"principal_id": "",
+					"tenant_id":    "",
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if !features.ThreePointOh() && len(v.expected) > 0 {
+			// prior to 3.0 the first User Assigned Identity is also exposed as `user_assigned_identity_id`
+			values := v.expected[0].(map[string]interface{})
+			values["user_assigned_identity_id"] = ""
+			if ids := values["identity_ids"].([]interface{}); len(ids) > 0 {
+				values["user_assigned_identity_id"] = ids[0]
+			}
+		}
+
+		actual, err := flattenKubernetesClusterManagedClusterIdentity(v.input)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
@@ -20,6 +20,10 @@ import (
 type KubernetesClusterNodePoolResource struct {
 }
 
+func (KubernetesClusterNodePoolResource) ResourceCost() int {
+	return 2
+}
+
 var kubernetesNodePoolTests = map[string]func(t *testing.T){
 	"autoScale":                      testAccKubernetesClusterNodePool_autoScale,
 	"autoScaleUpdate":                testAccKubernetesClusterNodePool_autoScaleUpdate,
@@ -17,6 +17,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/identity"
 	computeValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/compute/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/containers/kubernetes"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/containers/parse"
@@ -30,6 +32,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+type kubernetesClusterIdentity = identity.SystemAssignedUserAssigned
+
 func resourceKubernetesCluster() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceKubernetesClusterCreate,
@@ -202,38 +206,7 @@ func resourceKubernetesCluster() *schema.Resource {
 				Optional: true,
 			},
 
-			"identity": {
-				Type:     schema.TypeList,
-				Optional: true,
-				ForceNew: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"type": {
-							Type:     schema.TypeString,
-							Required: true,
-							ForceNew: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(containerservice.ResourceIdentityTypeSystemAssigned),
-								string(containerservice.ResourceIdentityTypeUserAssigned),
-							}, false),
-						},
-						"user_assigned_identity_id": {
-							Type:         schema.TypeString,
-							ValidateFunc: msivalidate.UserAssignedIdentityID,
-							Optional:     true,
-						},
-						"principal_id": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"tenant_id": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-					},
-				},
-			},
+			"identity": schemaKubernetesClusterIdentity(),
 
 			"kubelet_identity": {
 				Type:     schema.TypeList,
@@ -846,7 +819,7 @@ func resourceKubernetesClusterCreate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	managedClusterIdentityRaw := d.Get("identity").([]interface{})
+	managedClusterIdentityRaw := kubernetesClusterIdentityRaw(d)
 	servicePrincipalProfileRaw := d.Get("service_principal").([]interface{})
 
 	if len(managedClusterIdentityRaw) == 0 && len(servicePrincipalProfileRaw) == 0 {
@@ -854,7 +827,11 @@ func resourceKubernetesClusterCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if len(managedClusterIdentityRaw) > 0 {
-		parameters.Identity = expandKubernetesClusterManagedClusterIdentity(managedClusterIdentityRaw)
+		clusterIdentity, err := expandKubernetesClusterManagedClusterIdentity(managedClusterIdentityRaw)
+		if err != nil {
+			return fmt.Errorf("expanding `identity`: %+v", err)
+		}
+		parameters.Identity = clusterIdentity
 		parameters.ManagedClusterProperties.ServicePrincipalProfile = &containerservice.ManagedClusterServicePrincipalProfile{
 			ClientID: utils.String("msi"),
 		}
@@ -1138,8 +1115,12 @@ func resourceKubernetesClusterUpdate(d *schema.ResourceData, meta interface{}) e
 
 	if d.HasChange("identity") {
 		updateCluster = true
-		managedClusterIdentityRaw := d.Get("identity").([]interface{})
-		existing.Identity = expandKubernetesClusterManagedClusterIdentity(managedClusterIdentityRaw)
+		managedClusterIdentityRaw := kubernetesClusterIdentityRaw(d)
+		clusterIdentity, err := expandKubernetesClusterManagedClusterIdentity(managedClusterIdentityRaw)
+		if err != nil {
+			return fmt.Errorf("expanding `identity`: %+v", err)
+		}
+		existing.Identity = clusterIdentity
 	}
 
 	if d.HasChange("sku_tier") {
@@ -1840,29 +1821,78 @@ func expandKubernetesClusterRoleBasedAccessControl(input []interface{}, provider
 	return rbacEnabled, aad, nil
 }
 
-func expandKubernetesClusterManagedClusterIdentity(input []interface{}) *containerservice.ManagedClusterIdentity {
-	if len(input) == 0 || input[0] == nil {
-		return &containerservice.ManagedClusterIdentity{
-			Type: containerservice.ResourceIdentityTypeNone,
-		}
+func schemaKubernetesClusterIdentity() *schema.Schema {
+	output := kubernetesClusterIdentity{}.Schema()
+	output.ForceNew = true
+
+	fields := output.Elem.(*schema.Resource).Schema
+	fields["type"].ForceNew = true
+	fields["type"].ValidateFunc = validation.StringInSlice([]string{
+		string(containerservice.ResourceIdentityTypeSystemAssigned),
+		string(containerservice.ResourceIdentityTypeUserAssigned),
+	}, true)
+	// a Kubernetes Cluster can only be assigned a single User Assigned Identity
+	fields["identity_ids"].MaxItems = 1
+
+	if !features.ThreePointOh() {
+		fields["identity_ids"].Computed = true
+		fields["identity_ids"].ConflictsWith = []string{"identity.0.user_assigned_identity_id"}
+		fields["user_assigned_identity_id"] = &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  msivalidate.UserAssignedIdentityID,
+			ConflictsWith: []string{"identity.0.identity_ids"},
+			Deprecated:    "Deprecated in favour of `identity_ids` and will be removed in version 3.0 of the AzureRM Provider",
+		}
+	}
+
+	return output
+}
+
+// kubernetesClusterIdentityRaw returns the `identity` block from the configuration - prior to 3.0 both
+// `identity_ids` and `user_assigned_identity_id` are Computed, so the field which has changed takes precedence
+func kubernetesClusterIdentityRaw(d *schema.ResourceData) []interface{} {
+	raw := d.Get("identity").([]interface{})
+	if features.ThreePointOh() || len(raw) == 0 || raw[0] == nil {
+		return raw
 	}
 
-	values := input[0].(map[string]interface{})
+	if d.HasChange("identity.0.user_assigned_identity_id") && !d.HasChange("identity.0.identity_ids") {
+		values := raw[0].(map[string]interface{})
+		values["identity_ids"] = []interface{}{}
+	}
 
-	if containerservice.ResourceIdentityType(values["type"].(string)) == containerservice.ResourceIdentityTypeUserAssigned {
-		userAssignedIdentities := map[string]*containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{
-			values["user_assigned_identity_id"].(string): {},
+	return raw
+}
+
+func expandKubernetesClusterManagedClusterIdentity(input []interface{}) (*containerservice.ManagedClusterIdentity, error) {
+	if !features.ThreePointOh() && len(input) > 0 && input[0] != nil {
+		values := input[0].(map[string]interface{})
+
+		var identityIds []interface{}
+		switch raw := values["identity_ids"].(type) {
+		case *schema.Set:
+			identityIds = raw.List()
+		case []interface{}:
+			identityIds = raw
 		}
 
-		return &containerservice.ManagedClusterIdentity{
-			Type:                   containerservice.ResourceIdentityType(values["type"].(string)),
-			UserAssignedIdentities: userAssignedIdentities,
+		if identityId, ok := values["user_assigned_identity_id"].(string); ok && identityId != "" && len(identityIds) == 0 {
+			values["identity_ids"] = []interface{}{identityId}
 		}
 	}
 
-	return &containerservice.ManagedClusterIdentity{
-		Type: containerservice.ResourceIdentityType(values["type"].(string)),
+	config, err := kubernetesClusterIdentity{}.Expand(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var output containerservice.ManagedClusterIdentity
+	if err := identity.ExpandTo(config, &output); err != nil {
+		return nil, err
 	}
+	return &output, nil
 }
 
 func flattenKubernetesClusterRoleBasedAccessControl(input *containerservice.ManagedClusterProperties, d *schema.ResourceData) []interface{} {
@@ -2006,41 +2036,21 @@ func flattenKubernetesClusterKubeConfigAAD(config kubernetes.KubeConfigAAD) []in
 }
 
 func flattenKubernetesClusterManagedClusterIdentity(input *containerservice.ManagedClusterIdentity) ([]interface{}, error) {
-	// if it's none, omit the block
-	if input == nil || input.Type == containerservice.ResourceIdentityTypeNone {
-		return []interface{}{}, nil
-	}
-
-	identity := make(map[string]interface{})
-
-	identity["principal_id"] = ""
-	if input.PrincipalID != nil {
-		identity["principal_id"] = *input.PrincipalID
-	}
-
-	identity["tenant_id"] = ""
-	if input.TenantID != nil {
-		identity["tenant_id"] = *input.TenantID
+	config, err := identity.FlattenFrom(input)
+	if err != nil {
+		return nil, err
 	}
 
-	identity["user_assigned_identity_id"] = ""
-	if input.UserAssignedIdentities != nil {
-		keys := []string{}
-		for key := range input.UserAssignedIdentities {
-			keys = append(keys, key)
-		}
-		if len(keys) > 0 {
-			parsedId, err := msiparse.UserAssignedIdentityID(keys[0])
-			if err != nil {
-				return nil, err
-			}
-			identity["user_assigned_identity_id"] = parsedId.ID()
+	output := kubernetesClusterIdentity{}.Flatten(config)
+	if !features.ThreePointOh() && len(output) > 0 {
+		userAssignedIdentityId := ""
+		if config.UserAssignedIdentityIds != nil && len(*config.UserAssignedIdentityIds) > 0 {
+			userAssignedIdentityId = (*config.UserAssignedIdentityIds)[0]
 		}
+		output[0].(map[string]interface{})["user_assigned_identity_id"] = userAssignedIdentityId
 	}
 
-	identity["type"] = string(input.Type)
-
-	return []interface{}{identity}, nil
+	return output, nil
 }
 
 func flattenKubernetesClusterAutoScalerProfile(profile *containerservice.ManagedClusterPropertiesAutoScalerProfile) []interface{} {
@@ -18,6 +18,10 @@ import (
 type KubernetesClusterResource struct {
 }
 
+func (KubernetesClusterResource) ResourceCost() int {
+	return 2
+}
+
 var (
 	olderKubernetesVersion   = "1.18.14"
 	currentKubernetesVersion = "1.19.6"
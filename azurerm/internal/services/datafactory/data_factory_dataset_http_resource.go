@@ -226,7 +226,7 @@ func resourceDataFactoryDatasetHTTPCreateUpdate(d *schema.ResourceData, meta int
 		httpTableset.Structure = expandDataFactoryDatasetStructure(v.([]interface{}))
 	}
 
-	datasetType := string(datafactory.TypeHTTPFile)
+	datasetType := string(datafactory.TypeBasicDatasetTypeHTTPFile)
 	dataset := datafactory.DatasetResource{
 		Properties: &httpTableset,
 		Type:       &datasetType,
@@ -279,7 +279,7 @@ func resourceDataFactoryDatasetHTTPRead(d *schema.ResourceData, meta interface{}
 
 	httpTable, ok := resp.Properties.AsHTTPDataset()
 	if !ok {
-		return fmt.Errorf("Error classifiying Data Factory Dataset HTTP %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeRelationalTable, *resp.Type)
+		return fmt.Errorf("Error classifiying Data Factory Dataset HTTP %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeBasicDatasetTypeRelationalTable, *resp.Type)
 	}
 
 	d.Set("additional_properties", httpTable.AdditionalProperties)
@@ -117,7 +117,7 @@ func resourceDataFactoryIntegrationRuntimeSelfHostedCreateUpdate(d *schema.Resou
 
 	selfHostedIntegrationRuntime := datafactory.SelfHostedIntegrationRuntime{
 		Description: &description,
-		Type:        datafactory.TypeSelfHosted,
+		Type:        datafactory.TypeBasicIntegrationRuntimeTypeSelfHosted,
 	}
 
 	properties := expandAzureRmDataFactoryIntegrationRuntimeSelfHostedTypeProperties(d)
@@ -166,7 +166,7 @@ func resourceDataFactoryLinkedServiceAzureFileStorageCreateUpdate(d *schema.Reso
 	fileStorageLinkedService := &datafactory.AzureFileStorageLinkedService{
 		Description: utils.String(d.Get("description").(string)),
 		AzureFileStorageLinkedServiceTypeProperties: fileStorageProperties,
-		Type: datafactory.TypeAzureFileStorage,
+		Type: datafactory.TypeBasicLinkedServiceTypeAzureFileStorage,
 	}
 
 	if v, ok := d.GetOk("parameters"); ok {
@@ -237,7 +237,7 @@ func resourceDataFactoryLinkedServiceAzureFileStorageRead(d *schema.ResourceData
 
 	fileStorage, ok := resp.Properties.AsAzureFileStorageLinkedService()
 	if !ok {
-		return fmt.Errorf("Error classifiying Data Factory Linked Service Azure File Storage %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeWeb, *resp.Type)
+		return fmt.Errorf("Error classifiying Data Factory Linked Service Azure File Storage %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeBasicLinkedServiceTypeWeb, *resp.Type)
 	}
 
 	d.Set("additional_properties", fileStorage.AdditionalProperties)
@@ -135,7 +135,7 @@ func resourceDataFactoryLinkedServiceAzureFunctionCreateUpdate(d *schema.Resourc
 				Type:  datafactory.TypeSecureString,
 			},
 		},
-		Type: datafactory.TypeAzureFunction,
+		Type: datafactory.TypeBasicLinkedServiceTypeAzureFunction,
 	}
 
 	if v, ok := d.GetOk("parameters"); ok {
@@ -206,7 +206,7 @@ func resourceDataFactoryLinkedServiceAzureFunctionRead(d *schema.ResourceData, m
 
 	azureFunction, ok := resp.Properties.AsAzureFunctionLinkedService()
 	if !ok {
-		return fmt.Errorf("Error classifiying Data Factory Linked Service Azure Function %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeWeb, *resp.Type)
+		return fmt.Errorf("Error classifiying Data Factory Linked Service Azure Function %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeBasicLinkedServiceTypeWeb, *resp.Type)
 	}
 
 	d.Set("url", azureFunction.AzureFunctionLinkedServiceTypeProperties.FunctionAppURL)
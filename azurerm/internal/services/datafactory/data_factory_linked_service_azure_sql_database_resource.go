@@ -204,7 +204,7 @@ func resourceDataFactoryLinkedServiceAzureSQLDatabaseCreateUpdate(d *schema.Reso
 	azureSQLDatabaseLinkedService := &datafactory.AzureSQLDatabaseLinkedService{
 		Description: utils.String(d.Get("description").(string)),
 		AzureSQLDatabaseLinkedServiceTypeProperties: sqlDatabaseProperties,
-		Type: datafactory.TypeAzureSQLDatabase,
+		Type: datafactory.TypeBasicLinkedServiceTypeAzureSQLDatabase,
 	}
 
 	if v, ok := d.GetOk("parameters"); ok {
@@ -272,7 +272,7 @@ func resourceDataFactoryLinkedServiceAzureSQLDatabaseRead(d *schema.ResourceData
 
 	sql, ok := resp.Properties.AsAzureSQLDatabaseLinkedService()
 	if !ok {
-		return fmt.Errorf("Error classifiying Data Factory Linked Service AzureSQLDatabase %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", id.Name, id.FactoryName, id.ResourceGroup, datafactory.TypeAzureSQLDatabase, *resp.Type)
+		return fmt.Errorf("Error classifiying Data Factory Linked Service AzureSQLDatabase %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", id.Name, id.FactoryName, id.ResourceGroup, datafactory.TypeBasicLinkedServiceTypeAzureSQLDatabase, *resp.Type)
 	}
 
 	if sql != nil {
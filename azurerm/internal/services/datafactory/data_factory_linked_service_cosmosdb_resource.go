@@ -174,7 +174,7 @@ func resourceDataFactoryLinkedServiceCosmosDbCreateUpdate(d *schema.ResourceData
 	cosmosdbLinkedService := &datafactory.CosmosDbLinkedService{
 		Description:                         &description,
 		CosmosDbLinkedServiceTypeProperties: cosmosdbProperties,
-		Type:                                datafactory.TypeCosmosDb,
+		Type:                                datafactory.TypeBasicLinkedServiceTypeCosmosDb,
 	}
 
 	if v, ok := d.GetOk("parameters"); ok {
@@ -245,7 +245,7 @@ func resourceDataFactoryLinkedServiceCosmosDbRead(d *schema.ResourceData, meta i
 
 	cosmosdb, ok := resp.Properties.AsCosmosDbLinkedService()
 	if !ok {
-		return fmt.Errorf("Error classifiying Data Factory Linked Service CosmosDb %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeCosmosDb, *resp.Type)
+		return fmt.Errorf("Error classifiying Data Factory Linked Service CosmosDb %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeBasicLinkedServiceTypeCosmosDb, *resp.Type)
 	}
 
 	d.Set("additional_properties", cosmosdb.AdditionalProperties)
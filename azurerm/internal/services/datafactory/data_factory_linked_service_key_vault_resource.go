@@ -138,7 +138,7 @@ func resourceDataFactoryLinkedServiceKeyVaultCreateUpdate(d *schema.ResourceData
 	azureKeyVaultLinkedService := &datafactory.AzureKeyVaultLinkedService{
 		Description:                              utils.String(d.Get("description").(string)),
 		AzureKeyVaultLinkedServiceTypeProperties: azureKeyVaultProperties,
-		Type:                                     datafactory.TypeAzureKeyVault,
+		Type:                                     datafactory.TypeBasicLinkedServiceTypeAzureKeyVault,
 	}
 
 	if v, ok := d.GetOk("parameters"); ok {
@@ -211,7 +211,7 @@ func resourceDataFactoryLinkedServiceKeyVaultRead(d *schema.ResourceData, meta i
 
 	keyVault, ok := resp.Properties.AsAzureKeyVaultLinkedService()
 	if !ok {
-		return fmt.Errorf("Error classifiying Data Factory Linked Service Key Vault %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeAzureKeyVault, *resp.Type)
+		return fmt.Errorf("Error classifiying Data Factory Linked Service Key Vault %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", name, dataFactoryName, resourceGroup, datafactory.TypeBasicLinkedServiceTypeAzureKeyVault, *resp.Type)
 	}
 
 	d.Set("additional_properties", keyVault.AdditionalProperties)
@@ -154,7 +154,7 @@ func resourceDataFactoryLinkedServiceSynapseCreateUpdate(d *schema.ResourceData,
 			ConnectionString: d.Get("connection_string").(string),
 			Password:         expandAzureKeyVaultPassword(password),
 		},
-		Type: datafactory.TypeAzureSQLDW,
+		Type: datafactory.TypeBasicLinkedServiceTypeAzureSQLDW,
 	}
 
 	if v, ok := d.GetOk("parameters"); ok {
@@ -222,7 +222,7 @@ func resourceDataFactoryLinkedServiceSynapseRead(d *schema.ResourceData, meta in
 
 	sqlDW, ok := resp.Properties.AsAzureSQLDWLinkedService()
 	if !ok {
-		return fmt.Errorf("Error classifying Data Factory Linked Service Synapse %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", id.Name, id.FactoryName, id.ResourceGroup, datafactory.TypeAzureSQLDW, *resp.Type)
+		return fmt.Errorf("Error classifying Data Factory Linked Service Synapse %q (Data Factory %q / Resource Group %q): Expected: %q Received: %q", id.Name, id.FactoryName, id.ResourceGroup, datafactory.TypeBasicLinkedServiceTypeAzureSQLDW, *resp.Type)
 	}
 
 	d.Set("additional_properties", sqlDW.AdditionalProperties)
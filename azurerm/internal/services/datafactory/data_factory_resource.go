@@ -2,6 +2,7 @@ package datafactory
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/datafactory/mgmt/2018-06-01/datafactory"
@@ -10,6 +11,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/identity"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/migration"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/datafactory/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
@@ -17,6 +19,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+type dataFactoryIdentity = identity.SystemAssignedUserAssigned
+
 func resourceDataFactory() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDataFactoryCreateUpdate,
@@ -54,31 +58,7 @@ func resourceDataFactory() *schema.Resource {
 			// BUG: https://github.com/Azure/azure-rest-api-specs/issues/5788
 			"resource_group_name": azure.SchemaResourceGroupNameDiffSuppress(),
 
-			"identity": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Computed: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"type": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								string(datafactory.SystemAssigned),
-							}, false),
-						},
-						"principal_id": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"tenant_id": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-					},
-				},
-			},
+			"identity": schemaDataFactoryIdentity(),
 
 			"github_configuration": {
 				Type:          schema.TypeList,
@@ -203,11 +183,12 @@ func resourceDataFactoryCreateUpdate(d *schema.ResourceData, meta interface{}) e
 		dataFactory.FactoryProperties.PublicNetworkAccess = datafactory.PublicNetworkAccessDisabled
 	}
 
-	if v, ok := d.GetOk("identity.0.type"); ok {
-		identityType := v.(string)
-		dataFactory.Identity = &datafactory.FactoryIdentity{
-			Type: datafactory.FactoryIdentityType(identityType),
+	if _, ok := d.GetOk("identity.0.type"); ok {
+		factoryIdentity, err := expandDataFactoryIdentity(d.Get("identity").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("expanding `identity`: %+v", err)
 		}
+		dataFactory.Identity = factoryIdentity
 	}
 
 	if _, err := client.CreateOrUpdate(ctx, resourceGroup, name, dataFactory, ""); err != nil {
@@ -269,23 +250,27 @@ func resourceDataFactoryRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("vsts_configuration", []interface{}{})
 	d.Set("github_configuration", []interface{}{})
 	repoType, repo := flattenDataFactoryRepoConfiguration(&resp)
-	if repoType == datafactory.TypeFactoryVSTSConfiguration {
+	if repoType == datafactory.TypeBasicFactoryRepoConfigurationTypeFactoryVSTSConfiguration {
 		if err := d.Set("vsts_configuration", repo); err != nil {
 			return fmt.Errorf("Error setting `vsts_configuration`: %+v", err)
 		}
 	}
-	if repoType == datafactory.TypeFactoryGitHubConfiguration {
+	if repoType == datafactory.TypeBasicFactoryRepoConfigurationTypeFactoryGitHubConfiguration {
 		if err := d.Set("github_configuration", repo); err != nil {
 			return fmt.Errorf("Error setting `github_configuration`: %+v", err)
 		}
 	}
-	if repoType == datafactory.TypeFactoryRepoConfiguration {
+	if repoType == datafactory.TypeBasicFactoryRepoConfigurationTypeFactoryRepoConfiguration {
 		d.Set("vsts_configuration", repo)
 		d.Set("github_configuration", repo)
 	}
 
-	if err := d.Set("identity", flattenDataFactoryIdentity(resp.Identity)); err != nil {
-		return fmt.Errorf("Error flattening `identity`: %+v", err)
+	factoryIdentity, err := flattenDataFactoryIdentity(resp.Identity)
+	if err != nil {
+		return fmt.Errorf("flattening `identity`: %+v", err)
+	}
+	if err := d.Set("identity", factoryIdentity); err != nil {
+		return fmt.Errorf("Error setting `identity`: %+v", err)
 	}
 
 	// This variable isn't returned from the API if it hasn't been passed in first but we know the default is `true`
@@ -379,7 +364,7 @@ func flattenDataFactoryRepoConfiguration(factory *datafactory.Factory) (datafact
 				if config.RootFolder != nil {
 					settings["root_folder"] = *config.RootFolder
 				}
-				return datafactory.TypeFactoryGitHubConfiguration, append(result, settings)
+				return datafactory.TypeBasicFactoryRepoConfigurationTypeFactoryGitHubConfiguration, append(result, settings)
 			}
 			if config, test := repo.AsFactoryVSTSConfiguration(); test {
 				if config.AccountName != nil {
@@ -400,32 +385,44 @@ func flattenDataFactoryRepoConfiguration(factory *datafactory.Factory) (datafact
 				if config.TenantID != nil {
 					settings["tenant_id"] = *config.TenantID
 				}
-				return datafactory.TypeFactoryVSTSConfiguration, append(result, settings)
+				return datafactory.TypeBasicFactoryRepoConfigurationTypeFactoryVSTSConfiguration, append(result, settings)
 			}
 		}
 	}
-	return datafactory.TypeFactoryRepoConfiguration, result
+	return datafactory.TypeBasicFactoryRepoConfigurationTypeFactoryRepoConfiguration, result
+}
+
+func schemaDataFactoryIdentity() *schema.Schema {
+	output := dataFactoryIdentity{}.Schema()
+	output.Computed = true
+	return output
 }
 
-func flattenDataFactoryIdentity(identity *datafactory.FactoryIdentity) interface{} {
-	if identity == nil {
-		return []interface{}{}
+func expandDataFactoryIdentity(input []interface{}) (*datafactory.FactoryIdentity, error) {
+	config, err := dataFactoryIdentity{}.Expand(input)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(config.Type, "None") {
+		// Data Factory doesn't support removing the Managed Identity
+		return nil, nil
 	}
 
-	principalId := ""
-	if identity.PrincipalID != nil {
-		principalId = identity.PrincipalID.String()
+	var output datafactory.FactoryIdentity
+	if err := identity.ExpandTo(config, &output); err != nil {
+		return nil, err
 	}
-	tenantId := ""
-	if identity.TenantID != nil {
-		tenantId = identity.TenantID.String()
+	if output.Type == datafactory.FactoryIdentityType("SystemAssigned, UserAssigned") {
+		// Data Factory uses a different format for this value
+		output.Type = datafactory.FactoryIdentityTypeSystemAssignedUserAssigned
 	}
+	return &output, nil
+}
 
-	return []interface{}{
-		map[string]interface{}{
-			"principal_id": principalId,
-			"tenant_id":    tenantId,
-			"type":         string(identity.Type),
-		},
+func flattenDataFactoryIdentity(input *datafactory.FactoryIdentity) ([]interface{}, error) {
+	config, err := identity.FlattenFrom(input)
+	if err != nil {
+		return nil, err
 	}
+	return dataFactoryIdentity{}.Flatten(config), nil
 }
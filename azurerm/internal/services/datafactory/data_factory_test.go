@@ -1,6 +1,12 @@
 package datafactory
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/datafactory/mgmt/2018-06-01/datafactory"
+	"github.com/gofrs/uuid"
+)
 
 func TestDataFactoryLinkedServiceConnectionStringDiff(t *testing.T) {
 	cases := []struct {
@@ -203,3 +209,122 @@ func TestNormalizeJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandDataFactoryIdentity(t *testing.T) {
+	identityId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"
+
+	testData := []struct {
+		name     string
+		input    []interface{}
+		expected *datafactory.FactoryIdentity
+	}{
+		{
+			name:     "omitted",
+			input:    []interface{}{},
+			expected: nil,
+		},
+		{
+			name: "none",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "None",
+					"identity_ids": []interface{}{},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "system assigned, user assigned",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned, UserAssigned",
+					"identity_ids": []interface{}{identityId},
+				},
+			},
+			expected: &datafactory.FactoryIdentity{
+				Type: datafactory.FactoryIdentityTypeSystemAssignedUserAssigned,
+				UserAssignedIdentities: map[string]interface{}{
+					identityId: map[string]interface{}{},
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := expandDataFactoryIdentity(v.input)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestFlattenDataFactoryIdentity(t *testing.T) {
+	identityId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"
+	principalId := uuid.FromStringOrNil("11111111-1111-1111-1111-111111111111")
+	tenantId := uuid.FromStringOrNil("22222222-2222-2222-2222-222222222222")
+
+	testData := []struct {
+		name     string
+		input    *datafactory.FactoryIdentity
+		expected []interface{}
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: []interface{}{},
+		},
+		{
+			name: "system assigned",
+			input: &datafactory.FactoryIdentity{
+				Type:        datafactory.FactoryIdentityTypeSystemAssigned,
+				PrincipalID: &principalId,
+				TenantID:    &tenantId,
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned",
+					"identity_ids": []interface{}{},
+					"principal_id": principalId.String(),
+					"tenant_id":    tenantId.String(),
+				},
+			},
+		},
+		{
+			// Data Factory returns `SystemAssigned,UserAssigned` which is normalized
+			name: "system assigned, user assigned",
+			input: &datafactory.FactoryIdentity{
+				Type:        datafactory.FactoryIdentityTypeSystemAssignedUserAssigned,
+				PrincipalID: &principalId,
+				TenantID:    &tenantId,
+				UserAssignedIdentities: map[string]interface{}{
+					identityId: map[string]interface{}{},
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned, UserAssigned",
+					"identity_ids": []interface{}{identityId},
+					"principal_id": principalId.String(),
+					"tenant_id":    tenantId.String(),
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := flattenDataFactoryIdentity(v.input)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
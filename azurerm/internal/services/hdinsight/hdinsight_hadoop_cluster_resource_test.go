@@ -17,6 +17,10 @@ import (
 type HDInsightHadoopClusterResource struct {
 }
 
+func (HDInsightHadoopClusterResource) ResourceCost() int {
+	return 3
+}
+
 func TestAccHDInsightHadoopCluster_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_hdinsight_hadoop_cluster", "test")
 	r := HDInsightHadoopClusterResource{}
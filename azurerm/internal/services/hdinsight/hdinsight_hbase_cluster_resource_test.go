@@ -17,6 +17,10 @@ import (
 type HDInsightHBaseClusterResource struct {
 }
 
+func (HDInsightHBaseClusterResource) ResourceCost() int {
+	return 3
+}
+
 func TestAccHDInsightHBaseCluster_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_hdinsight_hbase_cluster", "test")
 	r := HDInsightHBaseClusterResource{}
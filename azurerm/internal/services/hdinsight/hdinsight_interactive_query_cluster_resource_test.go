@@ -17,6 +17,10 @@ import (
 type HDInsightInteractiveQueryClusterResource struct {
 }
 
+func (HDInsightInteractiveQueryClusterResource) ResourceCost() int {
+	return 3
+}
+
 func TestAccHDInsightInteractiveQueryCluster_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_hdinsight_interactive_query_cluster", "test")
 	r := HDInsightInteractiveQueryClusterResource{}
@@ -17,6 +17,10 @@ import (
 type HDInsightKafkaClusterResource struct {
 }
 
+func (HDInsightKafkaClusterResource) ResourceCost() int {
+	return 3
+}
+
 func TestAccHDInsightKafkaCluster_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_hdinsight_kafka_cluster", "test")
 	r := HDInsightKafkaClusterResource{}
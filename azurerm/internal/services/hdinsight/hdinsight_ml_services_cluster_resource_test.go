@@ -17,6 +17,10 @@ import (
 type HDInsightMLServicesClusterResource struct {
 }
 
+func (HDInsightMLServicesClusterResource) ResourceCost() int {
+	return 3
+}
+
 func TestAccHDInsightMLServicesCluster_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_hdinsight_ml_services_cluster", "test")
 	r := HDInsightMLServicesClusterResource{}
@@ -17,6 +17,10 @@ import (
 type HDInsightRServerClusterResource struct {
 }
 
+func (HDInsightRServerClusterResource) ResourceCost() int {
+	return 3
+}
+
 func TestAccHDInsightRServerCluster_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_hdinsight_rserver_cluster", "test")
 	r := HDInsightRServerClusterResource{}
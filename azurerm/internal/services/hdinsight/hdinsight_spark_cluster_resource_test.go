@@ -17,6 +17,10 @@ import (
 type HDInsightSparkClusterResource struct {
 }
 
+func (HDInsightSparkClusterResource) ResourceCost() int {
+	return 3
+}
+
 func TestAccHDInsightSparkCluster_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_hdinsight_spark_cluster", "test")
 	r := HDInsightSparkClusterResource{}
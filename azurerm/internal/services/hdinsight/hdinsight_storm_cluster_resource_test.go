@@ -17,6 +17,10 @@ import (
 type HDInsightStormClusterResource struct {
 }
 
+func (HDInsightStormClusterResource) ResourceCost() int {
+	return 3
+}
+
 func TestAccHDInsightStormCluster_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_hdinsight_storm_cluster", "test")
 	r := HDInsightStormClusterResource{}
@@ -38,8 +38,8 @@ func SchemaHDInsightTier() *schema.Schema {
 		Required: true,
 		ForceNew: true,
 		ValidateFunc: validation.StringInSlice([]string{
-			string(hdinsight.Standard),
-			string(hdinsight.Premium),
+			string(hdinsight.TierStandard),
+			string(hdinsight.TierPremium),
 		}, true),
 		// TODO: file a bug about this
 		DiffSuppressFunc: location.DiffSuppressFunc,
@@ -220,10 +220,10 @@ func SchemaHDInsightsNetwork() *schema.Schema {
 					Type:     schema.TypeString,
 					Optional: true,
 					ForceNew: true,
-					Default:  string(hdinsight.Inbound),
+					Default:  string(hdinsight.ResourceProviderConnectionInbound),
 					ValidateFunc: validation.StringInSlice([]string{
-						string(hdinsight.Inbound),
-						string(hdinsight.Outbound),
+						string(hdinsight.ResourceProviderConnectionInbound),
+						string(hdinsight.ResourceProviderConnectionOutbound),
 					}, false),
 				},
 
@@ -354,14 +354,14 @@ func ExpandHDInsightsNetwork(input []interface{}) *hdinsight.NetworkProperties {
 
 	vs := input[0].(map[string]interface{})
 
-	connDir := hdinsight.Outbound
-	if v, exists := vs["connection_direction"]; exists && v != string(hdinsight.Outbound) {
-		connDir = hdinsight.Inbound
+	connDir := hdinsight.ResourceProviderConnectionOutbound
+	if v, exists := vs["connection_direction"]; exists && v != string(hdinsight.ResourceProviderConnectionOutbound) {
+		connDir = hdinsight.ResourceProviderConnectionInbound
 	}
 
-	privateLink := hdinsight.Disabled
+	privateLink := hdinsight.PrivateLinkDisabled
 	if v, exists := vs["private_link_enabled"]; exists && v != false {
-		privateLink = hdinsight.Enabled
+		privateLink = hdinsight.PrivateLinkEnabled
 	}
 
 	return &hdinsight.NetworkProperties{
@@ -375,14 +375,14 @@ func FlattenHDInsightsNetwork(input *hdinsight.NetworkProperties) []interface{}
 		return nil
 	}
 
-	connDir := string(hdinsight.Outbound)
+	connDir := string(hdinsight.ResourceProviderConnectionOutbound)
 	if v := input.ResourceProviderConnection; v != "" {
 		connDir = string(v)
 	}
 
 	privateLink := false
 	if v := input.PrivateLink; v != "" {
-		privateLink = (v == hdinsight.Enabled)
+		privateLink = (v == hdinsight.PrivateLinkEnabled)
 	}
 
 	return []interface{}{
@@ -629,7 +629,7 @@ func ExpandHDInsightsStorageAccounts(storageAccounts []interface{}, gen2storageA
 
 		if clusterIndentity == nil {
 			clusterIndentity = &hdinsight.ClusterIdentity{
-				Type:                   hdinsight.UserAssigned,
+				Type:                   hdinsight.ResourceIdentityTypeUserAssigned,
 				UserAssignedIdentities: make(map[string]*hdinsight.ClusterIdentityUserAssignedIdentitiesValue),
 			}
 		}
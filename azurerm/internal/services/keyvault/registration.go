@@ -2,6 +2,8 @@ package keyvault
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 )
 
 type Registration struct{}
@@ -42,3 +44,65 @@ func (r Registration) SupportedResources() map[string]*schema.Resource {
 		"azurerm_key_vault":                    resourceKeyVault(),
 	}
 }
+
+// Sweepers returns a list of Sweepers which clean up resources leaked by the Acceptance Tests
+func (r Registration) Sweepers() []sdk.Sweeper {
+	return []sdk.Sweeper{
+		softDeletedKeyVaultSweeper(),
+	}
+}
+
+// APIProfiles returns the Data Sources and Resources supported by this Service on each API Profile
+func (r Registration) APIProfiles() map[string]sdk.APIProfileSupport {
+	return map[string]sdk.APIProfileSupport{
+		common.APIProfile20190301Hybrid: {
+			DataSources: []string{
+				"azurerm_key_vault",
+				"azurerm_key_vault_secret",
+			},
+			Resources: []string{
+				"azurerm_key_vault",
+				"azurerm_key_vault_access_policy",
+				"azurerm_key_vault_secret",
+			},
+		},
+	}
+}
+
+// RequiredPermissions returns the RBAC Actions required to provision each Resource within this Service
+func (r Registration) RequiredPermissions() map[string][]string {
+	return map[string][]string{
+		"azurerm_key_vault": {
+			"Microsoft.KeyVault/vaults/read",
+			"Microsoft.KeyVault/vaults/write",
+			"Microsoft.KeyVault/vaults/delete",
+		},
+		"azurerm_key_vault_access_policy": {
+			"Microsoft.KeyVault/vaults/read",
+			"Microsoft.KeyVault/vaults/accessPolicies/write",
+		},
+	}
+}
+
+// PolicyContent returns the Azure Resource provisioned by each Resource within this Service
+func (r Registration) PolicyContent() map[string]sdk.PolicyContent {
+	return map[string]sdk.PolicyContent{
+		"azurerm_key_vault": {
+			ResourceType: "Microsoft.KeyVault/vaults",
+			APIVersion:   "2019-09-01",
+			Properties: func(d *schema.ResourceDiff) map[string]interface{} {
+				properties := make(map[string]interface{})
+				if d.NewValueKnown("sku_name") {
+					properties["sku"] = map[string]interface{}{
+						"family": "A",
+						"name":   d.Get("sku_name").(string),
+					}
+				}
+				if d.NewValueKnown("tenant_id") {
+					properties["tenantId"] = d.Get("tenant_id").(string)
+				}
+				return properties
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package keyvault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+)
+
+func softDeletedKeyVaultSweeper() sdk.Sweeper {
+	return sdk.Sweeper{
+		Name: "Soft-Deleted Key Vaults",
+		// NOTE: Soft-Deleted Key Vaults always have a Deletion Date (and so an age) - as such only the
+		// `acct` prefix used by the Acceptance Tests is swept, to avoid purging Key Vaults not created by them
+		Prefixes: []string{
+			"acct",
+		},
+		List: func(ctx context.Context, client *clients.Client) (*[]sdk.SweepableResource, error) {
+			output := make([]sdk.SweepableResource, 0)
+
+			iterator, err := client.KeyVault.VaultsClient.ListDeletedComplete(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("listing Soft-Deleted Key Vaults: %+v", err)
+			}
+			for iterator.NotDone() {
+				vault := iterator.Value()
+				if vault.ID != nil && vault.Name != nil && vault.Properties != nil && vault.Properties.Location != nil {
+					resource := sdk.SweepableResource{
+						ID:       *vault.ID,
+						Name:     *vault.Name,
+						Location: *vault.Properties.Location,
+					}
+					if vault.Properties.DeletionDate != nil {
+						resource.Timestamp = &vault.Properties.DeletionDate.Time
+					}
+					output = append(output, resource)
+				}
+
+				if err := iterator.NextWithContext(ctx); err != nil {
+					return nil, fmt.Errorf("listing Soft-Deleted Key Vaults: %+v", err)
+				}
+			}
+
+			return &output, nil
+		},
+		Delete: func(ctx context.Context, client *clients.Client, resource sdk.SweepableResource) error {
+			vaultsClient := client.KeyVault.VaultsClient
+			future, err := vaultsClient.PurgeDeleted(ctx, resource.Name, resource.Location)
+			if err != nil {
+				return fmt.Errorf("purging Soft-Deleted Key Vault %q (Location %q): %+v", resource.Name, resource.Location, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, vaultsClient.Client); err != nil {
+				return fmt.Errorf("waiting for purge of Soft-Deleted Key Vault %q (Location %q): %+v", resource.Name, resource.Location, err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/types"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loadbalancer"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loadbalancer/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
@@ -28,6 +29,13 @@ func TestAccBackendAddressPoolAddressBasic(t *testing.T) {
 			Config: r.basic(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Model().
+					WithMatcher("backend_address_pool_id", check.IsNotEmpty()).
+					WithMatcher("virtual_network_id", check.IsNotEmpty()).
+					Matches(loadbalancer.BackendAddressPoolAddressModel{
+						Name:      "address",
+						IPAddress: "191.168.0.1",
+					}),
 			),
 		},
 		data.ImportStep(),
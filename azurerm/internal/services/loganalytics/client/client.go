@@ -10,6 +10,7 @@ type Client struct {
 	ClusterClient              *operationalinsights.ClustersClient
 	DataExportClient           *operationalinsights.DataExportsClient
 	DataSourcesClient          *operationalinsights.DataSourcesClient
+	DeletedWorkspacesClient    *operationalinsights.DeletedWorkspacesClient
 	LinkedServicesClient       *operationalinsights.LinkedServicesClient
 	LinkedStorageAccountClient *operationalinsights.LinkedStorageAccountsClient
 	SavedSearchesClient        *operationalinsights.SavedSearchesClient
@@ -29,6 +30,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	DataSourcesClient := operationalinsights.NewDataSourcesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&DataSourcesClient.Client, o.ResourceManagerAuthorizer)
 
+	DeletedWorkspacesClient := operationalinsights.NewDeletedWorkspacesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&DeletedWorkspacesClient.Client, o.ResourceManagerAuthorizer)
+
 	WorkspacesClient := operationalinsights.NewWorkspacesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&WorkspacesClient.Client, o.ResourceManagerAuthorizer)
 
@@ -54,6 +58,7 @@ func NewClient(o *common.ClientOptions) *Client {
 		ClusterClient:              &ClusterClient,
 		DataExportClient:           &DataExportClient,
 		DataSourcesClient:          &DataSourcesClient,
+		DeletedWorkspacesClient:    &DeletedWorkspacesClient,
 		LinkedServicesClient:       &LinkedServicesClient,
 		LinkedStorageAccountClient: &LinkedStorageAccountClient,
 		SavedSearchesClient:        &SavedSearchesClient,
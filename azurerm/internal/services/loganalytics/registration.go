@@ -2,6 +2,7 @@ package loganalytics
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 )
 
 type Registration struct{}
@@ -41,3 +42,10 @@ func (r Registration) SupportedResources() map[string]*schema.Resource {
 		"azurerm_log_analytics_workspace":                              resourceLogAnalyticsWorkspace(),
 	}
 }
+
+// Sweepers returns a list of Sweepers which clean up resources leaked by the Acceptance Tests
+func (r Registration) Sweepers() []sdk.Sweeper {
+	return []sdk.Sweeper{
+		softDeletedWorkspaceSweeper(),
+	}
+}
@@ -0,0 +1,120 @@
+package loganalytics
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/operationalinsights/mgmt/2020-08-01/operationalinsights"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func softDeletedWorkspaceSweeper() sdk.Sweeper {
+	return sdk.Sweeper{
+		Name: "Soft-Deleted Log Analytics Workspaces",
+		Prefixes: []string{
+			"acctest",
+		},
+		List: func(ctx context.Context, client *clients.Client) (*[]sdk.SweepableResource, error) {
+			resp, err := client.LogAnalytics.DeletedWorkspacesClient.List(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("listing Soft-Deleted Log Analytics Workspaces: %+v", err)
+			}
+
+			output := make([]sdk.SweepableResource, 0)
+			if resp.Value != nil {
+				for _, workspace := range *resp.Value {
+					if workspace.ID == nil || workspace.Name == nil || workspace.Location == nil {
+						continue
+					}
+
+					output = append(output, sdk.SweepableResource{
+						ID:       *workspace.ID,
+						Name:     *workspace.Name,
+						Location: *workspace.Location,
+					})
+				}
+			}
+
+			return &output, nil
+		},
+		Delete: func(ctx context.Context, client *clients.Client, resource sdk.SweepableResource) (err error) {
+			id, err := parse.LogAnalyticsWorkspaceID(resource.ID)
+			if err != nil {
+				return err
+			}
+
+			// a Soft-Deleted Workspace can't be purged directly - instead it has to be recovered (by re-creating it)
+			// and then Force-Deleted - which requires that the Resource Group exists
+			groupsClient := client.Resource.GroupsClient
+			existing, err := groupsClient.CheckExistence(ctx, id.ResourceGroup)
+			if err != nil {
+				return fmt.Errorf("checking for the existence of Resource Group %q: %+v", id.ResourceGroup, err)
+			}
+			if existing.StatusCode == http.StatusNotFound {
+				log.Printf("[DEBUG] Re-creating Resource Group %q to recover %s..", id.ResourceGroup, *id)
+				group := resources.Group{
+					Location: utils.String(resource.Location),
+				}
+				if _, err := groupsClient.CreateOrUpdate(ctx, id.ResourceGroup, group); err != nil {
+					return fmt.Errorf("re-creating Resource Group %q: %+v", id.ResourceGroup, err)
+				}
+
+				// the re-created Resource Group must be removed even if recovering/deleting the Workspace fails
+				defer func() {
+					if deleteErr := deleteResourceGroup(ctx, client, id.ResourceGroup); deleteErr != nil {
+						if err == nil {
+							err = deleteErr
+							return
+						}
+						err = fmt.Errorf("%+v\n\nadditionally: %+v", err, deleteErr)
+					}
+				}()
+			}
+
+			workspacesClient := client.LogAnalytics.WorkspacesClient
+			workspace := operationalinsights.Workspace{
+				Location: utils.String(resource.Location),
+				WorkspaceProperties: &operationalinsights.WorkspaceProperties{
+					Sku: &operationalinsights.WorkspaceSku{
+						Name: operationalinsights.WorkspaceSkuNameEnumPerGB2018,
+					},
+				},
+			}
+			createFuture, err := workspacesClient.CreateOrUpdate(ctx, id.ResourceGroup, id.WorkspaceName, workspace)
+			if err != nil {
+				return fmt.Errorf("recovering %s: %+v", *id, err)
+			}
+			if err := createFuture.WaitForCompletionRef(ctx, workspacesClient.Client); err != nil {
+				return fmt.Errorf("waiting for recovery of %s: %+v", *id, err)
+			}
+
+			deleteFuture, err := workspacesClient.Delete(ctx, id.ResourceGroup, id.WorkspaceName, utils.Bool(true))
+			if err != nil {
+				return fmt.Errorf("force-deleting %s: %+v", *id, err)
+			}
+			if err := deleteFuture.WaitForCompletionRef(ctx, workspacesClient.Client); err != nil {
+				return fmt.Errorf("waiting for force-deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func deleteResourceGroup(ctx context.Context, client *clients.Client, name string) error {
+	groupsClient := client.Resource.GroupsClient
+	future, err := groupsClient.Delete(ctx, name, "")
+	if err != nil {
+		return fmt.Errorf("deleting re-created Resource Group %q: %+v", name, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, groupsClient.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of re-created Resource Group %q: %+v", name, err)
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-05-01/network"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
@@ -63,23 +62,13 @@ func dataSourceApplicationGatewayRead(d *schema.ResourceData, meta interface{})
 
 	d.Set("location", location.NormalizeNilable(resp.Location))
 
-	identity := flattenApplicationGatewayDataSourceIdentity(resp.Identity)
-	flattenedIdentity := applicationGatewayDataSourceIdentity{}.Flatten(identity)
-	if err = d.Set("identity", flattenedIdentity); err != nil {
+	identityConfig, err := identity.FlattenFrom(resp.Identity)
+	if err != nil {
+		return fmt.Errorf("flattening `identity`: %+v", err)
+	}
+	if err = d.Set("identity", applicationGatewayDataSourceIdentity{}.Flatten(identityConfig)); err != nil {
 		return err
 	}
 
 	return tags.FlattenAndSet(d, resp.Tags)
 }
-
-func flattenApplicationGatewayDataSourceIdentity(input *network.ManagedServiceIdentity) *identity.ExpandedConfig {
-	var config *identity.ExpandedConfig
-	if input != nil {
-		config = &identity.ExpandedConfig{
-			Type:        string(input.Type),
-			PrincipalId: input.PrincipalID,
-			TenantId:    input.TenantID,
-		}
-	}
-	return config
-}
@@ -2,6 +2,8 @@ package network
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 )
 
 type Registration struct{}
@@ -110,3 +112,93 @@ func (r Registration) SupportedResources() map[string]*schema.Resource {
 		"azurerm_web_application_firewall_policy":                                        resourceWebApplicationFirewallPolicy(),
 	}
 }
+
+// APIProfiles returns the Data Sources and Resources supported by this Service on each API Profile
+func (r Registration) APIProfiles() map[string]sdk.APIProfileSupport {
+	return map[string]sdk.APIProfileSupport{
+		common.APIProfile20190301Hybrid: {
+			DataSources: []string{
+				"azurerm_network_interface",
+				"azurerm_network_security_group",
+				"azurerm_public_ip",
+				"azurerm_subnet",
+				"azurerm_virtual_network",
+			},
+			Resources: []string{
+				"azurerm_network_interface",
+				"azurerm_network_security_group",
+				"azurerm_network_security_rule",
+				"azurerm_public_ip",
+				"azurerm_subnet",
+				"azurerm_virtual_network",
+			},
+		},
+	}
+}
+
+// RequiredPermissions returns the RBAC Actions required to provision each Resource within this Service
+func (r Registration) RequiredPermissions() map[string][]string {
+	return map[string][]string{
+		"azurerm_network_interface": {
+			"Microsoft.Network/networkInterfaces/read",
+			"Microsoft.Network/networkInterfaces/write",
+			"Microsoft.Network/networkInterfaces/delete",
+			"Microsoft.Network/virtualNetworks/subnets/join/action",
+		},
+		"azurerm_network_security_group": {
+			"Microsoft.Network/networkSecurityGroups/read",
+			"Microsoft.Network/networkSecurityGroups/write",
+			"Microsoft.Network/networkSecurityGroups/delete",
+		},
+		"azurerm_network_security_rule": {
+			"Microsoft.Network/networkSecurityGroups/securityRules/read",
+			"Microsoft.Network/networkSecurityGroups/securityRules/write",
+			"Microsoft.Network/networkSecurityGroups/securityRules/delete",
+		},
+		"azurerm_public_ip": {
+			"Microsoft.Network/publicIPAddresses/read",
+			"Microsoft.Network/publicIPAddresses/write",
+			"Microsoft.Network/publicIPAddresses/delete",
+		},
+		"azurerm_subnet": {
+			"Microsoft.Network/virtualNetworks/subnets/read",
+			"Microsoft.Network/virtualNetworks/subnets/write",
+			"Microsoft.Network/virtualNetworks/subnets/delete",
+		},
+		"azurerm_subnet_network_security_group_association": {
+			"Microsoft.Network/virtualNetworks/subnets/read",
+			"Microsoft.Network/virtualNetworks/subnets/write",
+			"Microsoft.Network/networkSecurityGroups/join/action",
+		},
+		"azurerm_virtual_network": {
+			"Microsoft.Network/virtualNetworks/read",
+			"Microsoft.Network/virtualNetworks/write",
+			"Microsoft.Network/virtualNetworks/delete",
+		},
+		"azurerm_virtual_network_peering": {
+			"Microsoft.Network/virtualNetworks/virtualNetworkPeerings/read",
+			"Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write",
+			"Microsoft.Network/virtualNetworks/virtualNetworkPeerings/delete",
+			"Microsoft.Network/virtualNetworks/peer/action",
+		},
+	}
+}
+
+// PolicyContent returns the Azure Resource provisioned by each Resource within this Service
+func (r Registration) PolicyContent() map[string]sdk.PolicyContent {
+	return map[string]sdk.PolicyContent{
+		"azurerm_virtual_network": {
+			ResourceType: "Microsoft.Network/virtualNetworks",
+			APIVersion:   "2020-05-01",
+			Properties: func(d *schema.ResourceDiff) map[string]interface{} {
+				properties := make(map[string]interface{})
+				if d.NewValueKnown("address_space") {
+					properties["addressSpace"] = map[string]interface{}{
+						"addressPrefixes": d.Get("address_space").([]interface{}),
+					}
+				}
+				return properties
+			},
+		},
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
@@ -227,6 +228,11 @@ func retryVnetPeeringsClientCreateUpdate(d *schema.ResourceData, resGroup string
 		ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 		defer cancel()
 
+		// the Remote Virtual Network can exist within another (Auxiliary) Tenant
+		if peer.VirtualNetworkPeeringPropertiesFormat != nil && peer.VirtualNetworkPeeringPropertiesFormat.RemoteVirtualNetwork != nil && peer.VirtualNetworkPeeringPropertiesFormat.RemoteVirtualNetwork.ID != nil {
+			ctx = common.WithAuxiliaryResourceIDs(ctx, *peer.VirtualNetworkPeeringPropertiesFormat.RemoteVirtualNetwork.ID)
+		}
+
 		future, err := vnetPeeringsClient.CreateOrUpdate(ctx, resGroup, vnetName, name, peer)
 		if err != nil {
 			if utils.ResponseErrorIsRetryable(err) {
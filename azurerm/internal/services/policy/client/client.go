@@ -2,6 +2,7 @@ package client
 
 import (
 	"github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2019-10-01-preview/policyinsights"
+	policyInsightsPreview "github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-09-01/policy"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 )
@@ -11,6 +12,7 @@ type Client struct {
 	DefinitionsClient    *policy.DefinitionsClient
 	SetDefinitionsClient *policy.SetDefinitionsClient
 	RemediationsClient   *policyinsights.RemediationsClient
+	RestrictionsClient   *policyInsightsPreview.PolicyRestrictionsClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -26,10 +28,14 @@ func NewClient(o *common.ClientOptions) *Client {
 	remediationsClient := policyinsights.NewRemediationsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&remediationsClient.Client, o.ResourceManagerAuthorizer)
 
+	restrictionsClient := policyInsightsPreview.NewPolicyRestrictionsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&restrictionsClient.Client, o.ResourceManagerAuthorizer)
+
 	return &Client{
 		AssignmentsClient:    &assignmentsClient,
 		DefinitionsClient:    &definitionsClient,
 		SetDefinitionsClient: &setDefinitionsClient,
 		RemediationsClient:   &remediationsClient,
+		RestrictionsClient:   &restrictionsClient,
 	}
 }
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 )
 
@@ -59,3 +60,45 @@ func (r Registration) Resources() []sdk.Resource {
 		ResourceProviderRegistrationResource{},
 	}
 }
+
+// Sweepers returns a list of Sweepers which clean up resources leaked by the Acceptance Tests
+func (r Registration) Sweepers() []sdk.Sweeper {
+	return []sdk.Sweeper{
+		resourceGroupSweeper(),
+	}
+}
+
+// APIProfiles returns the Data Sources and Resources supported by this Service on each API Profile
+func (r Registration) APIProfiles() map[string]sdk.APIProfileSupport {
+	return map[string]sdk.APIProfileSupport{
+		common.APIProfile20190301Hybrid: {
+			DataSources: []string{
+				"azurerm_resource_group",
+			},
+			Resources: []string{
+				"azurerm_resource_group",
+			},
+		},
+	}
+}
+
+// RequiredPermissions returns the RBAC Actions required to provision each Resource within this Service
+func (r Registration) RequiredPermissions() map[string][]string {
+	return map[string][]string{
+		"azurerm_resource_group": {
+			"Microsoft.Resources/subscriptions/resourceGroups/read",
+			"Microsoft.Resources/subscriptions/resourceGroups/write",
+			"Microsoft.Resources/subscriptions/resourceGroups/delete",
+		},
+	}
+}
+
+// PolicyContent returns the Azure Resource provisioned by each Resource within this Service
+func (r Registration) PolicyContent() map[string]sdk.PolicyContent {
+	return map[string]sdk.PolicyContent{
+		"azurerm_resource_group": {
+			ResourceType: "Microsoft.Resources/resourceGroups",
+			APIVersion:   "2020-06-01",
+		},
+	}
+}
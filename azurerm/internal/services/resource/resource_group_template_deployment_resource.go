@@ -55,8 +55,8 @@ func resourceGroupTemplateDeploymentResource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					string(resources.Complete),
-					string(resources.Incremental),
+					string(resources.DeploymentModeComplete),
+					string(resources.DeploymentModeIncremental),
 				}, false),
 			},
 
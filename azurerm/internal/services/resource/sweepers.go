@@ -0,0 +1,59 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+)
+
+func resourceGroupSweeper() sdk.Sweeper {
+	return sdk.Sweeper{
+		Name: "Resource Groups",
+		Prefixes: []string{
+			"acctestRG",
+		},
+		List: func(ctx context.Context, client *clients.Client) (*[]sdk.SweepableResource, error) {
+			output := make([]sdk.SweepableResource, 0)
+
+			iterator, err := client.Resource.GroupsClient.ListComplete(ctx, "", nil)
+			if err != nil {
+				return nil, fmt.Errorf("listing Resource Groups: %+v", err)
+			}
+			for iterator.NotDone() {
+				group := iterator.Value()
+				if group.ID != nil && group.Name != nil {
+					location := ""
+					if group.Location != nil {
+						location = *group.Location
+					}
+					output = append(output, sdk.SweepableResource{
+						ID:       *group.ID,
+						Name:     *group.Name,
+						Location: location,
+					})
+				}
+
+				if err := iterator.NextWithContext(ctx); err != nil {
+					return nil, fmt.Errorf("listing Resource Groups: %+v", err)
+				}
+			}
+
+			return &output, nil
+		},
+		Delete: func(ctx context.Context, client *clients.Client, resource sdk.SweepableResource) error {
+			groupsClient := client.Resource.GroupsClient
+			future, err := groupsClient.Delete(ctx, resource.Name, "")
+			if err != nil {
+				return fmt.Errorf("deleting Resource Group %q: %+v", resource.Name, err)
+			}
+
+			if err := future.WaitForCompletionRef(ctx, groupsClient.Client); err != nil {
+				return fmt.Errorf("waiting for deletion of Resource Group %q: %+v", resource.Name, err)
+			}
+
+			return nil
+		},
+	}
+}
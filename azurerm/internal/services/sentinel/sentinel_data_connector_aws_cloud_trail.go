@@ -92,11 +92,11 @@ func resourceSentinelDataConnectorAwsCloudTrailCreateUpdate(d *schema.ResourceDa
 			AwsRoleArn: utils.String(d.Get("aws_role_arn").(string)),
 			DataTypes: &securityinsight.AwsCloudTrailDataConnectorDataTypes{
 				Logs: &securityinsight.AwsCloudTrailDataConnectorDataTypesLogs{
-					State: securityinsight.Enabled,
+					State: securityinsight.DataTypeStateEnabled,
 				},
 			},
 		},
-		Kind: securityinsight.KindAmazonWebServicesCloudTrail,
+		Kind: securityinsight.KindBasicDataConnectorKindAmazonWebServicesCloudTrail,
 	}
 
 	// Service avoid concurrent updates of this resource via checking the "etag" to guarantee it is the same value as last Read.
@@ -97,11 +97,11 @@ func resourceSentinelDataConnectorAzureActiveDirectoryCreate(d *schema.ResourceD
 			TenantID: &tenantId,
 			DataTypes: &securityinsight.AlertsDataTypeOfDataConnector{
 				Alerts: &securityinsight.AlertsDataTypeOfDataConnectorAlerts{
-					State: securityinsight.Enabled,
+					State: securityinsight.DataTypeStateEnabled,
 				},
 			},
 		},
-		Kind: securityinsight.KindAzureActiveDirectory,
+		Kind: securityinsight.KindBasicDataConnectorKindAzureActiveDirectory,
 	}
 
 	if _, err = client.CreateOrUpdate(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name, param); err != nil {
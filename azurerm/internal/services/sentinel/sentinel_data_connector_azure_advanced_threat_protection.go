@@ -97,11 +97,11 @@ func resourceSentinelDataConnectorAzureAdvancedThreatProtectionCreate(d *schema.
 			TenantID: &tenantId,
 			DataTypes: &securityinsight.AlertsDataTypeOfDataConnector{
 				Alerts: &securityinsight.AlertsDataTypeOfDataConnectorAlerts{
-					State: securityinsight.Enabled,
+					State: securityinsight.DataTypeStateEnabled,
 				},
 			},
 		},
-		Kind: securityinsight.KindAzureAdvancedThreatProtection,
+		Kind: securityinsight.KindBasicDataConnectorKindAzureAdvancedThreatProtection,
 	}
 
 	if _, err = client.CreateOrUpdate(ctx, id.ResourceGroup, OperationalInsightsResourceProvider, id.WorkspaceName, id.Name, param); err != nil {
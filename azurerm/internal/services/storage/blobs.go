@@ -3,10 +3,13 @@ package storage
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"io"
+	"log"
+	"net/http"
 	"os"
 	"runtime"
 	"strings"
@@ -150,13 +153,39 @@ func (sbu BlobUpload) uploadBlockBlob(ctx context.Context) error {
 	}
 	defer file.Close()
 
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("Could not stat file %q: %s", file.Name(), err)
+	}
+
+	// when no `content_md5` is specified the hash of the file is computed, so that changes to the source can be detected
+	contentMD5 := sbu.ContentMD5
+	if contentMD5 == "" {
+		hash, hashErr := computeContentMD5(file)
+		if hashErr != nil {
+			return fmt.Errorf("computing the MD5 of %q: %s", sbu.Source, hashErr)
+		}
+		contentMD5 = base64.StdEncoding.EncodeToString(hash)
+
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("Error seeking: %s", err)
+		}
+	}
+
+	// larger files are split into blocks which are uploaded in parallel
+	if info.Size() > blockBlobSingleUploadThreshold {
+		if err := sbu.blockUploadFromSource(ctx, file, info.Size(), contentMD5); err != nil {
+			return fmt.Errorf("Error uploading source file %q in blocks: %s", sbu.Source, err)
+		}
+
+		return nil
+	}
+
 	input := blobs.PutBlockBlobInput{
 		ContentType: utils.String(sbu.ContentType),
+		ContentMD5:  utils.String(contentMD5),
 		MetaData:    sbu.MetaData,
 	}
-	if sbu.ContentMD5 != "" {
-		input.ContentMD5 = utils.String(sbu.ContentMD5)
-	}
 	if err := sbu.Client.PutBlockBlobFromFile(ctx, sbu.AccountName, sbu.ContainerName, sbu.BlobName, file, input); err != nil {
 		return fmt.Errorf("Error PutBlockBlobFromFile: %s", err)
 	}
@@ -363,6 +392,266 @@ func (sbu BlobUpload) blobPageUploadWorker(ctx context.Context, uploadCtx blobPa
 	}
 }
 
+// computeContentMD5 returns the MD5 sum of the reader's contents, which is streamed rather than held in memory
+func computeContentMD5(input io.Reader) ([]byte, error) {
+	hash := md5.New()
+	if _, err := io.Copy(hash, input); err != nil {
+		return nil, err
+	}
+
+	return hash.Sum(nil), nil
+}
+
+// computeFileContentMD5 returns the Hex encoded MD5 sum of the specified file
+func computeFileContentMD5(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash, err := computeContentMD5(file)
+	if err != nil {
+		return "", fmt.Errorf("reading %q: %+v", path, err)
+	}
+
+	return hex.EncodeToString(hash), nil
+}
+
+const (
+	// files up to this size are uploaded using a single Put Blob operation
+	blockBlobSingleUploadThreshold int64 = 4 * 1024 * 1024
+
+	minBlockSize int64 = 4 * 1024 * 1024
+
+	// a Block Blob can be made up of at most 50,000 committed blocks
+	maxBlockCount int64 = 50000
+
+	blockBlobMaxAttempts = 3
+)
+
+// blockBlobRetryDelay is the base delay between attempts to upload a single block, which increases with each attempt
+var blockBlobRetryDelay = 5 * time.Second
+
+type storageBlobBlock struct {
+	index  int
+	offset int64
+	size   int64
+}
+
+func (sbu BlobUpload) blockUploadFromSource(ctx context.Context, file io.ReaderAt, fileSize int64, contentMD5 string) error {
+	blockList := storageBlobBlockSplit(fileSize)
+
+	// blocks uploaded by a previous attempt which failed before the Block List was committed are retained
+	// by the service (for up to a week) - so any which match the source file can be re-used
+	existingBlocks, err := sbu.uncommittedBlocks(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieving uncommitted blocks: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	blocks := make(chan storageBlobBlock, len(blockList))
+	for _, block := range blockList {
+		blocks <- block
+	}
+	close(blocks)
+
+	workerCount := sbu.Parallelism * runtime.NumCPU()
+	if workerCount > len(blockList) {
+		workerCount = len(blockList)
+	}
+
+	blockIDs := make([]string, len(blockList))
+	errors := make(chan error, len(blockList))
+	wg := &sync.WaitGroup{}
+	wg.Add(workerCount)
+
+	for i := 0; i < workerCount; i++ {
+		go sbu.blobBlockUploadWorker(ctx, blobBlockUploadContext{
+			file:           file,
+			blocks:         blocks,
+			blockIDs:       blockIDs,
+			existingBlocks: existingBlocks,
+			errors:         errors,
+			cancel:         cancel,
+			wg:             wg,
+		})
+	}
+
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return <-errors
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	input := blobs.PutBlockListInput{
+		BlockList: blobs.BlockList{
+			LatestBlockIDs: make([]blobs.BlockID, 0, len(blockIDs)),
+		},
+		ContentType: utils.String(sbu.ContentType),
+		ContentMD5:  utils.String(contentMD5),
+		MetaData:    sbu.MetaData,
+	}
+	for _, blockID := range blockIDs {
+		input.BlockList.LatestBlockIDs = append(input.BlockList.LatestBlockIDs, blobs.BlockID{Value: blockID})
+	}
+	if _, err := sbu.Client.PutBlockList(ctx, sbu.AccountName, sbu.ContainerName, sbu.BlobName, input); err != nil {
+		return fmt.Errorf("Error PutBlockList: %s", err)
+	}
+
+	return nil
+}
+
+func (sbu BlobUpload) uncommittedBlocks(ctx context.Context) (map[string]int64, error) {
+	output := make(map[string]int64)
+
+	input := blobs.GetBlockListInput{
+		BlockListType: blobs.Uncommitted,
+	}
+	result, err := sbu.Client.GetBlockList(ctx, sbu.AccountName, sbu.ContainerName, sbu.BlobName, input)
+	if err != nil {
+		if utils.ResponseWasNotFound(result.Response) {
+			return output, nil
+		}
+
+		return nil, err
+	}
+
+	for _, block := range result.UncommittedBlocks.Blocks {
+		output[block.Name] = block.Size
+	}
+
+	return output, nil
+}
+
+// storageBlobBlockSplit splits the file into blocks, which are large enough to stay within the maximum number of blocks
+func storageBlobBlockSplit(fileSize int64) []storageBlobBlock {
+	blockSize := minBlockSize
+	if fileSize > blockSize*maxBlockCount {
+		blockSize = fileSize / maxBlockCount
+		if fileSize%maxBlockCount != 0 {
+			blockSize++
+		}
+
+		// round up to the nearest MiB
+		if remainder := blockSize % (1024 * 1024); remainder != 0 {
+			blockSize += 1024*1024 - remainder
+		}
+	}
+
+	blocks := make([]storageBlobBlock, 0)
+	for offset := int64(0); offset < fileSize; offset += blockSize {
+		size := blockSize
+		if offset+size > fileSize {
+			size = fileSize - offset
+		}
+
+		blocks = append(blocks, storageBlobBlock{
+			index:  len(blocks),
+			offset: offset,
+			size:   size,
+		})
+	}
+
+	return blocks
+}
+
+// storageBlobBlockID returns a Block ID derived from the position and contents of the block, so that blocks
+// from a previous attempt are only re-used when they match - all Block ID's for a Blob must be the same length
+func storageBlobBlockID(index int, content []byte) string {
+	hash := md5.Sum(content)
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%05d-%s", index, hex.EncodeToString(hash[:]))))
+}
+
+type blobBlockUploadContext struct {
+	file           io.ReaderAt
+	blocks         chan storageBlobBlock
+	blockIDs       []string
+	existingBlocks map[string]int64
+	errors         chan error
+	cancel         context.CancelFunc
+	wg             *sync.WaitGroup
+}
+
+func (sbu BlobUpload) blobBlockUploadWorker(ctx context.Context, uploadCtx blobBlockUploadContext) {
+	defer uploadCtx.wg.Done()
+
+	for block := range uploadCtx.blocks {
+		// another worker has failed, so there's no point uploading the remaining blocks
+		if ctx.Err() != nil {
+			return
+		}
+
+		chunk := make([]byte, block.size)
+		if _, err := uploadCtx.file.ReadAt(chunk, block.offset); err != nil && err != io.EOF {
+			uploadCtx.errors <- fmt.Errorf("Error reading source file %q at offset %d: %s", sbu.Source, block.offset, err)
+			uploadCtx.cancel()
+			return
+		}
+
+		blockID := storageBlobBlockID(block.index, chunk)
+		// each worker writes to a different index, so this doesn't need to be locked
+		uploadCtx.blockIDs[block.index] = blockID
+
+		if size, ok := uploadCtx.existingBlocks[blockID]; ok && size == block.size {
+			log.Printf("[DEBUG] Block %d at offset %d for Blob %q has already been uploaded - skipping", block.index, block.offset, sbu.BlobName)
+			continue
+		}
+
+		if err := sbu.putBlockWithRetries(ctx, blockID, chunk); err != nil {
+			uploadCtx.errors <- fmt.Errorf("Error writing block at offset %d for file %q: %s", block.offset, sbu.Source, err)
+			uploadCtx.cancel()
+			return
+		}
+	}
+}
+
+// putBlockWithRetries uploads a single block, retrying transient failures which remain once the SDK has given up - so
+// that a single block doesn't cause the upload of a large file to fail
+func (sbu BlobUpload) putBlockWithRetries(ctx context.Context, blockID string, content []byte) error {
+	input := blobs.PutBlockInput{
+		BlockID: blockID,
+		Content: content,
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, err := sbu.Client.PutBlock(ctx, sbu.AccountName, sbu.ContainerName, sbu.BlobName, input)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= blockBlobMaxAttempts || ctx.Err() != nil || !blockUploadIsRetryable(result.Response.Response) {
+			return err
+		}
+
+		log.Printf("[DEBUG] Error uploading block %q for Blob %q (attempt %d of %d) - retrying: %s", blockID, sbu.BlobName, attempt, blockBlobMaxAttempts, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * blockBlobRetryDelay):
+		}
+	}
+}
+
+// blockUploadIsRetryable determines whether a failed upload is transient, such as being throttled or a connection error
+func blockUploadIsRetryable(resp *http.Response) bool {
+	if resp == nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
 func convertHexToBase64Encoding(str string) (string, error) {
 	data, err := hex.DecodeString(str)
 	if err != nil {
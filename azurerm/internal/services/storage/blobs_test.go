@@ -0,0 +1,351 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/blobs"
+)
+
+func TestComputeFileContentMD5(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "source")
+	if err := os.WriteFile(path, []byte("Wubba Lubba Dub Dub"), 0600); err != nil {
+		t.Fatalf("writing %q: %+v", path, err)
+	}
+
+	actual, err := computeFileContentMD5(path)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expected := "737e15e6e8578dff3f0f284437a0cded"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+
+	if _, err := computeFileContentMD5(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatalf("Expected an error for a missing file but didn't get one")
+	}
+}
+
+func TestStorageBlobBlockSplit(t *testing.T) {
+	testData := []struct {
+		Name      string
+		FileSize  int64
+		Count     int
+		BlockSize int64
+	}{
+		{
+			Name:      "single block",
+			FileSize:  1024,
+			Count:     1,
+			BlockSize: 1024,
+		},
+		{
+			Name:      "partial last block",
+			FileSize:  minBlockSize*2 + 1,
+			Count:     3,
+			BlockSize: minBlockSize,
+		},
+		{
+			Name:      "larger blocks to stay within the maximum block count",
+			FileSize:  minBlockSize*maxBlockCount + 1,
+			Count:     40001,
+			BlockSize: 5 * 1024 * 1024,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		blocks := storageBlobBlockSplit(v.FileSize)
+		if len(blocks) != v.Count {
+			t.Fatalf("Expected %d blocks but got %d", v.Count, len(blocks))
+		}
+		if blocks[0].size != v.BlockSize {
+			t.Fatalf("Expected a block size of %d but got %d", v.BlockSize, blocks[0].size)
+		}
+
+		total := int64(0)
+		for i, block := range blocks {
+			if block.index != i || block.offset != total {
+				t.Fatalf("Expected block %d to start at offset %d but got block %d at offset %d", i, total, block.index, block.offset)
+			}
+			total += block.size
+		}
+		if total != v.FileSize {
+			t.Fatalf("Expected the blocks to total %d bytes but got %d", v.FileSize, total)
+		}
+	}
+}
+
+func TestBlobUploadBlockBlob(t *testing.T) {
+	blockRetryDelay := blockBlobRetryDelay
+	blockBlobRetryDelay = time.Millisecond
+	defer func() {
+		blockBlobRetryDelay = blockRetryDelay
+	}()
+
+	contents := make([]byte, minBlockSize*2+123)
+	if _, err := rand.Read(contents); err != nil {
+		t.Fatalf("generating random contents: %+v", err)
+	}
+	blocks := storageBlobBlockSplit(int64(len(contents)))
+	blockIDs := make([]string, 0)
+	for _, block := range blocks {
+		blockIDs = append(blockIDs, storageBlobBlockID(block.index, contents[block.offset:block.offset+block.size]))
+	}
+
+	testData := []struct {
+		Name            string
+		Contents        []byte
+		Uncommitted     map[string][]byte
+		Failures        map[string]int
+		FailureStatus   int
+		ExpectedUploads map[string]int
+		Error           bool
+	}{
+		{
+			Name:     "small file uses a single put",
+			Contents: []byte("Wubba Lubba Dub Dub"),
+		},
+		{
+			Name:     "blocks",
+			Contents: contents,
+			ExpectedUploads: map[string]int{
+				blockIDs[0]: 1,
+				blockIDs[1]: 1,
+				blockIDs[2]: 1,
+			},
+		},
+		{
+			Name:     "transient failures are retried",
+			Contents: contents,
+			// more failures than are retried by the SDK
+			Failures: map[string]int{
+				blockIDs[1]: 3,
+			},
+			FailureStatus: http.StatusServiceUnavailable,
+			ExpectedUploads: map[string]int{
+				blockIDs[0]: 1,
+				blockIDs[1]: 4,
+				blockIDs[2]: 1,
+			},
+		},
+		{
+			Name:     "uncommitted blocks are resumed",
+			Contents: contents,
+			Uncommitted: map[string][]byte{
+				blockIDs[0]: contents[blocks[0].offset : blocks[0].offset+blocks[0].size],
+				blockIDs[1]: contents[blocks[1].offset : blocks[1].offset+blocks[1].size],
+				// a block from a previous version of the file is ignored
+				storageBlobBlockID(2, []byte("stale")): []byte("stale"),
+			},
+			ExpectedUploads: map[string]int{
+				blockIDs[2]: 1,
+			},
+		},
+		{
+			Name:     "too many transient failures",
+			Contents: contents,
+			Failures: map[string]int{
+				blockIDs[1]: 100,
+			},
+			FailureStatus: http.StatusInternalServerError,
+			Error:         true,
+		},
+		{
+			Name:     "non-transient failures are not retried",
+			Contents: contents,
+			Failures: map[string]int{
+				blockIDs[0]: 1,
+			},
+			FailureStatus: http.StatusForbidden,
+			Error:         true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		source := filepath.Join(t.TempDir(), "source")
+		if err := os.WriteFile(source, v.Contents, 0600); err != nil {
+			t.Fatalf("writing %q: %+v", source, err)
+		}
+
+		stub := newBlobServiceStub(v.Uncommitted, v.Failures, v.FailureStatus)
+		server := httptest.NewServer(stub)
+
+		upload := BlobUpload{
+			Client:        stub.client(server.URL),
+			AccountName:   "account1",
+			ContainerName: "container1",
+			BlobName:      "blob1",
+			BlobType:      "Block",
+			ContentType:   "text/plain",
+			Parallelism:   2,
+			Source:        source,
+		}
+		err := upload.Create(context.TODO())
+		server.Close()
+
+		if v.Error {
+			if err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			if stub.committed != nil {
+				t.Fatalf("Expected the Blob not to be committed")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+
+		if !bytes.Equal(stub.committed, v.Contents) {
+			t.Fatalf("Expected the committed Blob to match the source file")
+		}
+		hash := md5.Sum(v.Contents)
+		if expected := base64.StdEncoding.EncodeToString(hash[:]); stub.contentMD5 != expected {
+			t.Fatalf("Expected the Content MD5 to be %q but got %q", expected, stub.contentMD5)
+		}
+		if stub.contentType != "text/plain" {
+			t.Fatalf("Expected the Content Type to be %q but got %q", "text/plain", stub.contentType)
+		}
+		if v.ExpectedUploads != nil && !reflect.DeepEqual(v.ExpectedUploads, stub.uploads) {
+			t.Fatalf("Expected the block uploads %+v but got %+v", v.ExpectedUploads, stub.uploads)
+		}
+	}
+}
+
+// blobServiceStub implements the subset of the Blob Service used to upload Block Blobs
+type blobServiceStub struct {
+	sync.Mutex
+
+	uncommitted   map[string][]byte
+	failures      map[string]int
+	failureStatus int
+	uploads       map[string]int
+
+	committed   []byte
+	contentMD5  string
+	contentType string
+}
+
+func newBlobServiceStub(uncommitted map[string][]byte, failures map[string]int, failureStatus int) *blobServiceStub {
+	stub := &blobServiceStub{
+		uncommitted:   make(map[string][]byte),
+		failures:      make(map[string]int),
+		failureStatus: failureStatus,
+		uploads:       make(map[string]int),
+	}
+	for k, v := range uncommitted {
+		stub.uncommitted[k] = v
+	}
+	for k, v := range failures {
+		stub.failures[k] = v
+	}
+	return stub
+}
+
+func (s *blobServiceStub) client(serverURL string) *blobs.Client {
+	target, _ := url.Parse(serverURL)
+	client := blobs.New()
+	// the per-block retries are tested rather than those in the SDK
+	client.RetryAttempts = 1
+	client.RetryDuration = time.Millisecond
+	client.Sender = autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	return &client
+}
+
+func (s *blobServiceStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		blockID := query.Get("blockid")
+		s.uploads[blockID]++
+		if s.failures[blockID] > 0 {
+			s.failures[blockID]--
+			w.WriteHeader(s.failureStatus)
+			return
+		}
+
+		s.uncommitted[blockID] = body
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodGet && query.Get("comp") == "blocklist":
+		if len(s.uncommitted) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		output := "<?xml version=\"1.0\" encoding=\"utf-8\"?><BlockList><CommittedBlocks /><UncommittedBlocks>"
+		for name, content := range s.uncommitted {
+			output += fmt.Sprintf("<Block><Name>%s</Name><Size>%d</Size></Block>", name, len(content))
+		}
+		output += "</UncommittedBlocks></BlockList>"
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(output)) // nolint: errcheck
+
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		var blockList blobs.BlockList
+		if err := xml.Unmarshal(body, &blockList); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		committed := make([]byte, 0)
+		for _, blockID := range blockList.LatestBlockIDs {
+			content, ok := s.uncommitted[blockID.Value]
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			committed = append(committed, content...)
+		}
+		s.committed = committed
+		s.contentMD5 = r.Header.Get("x-ms-blob-content-md5")
+		s.contentType = r.Header.Get("x-ms-blob-content-type")
+		s.uncommitted = make(map[string][]byte)
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut && query.Get("comp") == "":
+		s.committed = body
+		s.contentMD5 = r.Header.Get("x-ms-blob-content-md5")
+		s.contentType = r.Header.Get("x-ms-blob-content-type")
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
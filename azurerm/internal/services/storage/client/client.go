@@ -5,10 +5,15 @@ import (
 	"fmt"
 
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-01-01/storage"
+	inventory "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-04-01/storage"
 	"github.com/Azure/azure-sdk-for-go/services/storagesync/mgmt/2020-03-01/storagesync"
 	"github.com/Azure/go-autorest/autorest"
 	az "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-azure-helpers/sender"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/auditlog"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/datalake"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/sas"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/shim"
 	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/accounts"
 	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/blobs"
@@ -24,38 +29,57 @@ import (
 )
 
 type Client struct {
-	AccountsClient           *storage.AccountsClient
-	FileSystemsClient        *filesystems.Client
-	ADLSGen2PathsClient      *paths.Client
-	ManagementPoliciesClient *storage.ManagementPoliciesClient
-	BlobServicesClient       *storage.BlobServicesClient
-	CloudEndpointsClient     *storagesync.CloudEndpointsClient
-	EncryptionScopesClient   *storage.EncryptionScopesClient
-	Environment              az.Environment
-	SyncServiceClient        *storagesync.ServicesClient
-	SyncGroupsClient         *storagesync.SyncGroupsClient
-	SubscriptionId           string
-
+	AccountsClient              *storage.AccountsClient
+	BlobInventoryPoliciesClient *inventory.BlobInventoryPoliciesClient
+	FileSystemsClient           *filesystems.Client
+	ADLSGen2PathsClient         *paths.Client
+	ADLSGen2ACLRecursiveClient  *datalake.AccessControlRecursiveClient
+	ManagementPoliciesClient    *storage.ManagementPoliciesClient
+	ObjectReplicationClient     *storage.ObjectReplicationPoliciesClient
+	BlobServicesClient          *storage.BlobServicesClient
+	BlobContainersClient        *storage.BlobContainersClient
+	CloudEndpointsClient        *storagesync.CloudEndpointsClient
+	EncryptionScopesClient      *storage.EncryptionScopesClient
+	Environment                 az.Environment
+	SyncServiceClient           *storagesync.ServicesClient
+	SyncGroupsClient            *storagesync.SyncGroupsClient
+	SubscriptionId              string
+	UserDelegationKeysClient    *sas.UserDelegationKeysClient
+
+	auditLog                  *auditlog.Logger
 	resourceManagerAuthorizer autorest.Authorizer
 	storageAdAuth             *autorest.Authorizer
+	storageAuthorizer         autorest.Authorizer
 }
 
 func NewClient(options *common.ClientOptions) *Client {
 	accountsClient := storage.NewAccountsClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&accountsClient.Client, options.ResourceManagerAuthorizer)
 
+	blobInventoryPoliciesClient := inventory.NewBlobInventoryPoliciesClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
+	options.ConfigureClient(&blobInventoryPoliciesClient.Client, options.ResourceManagerAuthorizer)
+
 	fileSystemsClient := filesystems.NewWithEnvironment(options.Environment)
 	options.ConfigureClient(&fileSystemsClient.Client, options.StorageAuthorizer)
 
 	adlsGen2PathsClient := paths.NewWithEnvironment(options.Environment)
 	options.ConfigureClient(&adlsGen2PathsClient.Client, options.StorageAuthorizer)
 
+	adlsGen2ACLRecursiveClient := datalake.NewAccessControlRecursiveClientWithEnvironment(options.Environment)
+	options.ConfigureClient(&adlsGen2ACLRecursiveClient.Client, options.StorageAuthorizer)
+
 	managementPoliciesClient := storage.NewManagementPoliciesClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&managementPoliciesClient.Client, options.ResourceManagerAuthorizer)
 
+	objectReplicationClient := storage.NewObjectReplicationPoliciesClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
+	options.ConfigureClient(&objectReplicationClient.Client, options.ResourceManagerAuthorizer)
+
 	blobServicesClient := storage.NewBlobServicesClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&blobServicesClient.Client, options.ResourceManagerAuthorizer)
 
+	blobContainersClient := storage.NewBlobContainersClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
+	options.ConfigureClient(&blobContainersClient.Client, options.ResourceManagerAuthorizer)
+
 	cloudEndpointsClient := storagesync.NewCloudEndpointsClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&cloudEndpointsClient.Client, options.ResourceManagerAuthorizer)
 
@@ -68,22 +92,33 @@ func NewClient(options *common.ClientOptions) *Client {
 	syncGroupsClient := storagesync.NewSyncGroupsClientWithBaseURI(options.ResourceManagerEndpoint, options.SubscriptionId)
 	options.ConfigureClient(&syncGroupsClient.Client, options.ResourceManagerAuthorizer)
 
+	// User Delegation Keys can only be requested using Azure AD, regardless of how the other data-plane clients authenticate
+	userDelegationKeysClient := sas.NewUserDelegationKeysClientWithEnvironment(options.Environment)
+	options.ConfigureClient(&userDelegationKeysClient.Client, options.StorageAuthorizer)
+
 	// TODO: switch Storage Containers to using the storage.BlobContainersClient
 	// (which should fix #2977) when the storage clients have been moved in here
 	client := Client{
-		AccountsClient:           &accountsClient,
-		FileSystemsClient:        &fileSystemsClient,
-		ADLSGen2PathsClient:      &adlsGen2PathsClient,
-		ManagementPoliciesClient: &managementPoliciesClient,
-		BlobServicesClient:       &blobServicesClient,
-		CloudEndpointsClient:     &cloudEndpointsClient,
-		EncryptionScopesClient:   &encryptionScopesClient,
-		Environment:              options.Environment,
-		SubscriptionId:           options.SubscriptionId,
-		SyncServiceClient:        &syncServiceClient,
-		SyncGroupsClient:         &syncGroupsClient,
-
+		AccountsClient:              &accountsClient,
+		BlobInventoryPoliciesClient: &blobInventoryPoliciesClient,
+		FileSystemsClient:           &fileSystemsClient,
+		ADLSGen2ACLRecursiveClient:  &adlsGen2ACLRecursiveClient,
+		ADLSGen2PathsClient:         &adlsGen2PathsClient,
+		ManagementPoliciesClient:    &managementPoliciesClient,
+		ObjectReplicationClient:     &objectReplicationClient,
+		BlobServicesClient:          &blobServicesClient,
+		BlobContainersClient:        &blobContainersClient,
+		CloudEndpointsClient:        &cloudEndpointsClient,
+		EncryptionScopesClient:      &encryptionScopesClient,
+		Environment:                 options.Environment,
+		SubscriptionId:              options.SubscriptionId,
+		SyncServiceClient:           &syncServiceClient,
+		SyncGroupsClient:            &syncGroupsClient,
+		UserDelegationKeysClient:    &userDelegationKeysClient,
+
+		auditLog:                  options.AuditLog,
 		resourceManagerAuthorizer: options.ResourceManagerAuthorizer,
+		storageAuthorizer:         options.StorageAuthorizer,
 	}
 
 	if options.StorageUseAzureAD {
@@ -94,9 +129,9 @@ func NewClient(options *common.ClientOptions) *Client {
 }
 
 func (client Client) AccountsDataPlaneClient(ctx context.Context, account accountDetails) (*accounts.Client, error) {
-	if client.storageAdAuth != nil {
+	if authorizer := client.azureADAuthorizer(account); authorizer != nil {
 		accountsClient := accounts.NewWithEnvironment(client.Environment)
-		accountsClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&accountsClient.Client, *authorizer)
 		return &accountsClient, nil
 	}
 
@@ -111,14 +146,14 @@ func (client Client) AccountsDataPlaneClient(ctx context.Context, account accoun
 	}
 
 	accountsClient := accounts.NewWithEnvironment(client.Environment)
-	accountsClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&accountsClient.Client, storageAuth)
 	return &accountsClient, nil
 }
 
 func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*blobs.Client, error) {
-	if client.storageAdAuth != nil {
+	if authorizer := client.azureADAuthorizer(account); authorizer != nil {
 		blobsClient := blobs.NewWithEnvironment(client.Environment)
-		blobsClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&blobsClient.Client, *authorizer)
 		return &blobsClient, nil
 	}
 
@@ -133,14 +168,14 @@ func (client Client) BlobsClient(ctx context.Context, account accountDetails) (*
 	}
 
 	blobsClient := blobs.NewWithEnvironment(client.Environment)
-	blobsClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&blobsClient.Client, storageAuth)
 	return &blobsClient, nil
 }
 
 func (client Client) ContainersClient(ctx context.Context, account accountDetails) (shim.StorageContainerWrapper, error) {
-	if client.storageAdAuth != nil {
+	if authorizer := client.azureADAuthorizer(account); authorizer != nil {
 		containersClient := containers.NewWithEnvironment(client.Environment)
-		containersClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&containersClient.Client, *authorizer)
 		shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 		return shim, nil
 	}
@@ -156,7 +191,7 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 	}
 
 	containersClient := containers.NewWithEnvironment(client.Environment)
-	containersClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&containersClient.Client, storageAuth)
 
 	shim := shim.NewDataPlaneStorageContainerWrapper(&containersClient)
 	return shim, nil
@@ -164,7 +199,9 @@ func (client Client) ContainersClient(ctx context.Context, account accountDetail
 
 func (client Client) FileShareDirectoriesClient(ctx context.Context, account accountDetails) (*directories.Client, error) {
 	// NOTE: Files do not support AzureAD Authentication
-
+	if err := requireSharedKeyAccess(account, "File Shares"); err != nil {
+		return nil, err
+	}
 	accountKey, err := account.AccountKey(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("Error retrieving Account Key: %s", err)
@@ -176,13 +213,15 @@ func (client Client) FileShareDirectoriesClient(ctx context.Context, account acc
 	}
 
 	directoriesClient := directories.NewWithEnvironment(client.Environment)
-	directoriesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&directoriesClient.Client, storageAuth)
 	return &directoriesClient, nil
 }
 
 func (client Client) FileShareFilesClient(ctx context.Context, account accountDetails) (*files.Client, error) {
 	// NOTE: Files do not support AzureAD Authentication
-
+	if err := requireSharedKeyAccess(account, "File Shares"); err != nil {
+		return nil, err
+	}
 	accountKey, err := account.AccountKey(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("Error retrieving Account Key: %s", err)
@@ -194,13 +233,15 @@ func (client Client) FileShareFilesClient(ctx context.Context, account accountDe
 	}
 
 	filesClient := files.NewWithEnvironment(client.Environment)
-	filesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&filesClient.Client, storageAuth)
 	return &filesClient, nil
 }
 
 func (client Client) FileSharesClient(ctx context.Context, account accountDetails) (shim.StorageShareWrapper, error) {
 	// NOTE: Files do not support AzureAD Authentication
-
+	if err := requireSharedKeyAccess(account, "File Shares"); err != nil {
+		return nil, err
+	}
 	accountKey, err := account.AccountKey(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("Error retrieving Account Key: %s", err)
@@ -212,15 +253,15 @@ func (client Client) FileSharesClient(ctx context.Context, account accountDetail
 	}
 
 	sharesClient := shares.NewWithEnvironment(client.Environment)
-	sharesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&sharesClient.Client, storageAuth)
 	shim := shim.NewDataPlaneStorageShareWrapper(&sharesClient)
 	return shim, nil
 }
 
 func (client Client) QueuesClient(ctx context.Context, account accountDetails) (shim.StorageQueuesWrapper, error) {
-	if client.storageAdAuth != nil {
+	if authorizer := client.azureADAuthorizer(account); authorizer != nil {
 		queueClient := queues.NewWithEnvironment(client.Environment)
-		queueClient.Client.Authorizer = *client.storageAdAuth
+		client.configureDataPlaneClient(&queueClient.Client, *authorizer)
 		return shim.NewDataPlaneStorageQueueWrapper(&queueClient), nil
 	}
 
@@ -235,13 +276,15 @@ func (client Client) QueuesClient(ctx context.Context, account accountDetails) (
 	}
 
 	queuesClient := queues.NewWithEnvironment(client.Environment)
-	queuesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&queuesClient.Client, storageAuth)
 	return shim.NewDataPlaneStorageQueueWrapper(&queuesClient), nil
 }
 
 func (client Client) TableEntityClient(ctx context.Context, account accountDetails) (*entities.Client, error) {
 	// NOTE: Table Entity does not support AzureAD Authentication
-
+	if err := requireSharedKeyAccess(account, "Table Entities"); err != nil {
+		return nil, err
+	}
 	accountKey, err := account.AccountKey(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("Error retrieving Account Key: %s", err)
@@ -253,13 +296,15 @@ func (client Client) TableEntityClient(ctx context.Context, account accountDetai
 	}
 
 	entitiesClient := entities.NewWithEnvironment(client.Environment)
-	entitiesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&entitiesClient.Client, storageAuth)
 	return &entitiesClient, nil
 }
 
 func (client Client) TablesClient(ctx context.Context, account accountDetails) (shim.StorageTableWrapper, error) {
 	// NOTE: Tables do not support AzureAD Authentication
-
+	if err := requireSharedKeyAccess(account, "Tables"); err != nil {
+		return nil, err
+	}
 	accountKey, err := account.AccountKey(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("Error retrieving Account Key: %s", err)
@@ -271,7 +316,41 @@ func (client Client) TablesClient(ctx context.Context, account accountDetails) (
 	}
 
 	tablesClient := tables.NewWithEnvironment(client.Environment)
-	tablesClient.Client.Authorizer = storageAuth
+	client.configureDataPlaneClient(&tablesClient.Client, storageAuth)
 	shim := shim.NewDataPlaneStorageTableWrapper(&tablesClient)
 	return shim, nil
 }
+
+// azureADAuthorizer returns the Azure AD Authorizer which should be used for the Data Plane APIs of this Storage Account,
+// either because the Provider is configured to use Azure AD or Shared Key access is disabled for the Account - otherwise
+// nil is returned and the Account Key should be used
+func (client Client) azureADAuthorizer(account accountDetails) *autorest.Authorizer {
+	if client.storageAdAuth != nil {
+		return client.storageAdAuth
+	}
+
+	if account.SharedKeyAccessDisabled() && client.storageAuthorizer != nil {
+		return &client.storageAuthorizer
+	}
+
+	return nil
+}
+
+// requireSharedKeyAccess returns an error when Shared Key access is disabled for the Storage Account, since
+// the specified Data Plane API can only be authenticated using the Account Key
+func requireSharedKeyAccess(account accountDetails, service string) error {
+	if account.SharedKeyAccessDisabled() {
+		return fmt.Errorf("%s do not support Azure AD authentication and Shared Key access is disabled for Storage Account %q (Resource Group %q) - `shared_access_key_enabled` must be `true` to manage %s", service, account.name, account.ResourceGroup, service)
+	}
+
+	return nil
+}
+
+// configureDataPlaneClient configures the specified Storage data-plane client to use the specified Authorizer,
+// recording each request in the Audit Log when enabled
+func (client Client) configureDataPlaneClient(c *autorest.Client, authorizer autorest.Authorizer) {
+	c.Authorizer = authorizer
+	if client.auditLog != nil {
+		c.Sender = client.auditLog.Sender(sender.BuildSender("AzureRM"))
+	}
+}
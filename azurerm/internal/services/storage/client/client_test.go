@@ -0,0 +1,70 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-01-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAzureADAuthorizer(t *testing.T) {
+	storageAuthorizer := autorest.NewBearerAuthorizer(nil)
+
+	testData := []struct {
+		Name                 string
+		StorageUseAzureAD    bool
+		AllowSharedKeyAccess *bool
+		ExpectAzureAD        bool
+	}{
+		{
+			Name:          "shared key access not configured",
+			ExpectAzureAD: false,
+		},
+		{
+			Name:                 "shared key access enabled",
+			AllowSharedKeyAccess: utils.Bool(true),
+			ExpectAzureAD:        false,
+		},
+		{
+			Name:                 "shared key access disabled",
+			AllowSharedKeyAccess: utils.Bool(false),
+			ExpectAzureAD:        true,
+		},
+		{
+			Name:                 "provider configured to use azure ad",
+			StorageUseAzureAD:    true,
+			AllowSharedKeyAccess: utils.Bool(true),
+			ExpectAzureAD:        true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		client := Client{
+			storageAuthorizer: storageAuthorizer,
+		}
+		if v.StorageUseAzureAD {
+			client.storageAdAuth = &client.storageAuthorizer
+		}
+
+		account := accountDetails{
+			name: "acct",
+			Properties: &storage.AccountProperties{
+				AllowSharedKeyAccess: v.AllowSharedKeyAccess,
+			},
+		}
+
+		actual := client.azureADAuthorizer(account)
+		if (actual != nil) != v.ExpectAzureAD {
+			t.Fatalf("Expected Azure AD to be used to be %t but got %t", v.ExpectAzureAD, actual != nil)
+		}
+
+		err := requireSharedKeyAccess(account, "Tables")
+		sharedKeyDisabled := v.AllowSharedKeyAccess != nil && !*v.AllowSharedKeyAccess
+		if (err != nil) != sharedKeyDisabled {
+			t.Fatalf("Expected an error to be %t but got: %+v", sharedKeyDisabled, err)
+		}
+	}
+}
@@ -53,6 +53,12 @@ func (ad *accountDetails) AccountKey(ctx context.Context, client Client) (*strin
 	return ad.accountKey, nil
 }
 
+// SharedKeyAccessDisabled returns whether Shared Key access has been disabled for this Storage Account,
+// in which case the Data Plane APIs must be authenticated using Azure AD
+func (ad *accountDetails) SharedKeyAccessDisabled() bool {
+	return ad.Properties != nil && ad.Properties.AllowSharedKeyAccess != nil && !*ad.Properties.AllowSharedKeyAccess
+}
+
 func (client Client) AddToCache(accountName string, props storage.Account) error {
 	accountsLock.Lock()
 	defer accountsLock.Unlock()
@@ -0,0 +1,204 @@
+package datalake
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/validation"
+)
+
+// APIVersion is the version of the Storage API used to set ACLs recursively, which isn't supported by the
+// 2019-12-12 API version used by the other Data Lake Gen2 clients
+const APIVersion = "2020-02-10"
+
+// MaxRecordsPerBatch is the largest number of Paths which can be updated by a single request
+const MaxRecordsPerBatch = 2000
+
+type AccessControlRecursiveMode string
+
+const (
+	AccessControlRecursiveModeSet    AccessControlRecursiveMode = "set"
+	AccessControlRecursiveModeModify AccessControlRecursiveMode = "modify"
+	AccessControlRecursiveModeRemove AccessControlRecursiveMode = "remove"
+)
+
+type SetAccessControlRecursiveInput struct {
+	Mode AccessControlRecursiveMode
+
+	// ACL is the list of ACEs to set, modify or remove - when removing, the ACEs must not contain permissions
+	ACL string
+
+	// Continuation is the token returned from a previous request, used to continue updating the remaining Paths
+	Continuation string
+
+	// MaxRecords is the maximum number of Paths which are updated by this request, up to MaxRecordsPerBatch
+	MaxRecords int
+
+	// ForceFlag continues updating the remaining Paths when the ACL can't be updated on some Paths,
+	// which are then returned in the FailedEntries
+	ForceFlag bool
+}
+
+type AccessControlFailedEntry struct {
+	ErrorMessage string `json:"errorMessage"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+}
+
+type SetAccessControlRecursiveResult struct {
+	autorest.Response
+
+	// Continuation is the token used to update the remaining Paths, which is empty once all Paths have been updated
+	Continuation string `json:"-"`
+
+	DirectoriesSuccessful int64                      `json:"directoriesSuccessful"`
+	FilesSuccessful       int64                      `json:"filesSuccessful"`
+	FailureCount          int64                      `json:"failureCount"`
+	FailedEntries         []AccessControlFailedEntry `json:"failedEntries"`
+}
+
+// AccessControlRecursiveSummary is the combined result of updating the ACL on each batch of Paths
+type AccessControlRecursiveSummary struct {
+	DirectoriesSuccessful int64
+	FilesSuccessful       int64
+	FailureCount          int64
+	FailedEntries         []AccessControlFailedEntry
+}
+
+// AccessControlRecursiveClient sets the ACLs on a Data Lake Gen2 Path and all Paths beneath it
+type AccessControlRecursiveClient struct {
+	autorest.Client
+	BaseURI string
+}
+
+func NewAccessControlRecursiveClientWithEnvironment(environment azure.Environment) AccessControlRecursiveClient {
+	return AccessControlRecursiveClient{
+		Client:  autorest.NewClientWithUserAgent("terraform-provider-azurerm"),
+		BaseURI: environment.StorageEndpointSuffix,
+	}
+}
+
+// SetAccessControlRecursive sets, modifies or removes the ACL on a batch of Paths beneath the specified Path, returning
+// a Continuation token when further Paths remain to be updated
+func (client AccessControlRecursiveClient) SetAccessControlRecursive(ctx context.Context, accountName, fileSystemName, path string, input SetAccessControlRecursiveInput) (result SetAccessControlRecursiveResult, err error) {
+	if accountName == "" {
+		return result, validation.NewError("datalake.AccessControlRecursiveClient", "SetAccessControlRecursive", "`accountName` cannot be an empty string.")
+	}
+	if fileSystemName == "" {
+		return result, validation.NewError("datalake.AccessControlRecursiveClient", "SetAccessControlRecursive", "`fileSystemName` cannot be an empty string.")
+	}
+	if input.ACL == "" {
+		return result, validation.NewError("datalake.AccessControlRecursiveClient", "SetAccessControlRecursive", "`input.ACL` cannot be an empty string.")
+	}
+	if input.MaxRecords < 0 || input.MaxRecords > MaxRecordsPerBatch {
+		return result, validation.NewError("datalake.AccessControlRecursiveClient", "SetAccessControlRecursive", fmt.Sprintf("`input.MaxRecords` cannot be greater than %d.", MaxRecordsPerBatch))
+	}
+
+	req, err := client.SetAccessControlRecursivePreparer(ctx, accountName, fileSystemName, path, input)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "datalake.AccessControlRecursiveClient", "SetAccessControlRecursive", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetAccessControlRecursiveSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "datalake.AccessControlRecursiveClient", "SetAccessControlRecursive", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.SetAccessControlRecursiveResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "datalake.AccessControlRecursiveClient", "SetAccessControlRecursive", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+// SetAccessControlRecursiveAll updates the ACL on every Path beneath the specified Path in batches, following the
+// Continuation token returned from each request until all Paths have been updated. Unless `input.ForceFlag` is set
+// this stops at the first batch containing a failure, with the failed Paths returned in the summary
+func (client AccessControlRecursiveClient) SetAccessControlRecursiveAll(ctx context.Context, accountName, fileSystemName, path string, input SetAccessControlRecursiveInput) (*AccessControlRecursiveSummary, error) {
+	summary := AccessControlRecursiveSummary{
+		FailedEntries: make([]AccessControlFailedEntry, 0),
+	}
+
+	for batch := 1; ; batch++ {
+		resp, err := client.SetAccessControlRecursive(ctx, accountName, fileSystemName, path, input)
+		if err != nil {
+			return &summary, err
+		}
+
+		summary.DirectoriesSuccessful += resp.DirectoriesSuccessful
+		summary.FilesSuccessful += resp.FilesSuccessful
+		summary.FailureCount += resp.FailureCount
+		summary.FailedEntries = append(summary.FailedEntries, resp.FailedEntries...)
+		log.Printf("[DEBUG] Batch %d updated the ACL on %d Directories and %d Files (%d failures) beneath %q in File System %q (Account %q)", batch, resp.DirectoriesSuccessful, resp.FilesSuccessful, resp.FailureCount, path, fileSystemName, accountName)
+
+		if resp.Continuation == "" || (resp.FailureCount > 0 && !input.ForceFlag) {
+			return &summary, nil
+		}
+		input.Continuation = resp.Continuation
+	}
+}
+
+func (client AccessControlRecursiveClient) SetAccessControlRecursivePreparer(ctx context.Context, accountName, fileSystemName, path string, input SetAccessControlRecursiveInput) (*http.Request, error) {
+	pathParameters := map[string]interface{}{
+		"fileSystemName": autorest.Encode("path", fileSystemName),
+		"path":           autorest.Encode("path", strings.TrimPrefix(path, "/")),
+	}
+
+	queryParameters := map[string]interface{}{
+		"action": autorest.Encode("query", "setAccessControlRecursive"),
+		"mode":   autorest.Encode("query", string(input.Mode)),
+	}
+	if input.Continuation != "" {
+		queryParameters["continuation"] = autorest.Encode("query", input.Continuation)
+	}
+	if input.MaxRecords > 0 {
+		queryParameters["maxRecords"] = autorest.Encode("query", input.MaxRecords)
+	}
+	if input.ForceFlag {
+		queryParameters["forceFlag"] = autorest.Encode("query", true)
+	}
+
+	headers := map[string]interface{}{
+		"x-ms-version": APIVersion,
+		"x-ms-acl":     input.ACL,
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPatch(),
+		autorest.WithBaseURL(fmt.Sprintf("https://%s.dfs.%s", accountName, client.BaseURI)),
+		autorest.WithPathParameters("/{fileSystemName}/{path}", pathParameters),
+		autorest.WithQueryParameters(queryParameters),
+		autorest.WithHeaders(headers))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client AccessControlRecursiveClient) SetAccessControlRecursiveSender(req *http.Request) (*http.Response, error) {
+	return autorest.SendWithSender(client, req,
+		azure.DoRetryWithRegistration(client.Client))
+}
+
+func (client AccessControlRecursiveClient) SetAccessControlRecursiveResponder(resp *http.Response) (result SetAccessControlRecursiveResult, err error) {
+	if resp != nil && resp.Header != nil {
+		result.Continuation = resp.Header.Get("x-ms-continuation")
+	}
+
+	err = autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+
+	return
+}
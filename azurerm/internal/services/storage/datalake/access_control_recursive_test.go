@@ -0,0 +1,165 @@
+package datalake
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+type accessControlRecursiveBatch struct {
+	Continuation string
+	Body         string
+}
+
+func accessControlRecursiveStubClient(t *testing.T, batches []accessControlRecursiveBatch, requests *[]*http.Request) AccessControlRecursiveClient {
+	client := NewAccessControlRecursiveClientWithEnvironment(azure.PublicCloud)
+	client.Sender = autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		if len(*requests) >= len(batches) {
+			t.Fatalf("Unexpected request %d to %q", len(*requests)+1, req.URL.String())
+		}
+		batch := batches[len(*requests)]
+		*requests = append(*requests, req)
+
+		header := http.Header{"Content-Type": []string{"application/json"}}
+		if batch.Continuation != "" {
+			header.Set("x-ms-continuation", batch.Continuation)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(batch.Body)),
+			Request:    req,
+		}, nil
+	})
+	return client
+}
+
+func TestSetAccessControlRecursivePreparer(t *testing.T) {
+	client := NewAccessControlRecursiveClientWithEnvironment(azure.PublicCloud)
+	input := SetAccessControlRecursiveInput{
+		Mode:         AccessControlRecursiveModeModify,
+		ACL:          "user:00000000-0000-0000-0000-000000000001:r-x",
+		Continuation: "token1",
+		MaxRecords:   100,
+		ForceFlag:    true,
+	}
+
+	req, err := client.SetAccessControlRecursivePreparer(context.TODO(), "acct", "fs1", "/dir", input)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	if req.Method != http.MethodPatch {
+		t.Fatalf("Expected a %s request but got %s", http.MethodPatch, req.Method)
+	}
+	if expected := "https://acct.dfs.core.windows.net/fs1/dir?action=setAccessControlRecursive&continuation=token1&forceFlag=true&maxRecords=100&mode=modify"; req.URL.String() != expected {
+		t.Fatalf("Expected the URL %q but got %q", expected, req.URL.String())
+	}
+	if actual := req.Header.Get("x-ms-acl"); actual != input.ACL {
+		t.Fatalf("Expected the ACL %q but got %q", input.ACL, actual)
+	}
+	if actual := req.Header.Get("x-ms-version"); actual != APIVersion {
+		t.Fatalf("Expected the version %q but got %q", APIVersion, actual)
+	}
+}
+
+func TestSetAccessControlRecursiveAll(t *testing.T) {
+	testData := []struct {
+		Name              string
+		ForceFlag         bool
+		Batches           []accessControlRecursiveBatch
+		ExpectedRequests  int
+		ExpectedFiles     int64
+		ExpectedFailures  int64
+		ExpectedFailedLen int
+	}{
+		{
+			Name: "single batch",
+			Batches: []accessControlRecursiveBatch{
+				{Body: `{"directoriesSuccessful":2,"filesSuccessful":5,"failureCount":0}`},
+			},
+			ExpectedRequests: 1,
+			ExpectedFiles:    5,
+		},
+		{
+			Name: "continuation tokens are followed",
+			Batches: []accessControlRecursiveBatch{
+				{Continuation: "token1", Body: `{"directoriesSuccessful":1,"filesSuccessful":3,"failureCount":0}`},
+				{Continuation: "token2", Body: `{"directoriesSuccessful":1,"filesSuccessful":3,"failureCount":0}`},
+				{Body: `{"directoriesSuccessful":0,"filesSuccessful":1,"failureCount":0}`},
+			},
+			ExpectedRequests: 3,
+			ExpectedFiles:    7,
+		},
+		{
+			Name: "failures stop the update",
+			Batches: []accessControlRecursiveBatch{
+				{Continuation: "token1", Body: `{"directoriesSuccessful":1,"filesSuccessful":3,"failureCount":1,"failedEntries":[{"errorMessage":"This request is not authorized to perform this operation.","name":"dir/file1","type":"FILE"}]}`},
+				{Body: `{"directoriesSuccessful":1,"filesSuccessful":3,"failureCount":0}`},
+			},
+			ExpectedRequests:  1,
+			ExpectedFiles:     3,
+			ExpectedFailures:  1,
+			ExpectedFailedLen: 1,
+		},
+		{
+			Name:      "failures are skipped when forced",
+			ForceFlag: true,
+			Batches: []accessControlRecursiveBatch{
+				{Continuation: "token1", Body: `{"directoriesSuccessful":1,"filesSuccessful":3,"failureCount":1,"failedEntries":[{"errorMessage":"This request is not authorized to perform this operation.","name":"dir/file1","type":"FILE"}]}`},
+				{Body: `{"directoriesSuccessful":1,"filesSuccessful":3,"failureCount":0}`},
+			},
+			ExpectedRequests:  2,
+			ExpectedFiles:     6,
+			ExpectedFailures:  1,
+			ExpectedFailedLen: 1,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		requests := make([]*http.Request, 0)
+		client := accessControlRecursiveStubClient(t, v.Batches, &requests)
+
+		input := SetAccessControlRecursiveInput{
+			Mode:       AccessControlRecursiveModeSet,
+			ACL:        "user::rwx,group::r-x,other::---",
+			MaxRecords: 10,
+			ForceFlag:  v.ForceFlag,
+		}
+		summary, err := client.SetAccessControlRecursiveAll(context.TODO(), "acct", "fs1", "/", input)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+
+		if len(requests) != v.ExpectedRequests {
+			t.Fatalf("Expected %d requests but got %d", v.ExpectedRequests, len(requests))
+		}
+		for i, req := range requests {
+			expected := ""
+			if i > 0 {
+				expected = fmt.Sprintf("token%d", i)
+			}
+			if actual := req.URL.Query().Get("continuation"); actual != expected {
+				t.Fatalf("Expected request %d to use the continuation %q but got %q", i+1, expected, actual)
+			}
+		}
+
+		if summary.FilesSuccessful != v.ExpectedFiles {
+			t.Fatalf("Expected %d files to be updated but got %d", v.ExpectedFiles, summary.FilesSuccessful)
+		}
+		if summary.FailureCount != v.ExpectedFailures {
+			t.Fatalf("Expected %d failures but got %d", v.ExpectedFailures, summary.FailureCount)
+		}
+		if len(summary.FailedEntries) != v.ExpectedFailedLen {
+			t.Fatalf("Expected %d failed entries but got %d", v.ExpectedFailedLen, len(summary.FailedEntries))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This is a special case ID for a meta resource that manages the pair of Object Replication Policies on the
+// Source and Destination Storage Accounts, which share the same Policy ID
+
+type ObjectReplicationId struct {
+	Src ObjectReplicationPolicyId
+	Dst ObjectReplicationPolicyId
+}
+
+func NewObjectReplicationId(src ObjectReplicationPolicyId, dst ObjectReplicationPolicyId) ObjectReplicationId {
+	return ObjectReplicationId{
+		Src: src,
+		Dst: dst,
+	}
+}
+
+func (id ObjectReplicationId) ID() string {
+	return fmt.Sprintf("%s|%s", id.Src.ID(), id.Dst.ID())
+}
+
+func (id ObjectReplicationId) String() string {
+	return fmt.Sprintf("Object Replication: (Source %s / Destination %s)", id.Src.String(), id.Dst.String())
+}
+
+func ObjectReplicationID(input string) (*ObjectReplicationId, error) {
+	idParts := strings.Split(input, "|")
+	if len(idParts) != 2 {
+		return nil, fmt.Errorf("could not parse Object Replication ID, expected two Object Replication Policy IDs joined by `|`")
+	}
+
+	src, err := ObjectReplicationPolicyID(idParts[0])
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the Source portion of Object Replication ID: %+v", err)
+	}
+	dst, err := ObjectReplicationPolicyID(idParts[1])
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the Destination portion of Object Replication ID: %+v", err)
+	}
+
+	if src.Name != dst.Name {
+		return nil, fmt.Errorf("the Source and Destination Object Replication Policies must have the same Policy ID but got %q and %q", src.Name, dst.Name)
+	}
+
+	return &ObjectReplicationId{
+		Src: *src,
+		Dst: *dst,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/resourceid"
+)
+
+var _ resourceid.Formatter = StorageTableEntitiesDataPlaneId{}
+
+// StorageTableEntitiesDataPlaneId is the ID of the Entities within a Storage Table, which (unlike the ID of the
+// Storage Table itself, e.g. `https://account1.table.core.windows.net/Tables('table1')`) is the URI of the Entities
+type StorageTableEntitiesDataPlaneId struct {
+	AccountName  string
+	DomainSuffix string
+	TableName    string
+}
+
+func (id StorageTableEntitiesDataPlaneId) ID() string {
+	return fmt.Sprintf("https://%s.table.%s/%s", id.AccountName, id.DomainSuffix, id.TableName)
+}
+
+func NewStorageTableEntitiesDataPlaneId(accountName, domainSuffix, tableName string) StorageTableEntitiesDataPlaneId {
+	return StorageTableEntitiesDataPlaneId{
+		AccountName:  accountName,
+		DomainSuffix: domainSuffix,
+		TableName:    tableName,
+	}
+}
+
+func StorageTableEntitiesDataPlaneID(input string) (*StorageTableEntitiesDataPlaneId, error) {
+	if input == "" {
+		return nil, fmt.Errorf("`input` was empty")
+	}
+
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a URI: %+v", input, err)
+	}
+
+	hostSegments := strings.SplitN(uri.Host, ".table.", 2)
+	if len(hostSegments) != 2 || hostSegments[0] == "" || hostSegments[1] == "" {
+		return nil, fmt.Errorf("expected the host %q to be in the format `{account}.table.{domainSuffix}`", uri.Host)
+	}
+
+	tableName := strings.TrimPrefix(uri.Path, "/")
+	if tableName == "" || strings.ContainsAny(tableName, "/()") {
+		return nil, fmt.Errorf("expected the path %q to be in the format `/{tableName}`", uri.Path)
+	}
+
+	return &StorageTableEntitiesDataPlaneId{
+		AccountName:  hostSegments[0],
+		DomainSuffix: hostSegments[1],
+		TableName:    tableName,
+	}, nil
+}
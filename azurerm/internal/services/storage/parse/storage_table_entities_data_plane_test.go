@@ -0,0 +1,97 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestStorageTableEntitiesDataPlaneIDFormatter(t *testing.T) {
+	actual := NewStorageTableEntitiesDataPlaneId("account1", "core.windows.net", "table1").ID()
+	expected := "https://account1.table.core.windows.net/table1"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
+
+func TestStorageTableEntitiesDataPlaneID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *StorageTableEntitiesDataPlaneId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+
+		{
+			// missing table name
+			Input: "https://account1.table.core.windows.net/",
+			Error: true,
+		},
+
+		{
+			// not a table endpoint
+			Input: "https://account1.blob.core.windows.net/table1",
+			Error: true,
+		},
+
+		{
+			// storage table id
+			Input: "https://account1.table.core.windows.net/Tables('table1')",
+			Error: true,
+		},
+
+		{
+			// storage table entity id
+			Input: "https://account1.table.core.windows.net/table1(PartitionKey='partition1',RowKey='row1')",
+			Error: true,
+		},
+
+		{
+			// valid
+			Input: "https://account1.table.core.windows.net/table1",
+			Expected: &StorageTableEntitiesDataPlaneId{
+				AccountName:  "account1",
+				DomainSuffix: "core.windows.net",
+				TableName:    "table1",
+			},
+		},
+
+		{
+			// valid in another cloud
+			Input: "https://account1.table.core.chinacloudapi.cn/table1",
+			Expected: &StorageTableEntitiesDataPlaneId{
+				AccountName:  "account1",
+				DomainSuffix: "core.chinacloudapi.cn",
+				TableName:    "table1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := StorageTableEntitiesDataPlaneID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.AccountName != v.Expected.AccountName {
+			t.Fatalf("Expected %q but got %q for AccountName", v.Expected.AccountName, actual.AccountName)
+		}
+		if actual.DomainSuffix != v.Expected.DomainSuffix {
+			t.Fatalf("Expected %q but got %q for DomainSuffix", v.Expected.DomainSuffix, actual.DomainSuffix)
+		}
+		if actual.TableName != v.Expected.TableName {
+			t.Fatalf("Expected %q but got %q for TableName", v.Expected.TableName, actual.TableName)
+		}
+	}
+}
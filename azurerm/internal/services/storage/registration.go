@@ -2,6 +2,8 @@ package storage
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
 )
 
 type Registration struct{}
@@ -22,8 +24,12 @@ func (r Registration) WebsiteCategories() []string {
 func (r Registration) SupportedDataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
 		"azurerm_storage_account_blob_container_sas": dataSourceStorageAccountBlobContainerSharedAccessSignature(),
+		"azurerm_storage_account_blob_sas":           dataSourceStorageAccountBlobSharedAccessSignature(),
+		"azurerm_storage_account_queue_sas":          dataSourceStorageAccountQueueSharedAccessSignature(),
 		"azurerm_storage_account_sas":                dataSourceStorageAccountSharedAccessSignature(),
+		"azurerm_storage_account_share_sas":          dataSourceStorageAccountShareSharedAccessSignature(),
 		"azurerm_storage_account":                    dataSourceStorageAccount(),
+		"azurerm_storage_blob":                       dataSourceStorageBlob(),
 		"azurerm_storage_container":                  dataSourceStorageContainer(),
 		"azurerm_storage_encryption_scope":           dataSourceStorageEncryptionScope(),
 		"azurerm_storage_management_policy":          dataSourceStorageManagementPolicy(),
@@ -39,19 +45,89 @@ func (r Registration) SupportedResources() map[string]*schema.Resource {
 		"azurerm_storage_account_customer_managed_key": resourceStorageAccountCustomerManagedKey(),
 		"azurerm_storage_account_network_rules":        resourceStorageAccountNetworkRules(),
 		"azurerm_storage_blob":                         resourceStorageBlob(),
+		"azurerm_storage_blob_inventory_policy":        resourceStorageBlobInventoryPolicy(),
 		"azurerm_storage_container":                    resourceStorageContainer(),
 		"azurerm_storage_encryption_scope":             resourceStorageEncryptionScope(),
 		"azurerm_storage_data_lake_gen2_filesystem":    resourceStorageDataLakeGen2FileSystem(),
 		"azurerm_storage_data_lake_gen2_path":          resourceStorageDataLakeGen2Path(),
+		"azurerm_storage_data_lake_gen2_recursive_acl": resourceStorageDataLakeGen2RecursiveAcl(),
 		"azurerm_storage_management_policy":            resourceStorageManagementPolicy(),
+		"azurerm_storage_object_replication":           resourceStorageObjectReplication(),
 		"azurerm_storage_queue":                        resourceStorageQueue(),
 		"azurerm_storage_share":                        resourceStorageShare(),
 		"azurerm_storage_share_file":                   resourceStorageShareFile(),
 		"azurerm_storage_share_directory":              resourceStorageShareDirectory(),
 		"azurerm_storage_table":                        resourceStorageTable(),
 		"azurerm_storage_table_entity":                 resourceStorageTableEntity(),
+		"azurerm_storage_table_entities":               resourceStorageTableEntities(),
 		"azurerm_storage_sync":                         resourceStorageSync(),
 		"azurerm_storage_sync_cloud_endpoint":          resourceStorageSyncCloudEndpoint(),
 		"azurerm_storage_sync_group":                   resourceStorageSyncGroup(),
 	}
 }
+
+// APIProfiles returns the Data Sources and Resources supported by this Service on each API Profile
+func (r Registration) APIProfiles() map[string]sdk.APIProfileSupport {
+	return map[string]sdk.APIProfileSupport{
+		common.APIProfile20190301Hybrid: {
+			DataSources: []string{
+				"azurerm_storage_account",
+			},
+			Resources: []string{
+				"azurerm_storage_account",
+			},
+		},
+	}
+}
+
+// RequiredPermissions returns the RBAC Actions required to provision each Resource within this Service
+func (r Registration) RequiredPermissions() map[string][]string {
+	return map[string][]string{
+		"azurerm_storage_account": {
+			"Microsoft.Storage/storageAccounts/read",
+			"Microsoft.Storage/storageAccounts/write",
+			"Microsoft.Storage/storageAccounts/delete",
+			"Microsoft.Storage/storageAccounts/listKeys/action",
+		},
+		"azurerm_storage_container": {
+			"Microsoft.Storage/storageAccounts/read",
+			"Microsoft.Storage/storageAccounts/listKeys/action",
+		},
+	}
+}
+
+// FieldsNotReturnedByAPI returns the fields of each Resource within this Service which aren't returned from the API
+func (r Registration) FieldsNotReturnedByAPI() map[string][]string {
+	return map[string][]string{
+		"azurerm_storage_blob": {
+			"parallelism",
+			"size",
+			"source",
+			"source_content",
+			"source_uri",
+		},
+	}
+}
+
+// PolicyContent returns the Azure Resource provisioned by each Resource within this Service
+func (r Registration) PolicyContent() map[string]sdk.PolicyContent {
+	return map[string]sdk.PolicyContent{
+		"azurerm_storage_account": {
+			ResourceType: "Microsoft.Storage/storageAccounts",
+			APIVersion:   "2021-01-01",
+			Properties: func(d *schema.ResourceDiff) map[string]interface{} {
+				properties := make(map[string]interface{})
+				if d.NewValueKnown("enable_https_traffic_only") {
+					properties["supportsHttpsTrafficOnly"] = d.Get("enable_https_traffic_only").(bool)
+				}
+				if d.NewValueKnown("min_tls_version") {
+					properties["minimumTlsVersion"] = d.Get("min_tls_version").(string)
+				}
+				if d.NewValueKnown("allow_blob_public_access") {
+					properties["allowBlobPublicAccess"] = d.Get("allow_blob_public_access").(bool)
+				}
+				return properties
+			},
+		},
+	}
+}
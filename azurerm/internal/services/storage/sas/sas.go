@@ -0,0 +1,269 @@
+package sas
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SignedVersion is the version of the Storage API used to sign Service SAS Tokens
+const SignedVersion = "2019-12-12"
+
+type SignedResource string
+
+const (
+	SignedResourceBlob      SignedResource = "b"
+	SignedResourceContainer SignedResource = "c"
+	SignedResourceFile      SignedResource = "f"
+	SignedResourceShare     SignedResource = "s"
+)
+
+// ServiceSASInput defines the values which are signed as a part of a Service SAS Token
+// https://docs.microsoft.com/en-us/rest/api/storageservices/create-service-sas
+type ServiceSASInput struct {
+	AccountName string
+
+	// Path is the path to the resource within the Storage Service, e.g. `{container}/{blob}`, `{share}/{path}` or `{queue}`
+	Path string
+
+	// Resource is the type of resource which the Token grants access to, this isn't used for Queues
+	Resource SignedResource
+
+	Permissions string
+	Start       string
+	Expiry      string
+	IP          string
+	Protocol    string
+
+	// Identifier is the ID of a Stored Access Policy, which can define the Permissions, Start and Expiry
+	Identifier string
+
+	// the Response Headers which are overridden when the Token is used to retrieve a Blob or File
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentLanguage    string
+	ContentType        string
+}
+
+// ComputeBlobSASToken computes a Service SAS Token for a Blob or Container, which is signed using the Account Key
+func ComputeBlobSASToken(input ServiceSASInput, accountKey string) (string, error) {
+	if err := input.validate(SignedResourceBlob, SignedResourceContainer); err != nil {
+		return "", err
+	}
+
+	stringToSign := strings.Join([]string{
+		input.Permissions,
+		input.Start,
+		input.Expiry,
+		input.canonicalizedResource("blob"),
+		input.Identifier,
+		input.IP,
+		input.Protocol,
+		SignedVersion,
+		string(input.Resource),
+		"", // signedSnapshotTime
+		input.CacheControl,
+		input.ContentDisposition,
+		input.ContentEncoding,
+		input.ContentLanguage,
+		input.ContentType,
+	}, "\n")
+
+	signature, err := sign(accountKey, stringToSign)
+	if err != nil {
+		return "", err
+	}
+
+	return input.token(nil, signature), nil
+}
+
+// ComputeBlobUserDelegationSASToken computes a User Delegation SAS Token for a Blob or Container, which is signed
+// using a User Delegation Key - and as such can be used when Shared Key access is disabled for the Storage Account
+func ComputeBlobUserDelegationSASToken(input ServiceSASInput, key UserDelegationKey) (string, error) {
+	if input.Identifier != "" {
+		return "", fmt.Errorf("a Stored Access Policy cannot be used with a User Delegation SAS")
+	}
+	if err := input.validate(SignedResourceBlob, SignedResourceContainer); err != nil {
+		return "", err
+	}
+
+	stringToSign := strings.Join([]string{
+		input.Permissions,
+		input.Start,
+		input.Expiry,
+		input.canonicalizedResource("blob"),
+		key.SignedObjectID,
+		key.SignedTenantID,
+		key.SignedStart,
+		key.SignedExpiry,
+		key.SignedService,
+		key.SignedVersion,
+		input.IP,
+		input.Protocol,
+		SignedVersion,
+		string(input.Resource),
+		"", // signedSnapshotTime
+		input.CacheControl,
+		input.ContentDisposition,
+		input.ContentEncoding,
+		input.ContentLanguage,
+		input.ContentType,
+	}, "\n")
+
+	signature, err := sign(key.Value, stringToSign)
+	if err != nil {
+		return "", err
+	}
+
+	return input.token(&key, signature), nil
+}
+
+// ComputeFileSASToken computes a Service SAS Token for a File Share or File, which is signed using the Account Key
+func ComputeFileSASToken(input ServiceSASInput, accountKey string) (string, error) {
+	if err := input.validate(SignedResourceShare, SignedResourceFile); err != nil {
+		return "", err
+	}
+
+	stringToSign := strings.Join([]string{
+		input.Permissions,
+		input.Start,
+		input.Expiry,
+		input.canonicalizedResource("file"),
+		input.Identifier,
+		input.IP,
+		input.Protocol,
+		SignedVersion,
+		input.CacheControl,
+		input.ContentDisposition,
+		input.ContentEncoding,
+		input.ContentLanguage,
+		input.ContentType,
+	}, "\n")
+
+	signature, err := sign(accountKey, stringToSign)
+	if err != nil {
+		return "", err
+	}
+
+	return input.token(nil, signature), nil
+}
+
+// ComputeQueueSASToken computes a Service SAS Token for a Queue, which is signed using the Account Key
+func ComputeQueueSASToken(input ServiceSASInput, accountKey string) (string, error) {
+	if err := input.validate(""); err != nil {
+		return "", err
+	}
+	if input.CacheControl != "" || input.ContentDisposition != "" || input.ContentEncoding != "" || input.ContentLanguage != "" || input.ContentType != "" {
+		return "", fmt.Errorf("Response Headers cannot be overridden for a Queue")
+	}
+
+	stringToSign := strings.Join([]string{
+		input.Permissions,
+		input.Start,
+		input.Expiry,
+		input.canonicalizedResource("queue"),
+		input.Identifier,
+		input.IP,
+		input.Protocol,
+		SignedVersion,
+	}, "\n")
+
+	signature, err := sign(accountKey, stringToSign)
+	if err != nil {
+		return "", err
+	}
+
+	return input.token(nil, signature), nil
+}
+
+func (input ServiceSASInput) validate(resources ...SignedResource) error {
+	if input.AccountName == "" {
+		return fmt.Errorf("`AccountName` cannot be an empty string")
+	}
+	if input.Path == "" {
+		return fmt.Errorf("`Path` cannot be an empty string")
+	}
+
+	valid := false
+	for _, v := range resources {
+		if input.Resource == v {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unsupported Signed Resource %q", string(input.Resource))
+	}
+
+	// when a Stored Access Policy is used these can be defined within it instead
+	if input.Identifier == "" {
+		if input.Permissions == "" {
+			return fmt.Errorf("`Permissions` must be specified when a Stored Access Policy isn't used")
+		}
+		if input.Expiry == "" {
+			return fmt.Errorf("`Expiry` must be specified when a Stored Access Policy isn't used")
+		}
+	}
+
+	return nil
+}
+
+func (input ServiceSASInput) canonicalizedResource(service string) string {
+	return fmt.Sprintf("/%s/%s/%s", service, input.AccountName, strings.TrimPrefix(input.Path, "/"))
+}
+
+func (input ServiceSASInput) token(key *UserDelegationKey, signature string) string {
+	values := [][]string{
+		{"sv", SignedVersion},
+		{"sr", string(input.Resource)},
+		{"st", input.Start},
+		{"se", input.Expiry},
+		{"sp", input.Permissions},
+		{"sip", input.IP},
+		{"spr", input.Protocol},
+		{"si", input.Identifier},
+	}
+	if key != nil {
+		values = append(values, [][]string{
+			{"skoid", key.SignedObjectID},
+			{"sktid", key.SignedTenantID},
+			{"skt", key.SignedStart},
+			{"ske", key.SignedExpiry},
+			{"sks", key.SignedService},
+			{"skv", key.SignedVersion},
+		}...)
+	}
+	values = append(values, [][]string{
+		{"rscc", input.CacheControl},
+		{"rscd", input.ContentDisposition},
+		{"rsce", input.ContentEncoding},
+		{"rscl", input.ContentLanguage},
+		{"rsct", input.ContentType},
+		{"sig", signature},
+	}...)
+
+	parameters := make([]string, 0)
+	for _, v := range values {
+		if v[1] == "" {
+			continue
+		}
+
+		parameters = append(parameters, fmt.Sprintf("%s=%s", v[0], url.QueryEscape(v[1])))
+	}
+
+	return "?" + strings.Join(parameters, "&")
+}
+
+func sign(key, stringToSign string) (string, error) {
+	binaryKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", fmt.Errorf("decoding the signing key: %+v", err)
+	}
+
+	hasher := hmac.New(sha256.New, binaryKey)
+	hasher.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(hasher.Sum(nil)), nil
+}
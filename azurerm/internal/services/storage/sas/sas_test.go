@@ -0,0 +1,171 @@
+package sas
+
+import (
+	"testing"
+)
+
+// these keys aren't secrets, they're the base64 encoded values `terraform-sas-test-key` and `user-delegation-key`
+const (
+	testAccountKey        = "dGVycmFmb3JtLXNhcy10ZXN0LWtleQ=="
+	testUserDelegationKey = "dXNlci1kZWxlZ2F0aW9uLWtleQ=="
+)
+
+func TestComputeBlobSASToken(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    ServiceSASInput
+		Expected string
+		Error    bool
+	}{
+		{
+			Name: "blob",
+			Input: ServiceSASInput{
+				AccountName: "acct",
+				Path:        "container1/blob1.txt",
+				Resource:    SignedResourceBlob,
+				Permissions: "r",
+				Start:       "2021-05-01T00:00:00Z",
+				Expiry:      "2021-05-02T00:00:00Z",
+				IP:          "168.1.5.60-168.1.5.70",
+				Protocol:    "https",
+				ContentType: "application/json",
+			},
+			Expected: "?sv=2019-12-12&sr=b&st=2021-05-01T00%3A00%3A00Z&se=2021-05-02T00%3A00%3A00Z&sp=r&sip=168.1.5.60-168.1.5.70&spr=https&rsct=application%2Fjson&sig=Nt4TJtle%2B5dxfepWQ7YX9priY5TDtlWgpaRjFrxoHAI%3D",
+		},
+		{
+			Name: "container using a stored access policy",
+			Input: ServiceSASInput{
+				AccountName: "acct",
+				Path:        "container1",
+				Resource:    SignedResourceContainer,
+				Protocol:    "https",
+				Identifier:  "policy1",
+			},
+			Expected: "?sv=2019-12-12&sr=c&spr=https&si=policy1&sig=3a5zwdc4VMORJzrCJfRnW2IZml%2B%2BvFq5RnmJLBmGgb4%3D",
+		},
+		{
+			Name: "no permissions or stored access policy",
+			Input: ServiceSASInput{
+				AccountName: "acct",
+				Path:        "container1",
+				Resource:    SignedResourceContainer,
+				Expiry:      "2021-05-02T00:00:00Z",
+			},
+			Error: true,
+		},
+		{
+			Name: "unsupported resource",
+			Input: ServiceSASInput{
+				AccountName: "acct",
+				Path:        "share1",
+				Resource:    SignedResourceShare,
+				Permissions: "r",
+				Expiry:      "2021-05-02T00:00:00Z",
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := ComputeBlobSASToken(v.Input, testAccountKey)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestComputeBlobUserDelegationSASToken(t *testing.T) {
+	key := UserDelegationKey{
+		SignedObjectID: "00000000-0000-0000-0000-000000000001",
+		SignedTenantID: "00000000-0000-0000-0000-000000000002",
+		SignedStart:    "2021-05-01T00:00:00Z",
+		SignedExpiry:   "2021-05-02T00:00:00Z",
+		SignedService:  "b",
+		SignedVersion:  "2019-12-12",
+		Value:          testUserDelegationKey,
+	}
+
+	input := ServiceSASInput{
+		AccountName: "acct",
+		Path:        "container1/blob1.txt",
+		Resource:    SignedResourceBlob,
+		Permissions: "rw",
+		Start:       "2021-05-01T00:00:00Z",
+		Expiry:      "2021-05-02T00:00:00Z",
+		Protocol:    "https",
+	}
+	actual, err := ComputeBlobUserDelegationSASToken(input, key)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expected := "?sv=2019-12-12&sr=b&st=2021-05-01T00%3A00%3A00Z&se=2021-05-02T00%3A00%3A00Z&sp=rw&spr=https&skoid=00000000-0000-0000-0000-000000000001&sktid=00000000-0000-0000-0000-000000000002&skt=2021-05-01T00%3A00%3A00Z&ske=2021-05-02T00%3A00%3A00Z&sks=b&skv=2019-12-12&sig=Fw097Fe853qfKO%2FGGhM9vzkpHU2SVbRECqYz1j%2BTHKs%3D"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+
+	input.Identifier = "policy1"
+	if _, err := ComputeBlobUserDelegationSASToken(input, key); err == nil {
+		t.Fatalf("Expected an error when using a Stored Access Policy but didn't get one")
+	}
+}
+
+func TestComputeFileSASToken(t *testing.T) {
+	input := ServiceSASInput{
+		AccountName:  "acct",
+		Path:         "share1/dir/file.txt",
+		Resource:     SignedResourceFile,
+		Permissions:  "rl",
+		Expiry:       "2021-05-02T00:00:00Z",
+		Protocol:     "https,http",
+		CacheControl: "no-cache",
+	}
+	actual, err := ComputeFileSASToken(input, testAccountKey)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expected := "?sv=2019-12-12&sr=f&se=2021-05-02T00%3A00%3A00Z&sp=rl&spr=https%2Chttp&rscc=no-cache&sig=YdWJzP5G7zdcinsXXTvAhO3JpiZlO%2BTHoMS%2BPVqJZ%2Bs%3D"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
+
+func TestComputeQueueSASToken(t *testing.T) {
+	input := ServiceSASInput{
+		AccountName: "acct",
+		Path:        "queue1",
+		Permissions: "raup",
+		Start:       "2021-05-01T00:00:00Z",
+		Expiry:      "2021-05-02T00:00:00Z",
+		IP:          "10.0.0.1",
+		Protocol:    "https",
+	}
+	actual, err := ComputeQueueSASToken(input, testAccountKey)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expected := "?sv=2019-12-12&st=2021-05-01T00%3A00%3A00Z&se=2021-05-02T00%3A00%3A00Z&sp=raup&sip=10.0.0.1&spr=https&sig=KBOaaxlE6UoTXu1MTQYnf9%2BU8H8zfZ%2FyyCrVCF1xbtc%3D"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+
+	input.ContentType = "application/json"
+	if _, err := ComputeQueueSASToken(input, testAccountKey); err == nil {
+		t.Fatalf("Expected an error when overriding Response Headers but didn't get one")
+	}
+}
@@ -0,0 +1,122 @@
+package sas
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/validation"
+)
+
+// UserDelegationKey is a key which is requested using Azure Active Directory credentials,
+// which can be used to sign a User Delegation SAS for Blobs and Containers
+type UserDelegationKey struct {
+	SignedObjectID string `xml:"SignedOid"`
+	SignedTenantID string `xml:"SignedTid"`
+	SignedStart    string `xml:"SignedStart"`
+	SignedExpiry   string `xml:"SignedExpiry"`
+	SignedService  string `xml:"SignedService"`
+	SignedVersion  string `xml:"SignedVersion"`
+	Value          string `xml:"Value"`
+}
+
+type GetUserDelegationKeyResult struct {
+	autorest.Response
+
+	Key UserDelegationKey
+}
+
+type userDelegationKeyInfo struct {
+	XMLName xml.Name `xml:"KeyInfo"`
+	Start   string   `xml:"Start"`
+	Expiry  string   `xml:"Expiry"`
+}
+
+// UserDelegationKeysClient requests User Delegation Keys from the Blob Service, which must be authenticated using Azure Active Directory
+type UserDelegationKeysClient struct {
+	autorest.Client
+	BaseURI string
+}
+
+func NewUserDelegationKeysClientWithEnvironment(environment azure.Environment) UserDelegationKeysClient {
+	return UserDelegationKeysClient{
+		Client:  autorest.NewClientWithUserAgent("terraform-provider-azurerm"),
+		BaseURI: environment.StorageEndpointSuffix,
+	}
+}
+
+// Get requests a User Delegation Key which is valid between the start and expiry, which must be in the
+// format `2006-01-02T15:04:05Z` and can be at most 7 days apart
+func (client UserDelegationKeysClient) Get(ctx context.Context, accountName, start, expiry string) (result GetUserDelegationKeyResult, err error) {
+	if accountName == "" {
+		return result, validation.NewError("sas.UserDelegationKeysClient", "Get", "`accountName` cannot be an empty string.")
+	}
+	if expiry == "" {
+		return result, validation.NewError("sas.UserDelegationKeysClient", "Get", "`expiry` cannot be an empty string.")
+	}
+
+	req, err := client.GetPreparer(ctx, accountName, start, expiry)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sas.UserDelegationKeysClient", "Get", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "sas.UserDelegationKeysClient", "Get", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sas.UserDelegationKeysClient", "Get", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client UserDelegationKeysClient) GetPreparer(ctx context.Context, accountName, start, expiry string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"restype": autorest.Encode("query", "service"),
+		"comp":    autorest.Encode("query", "userdelegationkey"),
+	}
+
+	headers := map[string]interface{}{
+		"x-ms-version": SignedVersion,
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsContentType("application/xml; charset=utf-8"),
+		autorest.WithBaseURL(fmt.Sprintf("https://%s.blob.%s", accountName, client.BaseURI)),
+		autorest.WithPath("/"),
+		autorest.WithQueryParameters(queryParameters),
+		autorest.WithHeaders(headers),
+		autorest.WithXML(userDelegationKeyInfo{
+			Start:  start,
+			Expiry: expiry,
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client UserDelegationKeysClient) GetSender(req *http.Request) (*http.Response, error) {
+	return autorest.SendWithSender(client, req,
+		azure.DoRetryWithRegistration(client.Client))
+}
+
+func (client UserDelegationKeysClient) GetResponder(resp *http.Response) (result GetUserDelegationKeyResult, err error) {
+	err = autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&result.Key),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+
+	return
+}
@@ -0,0 +1,67 @@
+package sas
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+func TestUserDelegationKeysClientGet(t *testing.T) {
+	client := NewUserDelegationKeysClientWithEnvironment(azure.PublicCloud)
+	client.Sender = autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost {
+			t.Fatalf("Expected a %s request but got %s", http.MethodPost, req.Method)
+		}
+		if expected := "https://acct.blob.core.windows.net/?comp=userdelegationkey&restype=service"; req.URL.String() != expected {
+			t.Fatalf("Expected the URL %q but got %q", expected, req.URL.String())
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("reading the request body: %+v", err)
+		}
+		if expected := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<KeyInfo><Start>2021-05-01T00:00:00Z</Start><Expiry>2021-05-02T00:00:00Z</Expiry></KeyInfo>"; string(body) != expected {
+			t.Fatalf("Expected the body %q but got %q", expected, string(body))
+		}
+
+		response := `<?xml version="1.0" encoding="utf-8"?>
+<UserDelegationKey>
+  <SignedOid>00000000-0000-0000-0000-000000000001</SignedOid>
+  <SignedTid>00000000-0000-0000-0000-000000000002</SignedTid>
+  <SignedStart>2021-05-01T00:00:00Z</SignedStart>
+  <SignedExpiry>2021-05-02T00:00:00Z</SignedExpiry>
+  <SignedService>b</SignedService>
+  <SignedVersion>2019-12-12</SignedVersion>
+  <Value>dXNlci1kZWxlZ2F0aW9uLWtleQ==</Value>
+</UserDelegationKey>`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/xml"}},
+			Body:       io.NopCloser(strings.NewReader(response)),
+			Request:    req,
+		}, nil
+	})
+
+	result, err := client.Get(context.TODO(), "acct", "2021-05-01T00:00:00Z", "2021-05-02T00:00:00Z")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expected := UserDelegationKey{
+		SignedObjectID: "00000000-0000-0000-0000-000000000001",
+		SignedTenantID: "00000000-0000-0000-0000-000000000002",
+		SignedStart:    "2021-05-01T00:00:00Z",
+		SignedExpiry:   "2021-05-02T00:00:00Z",
+		SignedService:  "b",
+		SignedVersion:  "2019-12-12",
+		Value:          testUserDelegationKey,
+	}
+	if result.Key != expected {
+		t.Fatalf("Expected %+v but got %+v", expected, result.Key)
+	}
+}
@@ -1,17 +1,27 @@
 package storage
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/storage"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
-	validate2 "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/sas"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
 )
 
+var containerSASPermissions = [][]string{
+	{"read", "r"},
+	{"add", "a"},
+	{"create", "c"},
+	{"write", "w"},
+	{"delete", "d"},
+	{"list", "l"},
+}
+
 func dataSourceStorageAccountBlobContainerSharedAccessSignature() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceStorageContainerSasRead,
@@ -20,12 +30,22 @@ func dataSourceStorageAccountBlobContainerSharedAccessSignature() *schema.Resour
 			Read: schema.DefaultTimeout(5 * time.Minute),
 		},
 
-		Schema: map[string]*schema.Schema{
+		Schema: mergeServiceSASSchema(serviceSASSchema(), serviceSASResponseHeadersSchema(), map[string]*schema.Schema{
 			"connection_string": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				Sensitive:    true,
 				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"connection_string", "storage_account_name"},
+			},
+
+			// when a Storage Account Name is specified a User Delegation SAS is generated using Azure AD
+			"storage_account_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  ValidateStorageAccountName,
+				ExactlyOneOf:  []string{"connection_string", "storage_account_name"},
+				ConflictsWith: []string{"signed_identifier"},
 			},
 
 			"container_name": {
@@ -34,18 +54,7 @@ func dataSourceStorageAccountBlobContainerSharedAccessSignature() *schema.Resour
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
 
-			"https_only": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  true,
-			},
-
-			"ip_address": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ValidateFunc: validate2.SharedAccessSignatureIP,
-			},
-
+			// unlike the other Service SAS Data Sources these fields have always been Required
 			"start": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -96,116 +105,59 @@ func dataSourceStorageAccountBlobContainerSharedAccessSignature() *schema.Resour
 					},
 				},
 			},
-
-			"cache_control": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"content_disposition": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"content_encoding": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"content_language": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"content_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"sas": {
-				Type:      schema.TypeString,
-				Computed:  true,
-				Sensitive: true,
-			},
-		},
+		}),
 	}
 }
 
-func dataSourceStorageContainerSasRead(d *schema.ResourceData, _ interface{}) error {
-	connString := d.Get("connection_string").(string)
+func dataSourceStorageContainerSasRead(d *schema.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
 	containerName := d.Get("container_name").(string)
-	httpsOnly := d.Get("https_only").(bool)
-	ip := d.Get("ip_address").(string)
-	start := d.Get("start").(string)
-	expiry := d.Get("expiry").(string)
-	permissionsIface := d.Get("permissions").([]interface{})
-
-	// response headers
-	cacheControl := d.Get("cache_control").(string)
-	contentDisposition := d.Get("content_disposition").(string)
-	contentEncoding := d.Get("content_encoding").(string)
-	contentLanguage := d.Get("content_language").(string)
-	contentType := d.Get("content_type").(string)
-
-	permissions := BuildContainerPermissionsString(permissionsIface[0].(map[string]interface{}))
-
-	// Parse the connection string
-	kvp, err := storage.ParseAccountSASConnectionString(connString)
-	if err != nil {
-		return err
-	}
 
-	// Create the string to sign with the key...
-	accountName := kvp[connStringAccountNameKey]
-	accountKey := kvp[connStringAccountKeyKey]
-	signedProtocol := "https,http"
-	if httpsOnly {
-		signedProtocol = "https"
+	accountName := d.Get("storage_account_name").(string)
+	accountKey := ""
+	if connString := d.Get("connection_string").(string); connString != "" {
+		var err error
+		accountName, accountKey, err = parseServiceSASConnectionString(connString)
+		if err != nil {
+			return err
+		}
 	}
-	signedIp := ip
-	signedIdentifier := ""
-	signedSnapshotTime := ""
-
-	sasToken, err := storage.ComputeContainerSASToken(permissions, start, expiry, accountName, accountKey,
-		containerName, signedIdentifier, signedIp, signedProtocol, signedSnapshotTime, cacheControl,
-		contentDisposition, contentEncoding, contentLanguage, contentType)
-	if err != nil {
-		return err
+
+	input := expandServiceSASInput(d, accountName, containerName, sas.SignedResourceContainer)
+	input.Permissions = expandServiceSASPermissions(d.Get("permissions").([]interface{}), containerSASPermissions)
+	expandServiceSASResponseHeaders(d, &input)
+
+	var token string
+	if accountKey != "" {
+		// the SAS Token is computed as it was previously, so that the `sas` (and `id`) don't change for existing users
+		v, err := storage.ComputeContainerSASToken(input.Permissions, input.Start, input.Expiry, accountName, accountKey,
+			containerName, input.Identifier, input.IP, input.Protocol, "", input.CacheControl,
+			input.ContentDisposition, input.ContentEncoding, input.ContentLanguage, input.ContentType)
+		if err != nil {
+			return fmt.Errorf("computing SAS Token for Container %q (Account %q): %+v", containerName, accountName, err)
+		}
+		token = v
+	} else {
+		key, err := getUserDelegationKey(ctx, storageClient.UserDelegationKeysClient, accountName, input.Start, input.Expiry)
+		if err != nil {
+			return err
+		}
+
+		v, err := sas.ComputeBlobUserDelegationSASToken(input, *key)
+		if err != nil {
+			return fmt.Errorf("computing User Delegation SAS Token for Container %q (Account %q): %+v", containerName, accountName, err)
+		}
+		token = v
 	}
 
-	d.Set("sas", sasToken)
-	tokenHash := sha256.Sum256([]byte(sasToken))
-	d.SetId(hex.EncodeToString(tokenHash[:]))
+	setServiceSASToken(d, token)
 
 	return nil
 }
 
 func BuildContainerPermissionsString(perms map[string]interface{}) string {
-	retVal := ""
-
-	if val, pres := perms["read"].(bool); pres && val {
-		retVal += "r"
-	}
-
-	if val, pres := perms["add"].(bool); pres && val {
-		retVal += "a"
-	}
-
-	if val, pres := perms["create"].(bool); pres && val {
-		retVal += "c"
-	}
-
-	if val, pres := perms["write"].(bool); pres && val {
-		retVal += "w"
-	}
-
-	if val, pres := perms["delete"].(bool); pres && val {
-		retVal += "d"
-	}
-
-	if val, pres := perms["list"].(bool); pres && val {
-		retVal += "l"
-	}
-
-	return retVal
+	return expandServiceSASPermissions([]interface{}{perms}, containerSASPermissions)
 }
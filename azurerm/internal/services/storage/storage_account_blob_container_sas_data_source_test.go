@@ -45,6 +45,23 @@ func TestAccDataSourceStorageAccountBlobContainerSas_basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceStorageAccountBlobContainerSas_userDelegation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_blob_container_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: StorageAccountBlobContainerSASDataSource{}.userDelegation(data, startDate, endDate),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("storage_account_name").Exists(),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
 func (d StorageAccountBlobContainerSASDataSource) basic(data acceptance.TestData, startDate string, endDate string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -99,6 +116,62 @@ data "azurerm_storage_account_blob_container_sas" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString, startDate, endDate)
 }
 
+func (d StorageAccountBlobContainerSASDataSource) userDelegation(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "storage" {
+  name                = "acctestsads%s"
+  resource_group_name = azurerm_resource_group.rg.name
+
+  location                 = azurerm_resource_group.rg.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "container" {
+  name                  = "sas-test"
+  storage_account_name  = azurerm_storage_account.storage.name
+  container_access_type = "private"
+}
+
+data "azurerm_client_config" "current" {
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_storage_account.storage.id
+  role_definition_name = "Storage Blob Data Contributor"
+  principal_id         = data.azurerm_client_config.current.object_id
+}
+
+data "azurerm_storage_account_blob_container_sas" "test" {
+  storage_account_name = azurerm_storage_account.storage.name
+  container_name       = azurerm_storage_container.container.name
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read   = true
+    add    = false
+    create = false
+    write  = false
+    delete = false
+    list   = true
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, startDate, endDate)
+}
+
 func TestAccDataSourceStorageAccountBlobContainerSas_permissionsString(t *testing.T) {
 	testCases := []struct {
 		input    map[string]interface{}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/sas"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+)
+
+var blobSASPermissions = [][]string{
+	{"read", "r"},
+	{"add", "a"},
+	{"create", "c"},
+	{"write", "w"},
+	{"delete", "d"},
+}
+
+func dataSourceStorageAccountBlobSharedAccessSignature() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStorageAccountBlobSasRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: mergeServiceSASSchema(serviceSASSchema(), serviceSASResponseHeadersSchema(), map[string]*schema.Schema{
+			"connection_string": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"connection_string", "storage_account_name"},
+			},
+
+			// when a Storage Account Name is specified a User Delegation SAS is generated using Azure AD
+			"storage_account_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  ValidateStorageAccountName,
+				ExactlyOneOf:  []string{"connection_string", "storage_account_name"},
+				ConflictsWith: []string{"signed_identifier"},
+			},
+
+			"container_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageContainerName,
+			},
+
+			"blob_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"permissions": serviceSASPermissionsSchema("read", "add", "create", "write", "delete"),
+		}),
+	}
+}
+
+func dataSourceStorageAccountBlobSasRead(d *schema.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	containerName := d.Get("container_name").(string)
+	blobName := d.Get("blob_name").(string)
+
+	accountName := d.Get("storage_account_name").(string)
+	accountKey := ""
+	if connString := d.Get("connection_string").(string); connString != "" {
+		var err error
+		accountName, accountKey, err = parseServiceSASConnectionString(connString)
+		if err != nil {
+			return err
+		}
+	}
+
+	input := expandServiceSASInput(d, accountName, fmt.Sprintf("%s/%s", containerName, blobName), sas.SignedResourceBlob)
+	input.Permissions = expandServiceSASPermissions(d.Get("permissions").([]interface{}), blobSASPermissions)
+	expandServiceSASResponseHeaders(d, &input)
+
+	var token string
+	if accountKey != "" {
+		v, err := sas.ComputeBlobSASToken(input, accountKey)
+		if err != nil {
+			return fmt.Errorf("computing SAS Token for Blob %q (Container %q / Account %q): %+v", blobName, containerName, accountName, err)
+		}
+		token = v
+	} else {
+		key, err := getUserDelegationKey(ctx, storageClient.UserDelegationKeysClient, accountName, input.Start, input.Expiry)
+		if err != nil {
+			return err
+		}
+
+		v, err := sas.ComputeBlobUserDelegationSASToken(input, *key)
+		if err != nil {
+			return fmt.Errorf("computing User Delegation SAS Token for Blob %q (Container %q / Account %q): %+v", blobName, containerName, accountName, err)
+		}
+		token = v
+	}
+
+	setServiceSASToken(d, token)
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+)
+
+type StorageAccountBlobSASDataSource struct{}
+
+func TestAccDataSourceStorageAccountBlobSas_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_blob_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: StorageAccountBlobSASDataSource{}.basic(data, startDate, endDate),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("https_only").HasValue("true"),
+				check.That(data.ResourceName).Key("start").HasValue(startDate),
+				check.That(data.ResourceName).Key("expiry").HasValue(endDate),
+				check.That(data.ResourceName).Key("ip_address").HasValue("168.1.5.60-168.1.5.70"),
+				check.That(data.ResourceName).Key("permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.read").HasValue("true"),
+				check.That(data.ResourceName).Key("permissions.0.write").HasValue("false"),
+				check.That(data.ResourceName).Key("content_type").HasValue("text/plain"),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceStorageAccountBlobSas_userDelegation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_blob_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: StorageAccountBlobSASDataSource{}.userDelegation(data, startDate, endDate),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("storage_account_name").Exists(),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func (d StorageAccountBlobSASDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                = "acctestsads%s"
+  resource_group_name = azurerm_resource_group.test.name
+
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "sas-test"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "example.txt"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "Wubba Lubba Dub Dub"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (d StorageAccountBlobSASDataSource) basic(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_account_blob_sas" "test" {
+  connection_string = azurerm_storage_account.test.primary_connection_string
+  container_name    = azurerm_storage_container.test.name
+  blob_name         = azurerm_storage_blob.test.name
+  https_only        = true
+
+  ip_address = "168.1.5.60-168.1.5.70"
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read = true
+  }
+
+  content_type = "text/plain"
+}
+`, d.template(data), startDate, endDate)
+}
+
+func (d StorageAccountBlobSASDataSource) userDelegation(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_client_config" "current" {
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_storage_account.test.id
+  role_definition_name = "Storage Blob Data Contributor"
+  principal_id         = data.azurerm_client_config.current.object_id
+}
+
+data "azurerm_storage_account_blob_sas" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  container_name       = azurerm_storage_container.test.name
+  blob_name            = azurerm_storage_blob.test.name
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read = true
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, d.template(data), startDate, endDate)
+}
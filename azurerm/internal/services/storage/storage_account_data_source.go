@@ -83,6 +83,11 @@ func dataSourceStorageAccount() *schema.Resource {
 				Computed: true,
 			},
 
+			"shared_access_key_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
 			"is_hns_enabled": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -321,6 +326,12 @@ func dataSourceStorageAccountRead(d *schema.ResourceData, meta interface{}) erro
 		d.Set("is_hns_enabled", props.IsHnsEnabled)
 		d.Set("allow_blob_public_access", props.AllowBlobPublicAccess)
 
+		sharedAccessKeyEnabled := true
+		if props.AllowSharedKeyAccess != nil {
+			sharedAccessKeyEnabled = *props.AllowSharedKeyAccess
+		}
+		d.Set("shared_access_key_enabled", sharedAccessKeyEnabled)
+
 		if customDomain := props.CustomDomain; customDomain != nil {
 			if err := d.Set("custom_domain", flattenStorageAccountCustomDomain(customDomain)); err != nil {
 				return fmt.Errorf("Error setting `custom_domain`: %+v", err)
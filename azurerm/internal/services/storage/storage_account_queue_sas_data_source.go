@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/sas"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+)
+
+var queueSASPermissions = [][]string{
+	{"read", "r"},
+	{"add", "a"},
+	{"update", "u"},
+	{"process", "p"},
+}
+
+func dataSourceStorageAccountQueueSharedAccessSignature() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStorageAccountQueueSasRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: mergeServiceSASSchema(serviceSASSchema(), map[string]*schema.Schema{
+			"connection_string": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"queue_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageQueueName,
+			},
+
+			"permissions": serviceSASPermissionsSchema("read", "add", "update", "process"),
+		}),
+	}
+}
+
+func dataSourceStorageAccountQueueSasRead(d *schema.ResourceData, _ interface{}) error {
+	queueName := d.Get("queue_name").(string)
+
+	accountName, accountKey, err := parseServiceSASConnectionString(d.Get("connection_string").(string))
+	if err != nil {
+		return err
+	}
+
+	// Queues don't have a Signed Resource
+	input := expandServiceSASInput(d, accountName, queueName, "")
+	input.Permissions = expandServiceSASPermissions(d.Get("permissions").([]interface{}), queueSASPermissions)
+
+	token, err := sas.ComputeQueueSASToken(input, accountKey)
+	if err != nil {
+		return fmt.Errorf("computing SAS Token for Queue %q (Account %q): %+v", queueName, accountName, err)
+	}
+
+	setServiceSASToken(d, token)
+
+	return nil
+}
@@ -168,6 +168,12 @@ func resourceStorageAccount() *schema.Resource {
 				Default:  false,
 			},
 
+			"shared_access_key_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
 			"network_rules": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -287,6 +293,37 @@ func resourceStorageAccount() *schema.Resource {
 								},
 							},
 						},
+
+						"versioning_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"change_feed_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"change_feed_retention_in_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(1, 146000),
+						},
+
+						"last_access_time_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"default_service_version": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validate.BlobPropertiesDefaultServiceVersion,
+						},
 					},
 				},
 			},
@@ -666,6 +703,7 @@ func resourceStorageAccountCreate(d *schema.ResourceData, meta interface{}) erro
 	minimumTLSVersion := d.Get("min_tls_version").(string)
 	isHnsEnabled := d.Get("is_hns_enabled").(bool)
 	allowBlobPublicAccess := d.Get("allow_blob_public_access").(bool)
+	sharedAccessKeyEnabled := d.Get("shared_access_key_enabled").(bool)
 
 	accountTier := d.Get("account_tier").(string)
 	replicationType := d.Get("account_replication_type").(string)
@@ -682,6 +720,7 @@ func resourceStorageAccountCreate(d *schema.ResourceData, meta interface{}) erro
 			EnableHTTPSTrafficOnly: &enableHTTPSTrafficOnly,
 			NetworkRuleSet:         expandStorageAccountNetworkRules(d),
 			IsHnsEnabled:           &isHnsEnabled,
+			AllowSharedKeyAccess:   &sharedAccessKeyEnabled,
 		},
 	}
 
@@ -770,7 +809,10 @@ func resourceStorageAccountCreate(d *schema.ResourceData, meta interface{}) erro
 		if accountKind != string(storage.FileStorage) {
 			blobClient := meta.(*clients.Client).Storage.BlobServicesClient
 
-			blobProperties := expandBlobProperties(val.([]interface{}))
+			blobProperties, err := expandBlobProperties(val.([]interface{}))
+			if err != nil {
+				return err
+			}
 
 			if _, err = blobClient.SetServiceProperties(ctx, resourceGroupName, storageAccountName, blobProperties); err != nil {
 				return fmt.Errorf("Error updating Azure Storage Account `blob_properties` %q: %+v", storageAccountName, err)
@@ -986,6 +1028,22 @@ func resourceStorageAccountUpdate(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 
+	if d.HasChange("shared_access_key_enabled") {
+		sharedAccessKeyEnabled := d.Get("shared_access_key_enabled").(bool)
+		opts := storage.AccountUpdateParameters{
+			AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
+				AllowSharedKeyAccess: &sharedAccessKeyEnabled,
+			},
+		}
+
+		if _, err := client.Update(ctx, resourceGroupName, storageAccountName, opts); err != nil {
+			return fmt.Errorf("Error updating Azure Storage Account shared_access_key_enabled %q: %+v", storageAccountName, err)
+		}
+
+		// the cached Account determines whether the Data Plane APIs are authenticated using Azure AD
+		meta.(*clients.Client).Storage.RemoveAccountFromCache(storageAccountName)
+	}
+
 	if d.HasChange("identity") {
 		opts := storage.AccountUpdateParameters{
 			Identity: expandAzureRmStorageAccountIdentity(d),
@@ -1028,7 +1086,10 @@ func resourceStorageAccountUpdate(d *schema.ResourceData, meta interface{}) erro
 		// FileStorage does not support blob settings
 		if accountKind != string(storage.FileStorage) {
 			blobClient := meta.(*clients.Client).Storage.BlobServicesClient
-			blobProperties := expandBlobProperties(d.Get("blob_properties").([]interface{}))
+			blobProperties, err := expandBlobProperties(d.Get("blob_properties").([]interface{}))
+			if err != nil {
+				return err
+			}
 
 			if _, err = blobClient.SetServiceProperties(ctx, resourceGroupName, storageAccountName, blobProperties); err != nil {
 				return fmt.Errorf("Error updating Azure Storage Account `blob_properties` %q: %+v", storageAccountName, err)
@@ -1157,6 +1218,12 @@ func resourceStorageAccountRead(d *schema.ResourceData, meta interface{}) error
 		d.Set("enable_https_traffic_only", props.EnableHTTPSTrafficOnly)
 		d.Set("is_hns_enabled", props.IsHnsEnabled)
 		d.Set("allow_blob_public_access", props.AllowBlobPublicAccess)
+		// the API returns null when Shared Key access hasn't been configured, which is equivalent to true
+		sharedAccessKeyEnabled := true
+		if props.AllowSharedKeyAccess != nil {
+			sharedAccessKeyEnabled = *props.AllowSharedKeyAccess
+		}
+		d.Set("shared_access_key_enabled", sharedAccessKeyEnabled)
 		// For all Clouds except Public and USGovernmentCloud, "min_tls_version" is not returned from Azure so always persist the default values for "min_tls_version".
 		// https://github.com/terraform-providers/terraform-provider-azurerm/issues/7812
 		// https://github.com/terraform-providers/terraform-provider-azurerm/issues/8083
@@ -1484,7 +1551,7 @@ func expandStorageAccountBypass(networkRule map[string]interface{}) storage.Bypa
 	return storage.Bypass(strings.Join(bypassValues, ", "))
 }
 
-func expandBlobProperties(input []interface{}) storage.BlobServiceProperties {
+func expandBlobProperties(input []interface{}) (storage.BlobServiceProperties, error) {
 	props := storage.BlobServiceProperties{
 		BlobServicePropertiesProperties: &storage.BlobServicePropertiesProperties{
 			Cors: &storage.CorsRules{
@@ -1497,7 +1564,7 @@ func expandBlobProperties(input []interface{}) storage.BlobServiceProperties {
 	}
 
 	if len(input) == 0 || input[0] == nil {
-		return props
+		return props, nil
 	}
 
 	v := input[0].(map[string]interface{})
@@ -1509,7 +1576,53 @@ func expandBlobProperties(input []interface{}) storage.BlobServiceProperties {
 	corsRaw := v["cors_rule"].([]interface{})
 	props.BlobServicePropertiesProperties.Cors = expandBlobPropertiesCors(corsRaw)
 
-	return props
+	props.BlobServicePropertiesProperties.IsVersioningEnabled = utils.Bool(v["versioning_enabled"].(bool))
+
+	changeFeed, err := expandBlobPropertiesChangeFeed(v["change_feed_enabled"].(bool), v["change_feed_retention_in_days"].(int))
+	if err != nil {
+		return props, err
+	}
+	props.BlobServicePropertiesProperties.ChangeFeed = changeFeed
+
+	props.BlobServicePropertiesProperties.LastAccessTimeTrackingPolicy = expandBlobPropertiesLastAccessTimeTrackingPolicy(v["last_access_time_enabled"].(bool))
+
+	if version := v["default_service_version"].(string); version != "" {
+		props.BlobServicePropertiesProperties.DefaultServiceVersion = utils.String(version)
+	}
+
+	return props, nil
+}
+
+func expandBlobPropertiesChangeFeed(enabled bool, retentionInDays int) (*storage.ChangeFeed, error) {
+	changeFeed := storage.ChangeFeed{
+		Enabled: utils.Bool(enabled),
+	}
+
+	if retentionInDays != 0 {
+		if !enabled {
+			return nil, fmt.Errorf("`change_feed_retention_in_days` can only be specified when `change_feed_enabled` is set to `true`")
+		}
+
+		changeFeed.RetentionInDays = utils.Int32(int32(retentionInDays))
+	}
+
+	return &changeFeed, nil
+}
+
+func expandBlobPropertiesLastAccessTimeTrackingPolicy(enabled bool) *storage.LastAccessTimeTrackingPolicy {
+	if !enabled {
+		return &storage.LastAccessTimeTrackingPolicy{
+			Enable: utils.Bool(false),
+		}
+	}
+
+	// the Name, Tracking Granularity and Blob Type are currently fixed values
+	return &storage.LastAccessTimeTrackingPolicy{
+		Enable:                    utils.Bool(true),
+		Name:                      storage.AccessTimeTracking,
+		TrackingGranularityInDays: utils.Int32(1),
+		BlobType:                  &[]string{"blockBlob"},
+	}
 }
 
 func expandBlobPropertiesDeleteRetentionPolicy(input []interface{}) *storage.DeleteRetentionPolicy {
@@ -1777,7 +1890,34 @@ func flattenBlobProperties(input storage.BlobServiceProperties) []interface{} {
 		flattenedContainerDeletePolicy = flattenBlobPropertiesDeleteRetentionPolicy(containerDeletePolicy)
 	}
 
-	if len(flattenedCorsRules) == 0 && len(flattenedDeletePolicy) == 0 && len(flattenedContainerDeletePolicy) == 0 {
+	versioningEnabled := false
+	if input.BlobServicePropertiesProperties.IsVersioningEnabled != nil {
+		versioningEnabled = *input.BlobServicePropertiesProperties.IsVersioningEnabled
+	}
+
+	changeFeedEnabled := false
+	changeFeedRetentionInDays := 0
+	if changeFeed := input.BlobServicePropertiesProperties.ChangeFeed; changeFeed != nil {
+		if changeFeed.Enabled != nil {
+			changeFeedEnabled = *changeFeed.Enabled
+		}
+		if changeFeed.RetentionInDays != nil {
+			changeFeedRetentionInDays = int(*changeFeed.RetentionInDays)
+		}
+	}
+
+	lastAccessTimeEnabled := false
+	if policy := input.BlobServicePropertiesProperties.LastAccessTimeTrackingPolicy; policy != nil && policy.Enable != nil {
+		lastAccessTimeEnabled = *policy.Enable
+	}
+
+	defaultServiceVersion := ""
+	if input.BlobServicePropertiesProperties.DefaultServiceVersion != nil {
+		defaultServiceVersion = *input.BlobServicePropertiesProperties.DefaultServiceVersion
+	}
+
+	if len(flattenedCorsRules) == 0 && len(flattenedDeletePolicy) == 0 && len(flattenedContainerDeletePolicy) == 0 &&
+		!versioningEnabled && !changeFeedEnabled && !lastAccessTimeEnabled && defaultServiceVersion == "" {
 		return []interface{}{}
 	}
 
@@ -1786,6 +1926,11 @@ func flattenBlobProperties(input storage.BlobServiceProperties) []interface{} {
 			"cors_rule":                         flattenedCorsRules,
 			"delete_retention_policy":           flattenedDeletePolicy,
 			"container_delete_retention_policy": flattenedContainerDeletePolicy,
+			"versioning_enabled":                versioningEnabled,
+			"change_feed_enabled":               changeFeedEnabled,
+			"change_feed_retention_in_days":     changeFeedRetentionInDays,
+			"last_access_time_enabled":          lastAccessTimeEnabled,
+			"default_service_version":           defaultServiceVersion,
 		},
 	}
 }
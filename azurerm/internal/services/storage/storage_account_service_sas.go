@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	iso8601 "github.com/btubbs/datetime"
+	"github.com/hashicorp/go-azure-helpers/storage"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/sas"
+	validate2 "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+)
+
+// userDelegationKeyTimeFormat is the format the Start and Expiry of a User Delegation Key must be specified in
+const userDelegationKeyTimeFormat = "2006-01-02T15:04:05Z"
+
+// serviceSASSchema returns the fields common to each of the Service SAS Data Sources
+func serviceSASSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"https_only": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+
+		"ip_address": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validate2.SharedAccessSignatureIP,
+		},
+
+		"start": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validate.ISO8601DateTime,
+		},
+
+		"expiry": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validate.ISO8601DateTime,
+		},
+
+		"signed_identifier": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 64),
+		},
+
+		"sas": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+	}
+}
+
+// serviceSASResponseHeadersSchema returns the Response Headers which can be overridden for Blobs and Files
+func serviceSASResponseHeadersSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cache_control": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"content_disposition": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"content_encoding": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"content_language": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"content_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+// serviceSASPermissionsSchema returns a `permissions` block containing the specified permissions
+func serviceSASPermissionsSchema(permissions ...string) *schema.Schema {
+	fields := make(map[string]*schema.Schema)
+	for _, v := range permissions {
+		fields[v] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+func mergeServiceSASSchema(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema)
+	for _, s := range schemas {
+		for k, v := range s {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func expandServiceSASInput(d *schema.ResourceData, accountName, path string, resource sas.SignedResource) sas.ServiceSASInput {
+	protocol := "https,http"
+	if d.Get("https_only").(bool) {
+		protocol = "https"
+	}
+
+	return sas.ServiceSASInput{
+		AccountName: accountName,
+		Path:        path,
+		Resource:    resource,
+		Start:       d.Get("start").(string),
+		Expiry:      d.Get("expiry").(string),
+		IP:          d.Get("ip_address").(string),
+		Protocol:    protocol,
+		Identifier:  d.Get("signed_identifier").(string),
+	}
+}
+
+func expandServiceSASResponseHeaders(d *schema.ResourceData, input *sas.ServiceSASInput) {
+	input.CacheControl = d.Get("cache_control").(string)
+	input.ContentDisposition = d.Get("content_disposition").(string)
+	input.ContentEncoding = d.Get("content_encoding").(string)
+	input.ContentLanguage = d.Get("content_language").(string)
+	input.ContentType = d.Get("content_type").(string)
+}
+
+// expandServiceSASPermissions builds the permissions string in the order the Storage API requires,
+// where `permissions` maps each field within the `permissions` block to its abbreviation
+func expandServiceSASPermissions(input []interface{}, permissions [][]string) string {
+	if len(input) == 0 || input[0] == nil {
+		return ""
+	}
+	raw := input[0].(map[string]interface{})
+
+	out := ""
+	for _, v := range permissions {
+		if val, ok := raw[v[0]].(bool); ok && val {
+			out += v[1]
+		}
+	}
+	return out
+}
+
+// parseServiceSASConnectionString returns the Account Name and Account Key from a Storage Connection String
+func parseServiceSASConnectionString(connectionString string) (string, string, error) {
+	kvp, err := storage.ParseAccountSASConnectionString(connectionString)
+	if err != nil {
+		return "", "", fmt.Errorf("parsing `connection_string`: %+v", err)
+	}
+
+	return kvp[connStringAccountNameKey], kvp[connStringAccountKeyKey], nil
+}
+
+// getUserDelegationKey requests a User Delegation Key using Azure AD which is valid for the lifetime of the SAS Token
+func getUserDelegationKey(ctx context.Context, client *sas.UserDelegationKeysClient, accountName, start, expiry string) (*sas.UserDelegationKey, error) {
+	keyStart := time.Now().UTC()
+	if start != "" {
+		v, err := iso8601.Parse(start, time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `start`: %+v", err)
+		}
+		keyStart = v.UTC()
+	}
+
+	if expiry == "" {
+		return nil, fmt.Errorf("`expiry` must be specified when generating a User Delegation SAS")
+	}
+	keyExpiry, err := iso8601.Parse(expiry, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("parsing `expiry`: %+v", err)
+	}
+
+	resp, err := client.Get(ctx, accountName, keyStart.Format(userDelegationKeyTimeFormat), keyExpiry.UTC().Format(userDelegationKeyTimeFormat))
+	if err != nil {
+		return nil, fmt.Errorf("retrieving User Delegation Key for Storage Account %q: %+v", accountName, err)
+	}
+
+	return &resp.Key, nil
+}
+
+func setServiceSASToken(d *schema.ResourceData, token string) {
+	d.Set("sas", token)
+	tokenHash := sha256.Sum256([]byte(token))
+	d.SetId(hex.EncodeToString(tokenHash[:]))
+}
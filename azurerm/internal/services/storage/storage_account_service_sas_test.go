@@ -0,0 +1,24 @@
+package storage
+
+import "testing"
+
+func TestExpandServiceSASPermissions(t *testing.T) {
+	testCases := []struct {
+		input       []interface{}
+		permissions [][]string
+		expected    string
+	}{
+		{[]interface{}{}, blobSASPermissions, ""},
+		{[]interface{}{nil}, blobSASPermissions, ""},
+		{[]interface{}{map[string]interface{}{"delete": true, "read": true, "write": false}}, blobSASPermissions, "rd"},
+		{[]interface{}{map[string]interface{}{"list": true, "create": true, "read": true}}, shareSASPermissions, "rcl"},
+		{[]interface{}{map[string]interface{}{"process": true, "update": true, "add": true, "read": true}}, queueSASPermissions, "raup"},
+	}
+
+	for _, test := range testCases {
+		result := expandServiceSASPermissions(test.input, test.permissions)
+		if test.expected != result {
+			t.Fatalf("Failed to build permissions string: expected: %s, result: %s", test.expected, result)
+		}
+	}
+}
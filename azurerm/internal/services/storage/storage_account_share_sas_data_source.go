@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/sas"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+)
+
+var shareSASPermissions = [][]string{
+	{"read", "r"},
+	{"create", "c"},
+	{"write", "w"},
+	{"delete", "d"},
+	{"list", "l"},
+}
+
+func dataSourceStorageAccountShareSharedAccessSignature() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStorageAccountShareSasRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: mergeServiceSASSchema(serviceSASSchema(), serviceSASResponseHeadersSchema(), map[string]*schema.Schema{
+			"connection_string": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"share_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageShareName,
+			},
+
+			// when specified the SAS Token grants access to this File rather than the whole Share
+			"file_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"permissions": serviceSASPermissionsSchema("read", "create", "write", "delete", "list"),
+		}),
+	}
+}
+
+func dataSourceStorageAccountShareSasRead(d *schema.ResourceData, _ interface{}) error {
+	shareName := d.Get("share_name").(string)
+	filePath := strings.TrimPrefix(d.Get("file_path").(string), "/")
+
+	accountName, accountKey, err := parseServiceSASConnectionString(d.Get("connection_string").(string))
+	if err != nil {
+		return err
+	}
+
+	path := shareName
+	resource := sas.SignedResourceShare
+	if filePath != "" {
+		path = fmt.Sprintf("%s/%s", shareName, filePath)
+		resource = sas.SignedResourceFile
+	}
+
+	input := expandServiceSASInput(d, accountName, path, resource)
+	input.Permissions = expandServiceSASPermissions(d.Get("permissions").([]interface{}), shareSASPermissions)
+	expandServiceSASResponseHeaders(d, &input)
+
+	token, err := sas.ComputeFileSASToken(input, accountKey)
+	if err != nil {
+		return fmt.Errorf("computing SAS Token for %q (Share %q / Account %q): %+v", path, shareName, accountName, err)
+	}
+
+	setServiceSASToken(d, token)
+
+	return nil
+}
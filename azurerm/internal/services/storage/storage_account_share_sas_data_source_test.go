@@ -0,0 +1,158 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+)
+
+type StorageAccountShareSASDataSource struct{}
+
+func TestAccDataSourceStorageAccountShareSas_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_share_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: StorageAccountShareSASDataSource{}.basic(data, startDate, endDate),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("https_only").HasValue("true"),
+				check.That(data.ResourceName).Key("start").HasValue(startDate),
+				check.That(data.ResourceName).Key("expiry").HasValue(endDate),
+				check.That(data.ResourceName).Key("permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.read").HasValue("true"),
+				check.That(data.ResourceName).Key("permissions.0.list").HasValue("true"),
+				check.That(data.ResourceName).Key("permissions.0.delete").HasValue("false"),
+				check.That(data.ResourceName).Key("cache_control").HasValue("max-age=5"),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceStorageAccountShareSas_filePath(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_share_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: StorageAccountShareSASDataSource{}.filePath(data, startDate, endDate),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("file_path").HasValue("dir/example.txt"),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceStorageAccountShareSas_signedIdentifier(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_share_sas", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: StorageAccountShareSASDataSource{}.signedIdentifier(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("signed_identifier").HasValue("MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func (d StorageAccountShareSASDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                = "acctestsads%s"
+  resource_group_name = azurerm_resource_group.test.name
+
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_share" "test" {
+  name                 = "sas-test"
+  storage_account_name = azurerm_storage_account.test.name
+
+  acl {
+    id = "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"
+
+    access_policy {
+      permissions = "rl"
+      start       = "2021-05-01T00:00:00.0000000Z"
+      expiry      = "2099-05-01T00:00:00.0000000Z"
+    }
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (d StorageAccountShareSASDataSource) basic(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_account_share_sas" "test" {
+  connection_string = azurerm_storage_account.test.primary_connection_string
+  share_name        = azurerm_storage_share.test.name
+  https_only        = true
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read = true
+    list = true
+  }
+
+  cache_control = "max-age=5"
+}
+`, d.template(data), startDate, endDate)
+}
+
+func (d StorageAccountShareSASDataSource) filePath(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_account_share_sas" "test" {
+  connection_string = azurerm_storage_account.test.primary_connection_string
+  share_name        = azurerm_storage_share.test.name
+  file_path         = "dir/example.txt"
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read = true
+  }
+}
+`, d.template(data), startDate, endDate)
+}
+
+func (d StorageAccountShareSASDataSource) signedIdentifier(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_account_share_sas" "test" {
+  connection_string = azurerm_storage_account.test.primary_connection_string
+  share_name        = azurerm_storage_share.test.name
+  signed_identifier = "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"
+}
+`, d.template(data))
+}
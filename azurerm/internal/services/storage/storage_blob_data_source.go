@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/blobs"
+)
+
+// blobDataSourceMaxContentLength is the largest Blob which can be read into the state using `include_content`
+const blobDataSourceMaxContentLength int64 = 4 * 1024 * 1024
+
+func dataSourceStorageBlob() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStorageBlobRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: ValidateStorageAccountName,
+			},
+
+			"storage_container_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.StorageContainerName,
+			},
+
+			"include_content": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"access_tier": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cache_control": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_disposition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_encoding": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_language": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_length": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"content_md5": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_modified": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"metadata": MetaDataComputedSchema(),
+
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_base64": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceStorageBlobRead(d *schema.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+	name := d.Get("name").(string)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Blob %q (Container %q): %s", accountName, name, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Unable to locate Account %q for Blob %q (Container %q)", accountName, name, containerName)
+	}
+
+	blobsClient, err := storageClient.BlobsClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Blobs Client for Storage Account %q (Resource Group %q): %s", accountName, account.ResourceGroup, err)
+	}
+
+	id := blobsClient.GetResourceID(accountName, containerName, name)
+
+	props, err := blobsClient.GetProperties(ctx, accountName, containerName, name, blobs.GetPropertiesInput{})
+	if err != nil {
+		if utils.ResponseWasNotFound(props.Response) {
+			return fmt.Errorf("Blob %q was not found in Container %q / Account %q / Resource Group %q", name, containerName, accountName, account.ResourceGroup)
+		}
+		return fmt.Errorf("retrieving properties for Blob %q (Container %q / Account %q / Resource Group %q): %s", name, containerName, accountName, account.ResourceGroup, err)
+	}
+
+	d.SetId(id)
+
+	d.Set("name", name)
+	d.Set("storage_container_name", containerName)
+	d.Set("storage_account_name", accountName)
+	d.Set("url", id)
+
+	d.Set("type", strings.TrimSuffix(string(props.BlobType), "Blob"))
+	d.Set("access_tier", string(props.AccessTier))
+	d.Set("cache_control", props.CacheControl)
+	d.Set("content_disposition", props.ContentDisposition)
+	d.Set("content_encoding", props.ContentEncoding)
+	d.Set("content_language", props.ContentLanguage)
+	d.Set("content_length", int(props.ContentLength))
+	d.Set("content_type", props.ContentType)
+	d.Set("etag", props.ETag)
+	d.Set("last_modified", props.LastModified)
+
+	contentMD5 := ""
+	if props.ContentMD5 != "" {
+		contentMD5, err = convertBase64ToHexEncoding(props.ContentMD5)
+		if err != nil {
+			return fmt.Errorf("converting `content_md5` for Blob %q (Container %q / Account %q): %s", name, containerName, accountName, err)
+		}
+	}
+	d.Set("content_md5", contentMD5)
+
+	if err := d.Set("metadata", FlattenMetaData(props.MetaData)); err != nil {
+		return fmt.Errorf("setting `metadata`: %+v", err)
+	}
+
+	content := ""
+	contentBase64 := ""
+	if d.Get("include_content").(bool) {
+		if props.ContentLength > blobDataSourceMaxContentLength {
+			return fmt.Errorf("the contents of Blob %q (Container %q / Account %q) can only be retrieved for Blobs up to %d bytes but it is %d bytes", name, containerName, accountName, blobDataSourceMaxContentLength, props.ContentLength)
+		}
+
+		if props.ContentLength > 0 {
+			result, getErr := blobsClient.Get(ctx, accountName, containerName, name, blobs.GetInput{})
+			if getErr != nil {
+				return fmt.Errorf("retrieving contents of Blob %q (Container %q / Account %q / Resource Group %q): %s", name, containerName, accountName, account.ResourceGroup, getErr)
+			}
+
+			contentBase64 = base64.StdEncoding.EncodeToString(result.Contents)
+			if utf8.Valid(result.Contents) {
+				content = string(result.Contents)
+			} else {
+				log.Printf("[DEBUG] The contents of Blob %q (Container %q / Account %q) aren't valid UTF-8 - only `content_base64` will be populated", name, containerName, accountName)
+			}
+		}
+	}
+	d.Set("content", content)
+	d.Set("content_base64", contentBase64)
+
+	return nil
+}
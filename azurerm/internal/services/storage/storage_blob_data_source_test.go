@@ -0,0 +1,93 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+)
+
+type StorageBlobDataSource struct{}
+
+func TestAccDataSourceStorageBlob_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_blob", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: StorageBlobDataSource{}.basic(data, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("type").HasValue("Block"),
+				check.That(data.ResourceName).Key("content_type").HasValue("text/plain"),
+				check.That(data.ResourceName).Key("content_length").HasValue("19"),
+				check.That(data.ResourceName).Key("content_md5").HasValue("737e15e6e8578dff3f0f284437a0cded"),
+				check.That(data.ResourceName).Key("metadata.%").HasValue("1"),
+				check.That(data.ResourceName).Key("metadata.k1").HasValue("v1"),
+				check.That(data.ResourceName).Key("url").Exists(),
+				check.That(data.ResourceName).Key("content").HasValue(""),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceStorageBlob_includeContent(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_blob", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: StorageBlobDataSource{}.basic(data, true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("content").HasValue("Wubba Lubba Dub Dub"),
+				check.That(data.ResourceName).Key("content_base64").HasValue("V3ViYmEgTHViYmEgRHViIER1Yg=="),
+			),
+		},
+	})
+}
+
+func (d StorageBlobDataSource) basic(data acceptance.TestData, includeContent bool) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "test"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "rick.morty"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  content_type           = "text/plain"
+  source_content         = "Wubba Lubba Dub Dub"
+
+  metadata = {
+    k1 = "v1"
+  }
+}
+
+data "azurerm_storage_blob" "test" {
+  name                   = azurerm_storage_blob.test.name
+  storage_account_name   = azurerm_storage_blob.test.storage_account_name
+  storage_container_name = azurerm_storage_blob.test.storage_container_name
+  include_content        = %t
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, includeContent)
+}
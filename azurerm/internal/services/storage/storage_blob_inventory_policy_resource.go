@@ -0,0 +1,443 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	inventory "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-04-01/storage"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/parse"
+	storageValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+	azSchema "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// a Storage Account can only have a single Blob Inventory Policy, which is always named `default`
+const blobInventoryPolicyName = "default"
+
+func resourceStorageBlobInventoryPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageBlobInventoryPolicyCreateUpdate,
+		Read:   resourceStorageBlobInventoryPolicyRead,
+		Update: resourceStorageBlobInventoryPolicyCreateUpdate,
+		Delete: resourceStorageBlobInventoryPolicyDelete,
+
+		Importer: azSchema.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := parse.BlobInventoryPolicyID(id)
+			return err
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: storageValidate.StorageAccountID,
+			},
+
+			"rules": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						// the Container within the Storage Account where the Inventory reports for this rule are written
+						"storage_container_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: storageValidate.StorageContainerName,
+						},
+
+						"format": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(inventory.FormatCsv),
+								string(inventory.FormatParquet),
+							}, false),
+						},
+
+						"schedule": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(inventory.ScheduleDaily),
+								string(inventory.ScheduleWeekly),
+							}, false),
+						},
+
+						"scope": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(inventory.ObjectTypeBlob),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(inventory.ObjectTypeBlob),
+								string(inventory.ObjectTypeContainer),
+							}, false),
+						},
+
+						// the order of the Schema Fields defines the order of the columns within the Inventory report
+						"schema_fields": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+
+						"filter": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									// required when the `scope` is `Blob`
+									"blob_types": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												"blockBlob",
+												"appendBlob",
+												"pageBlob",
+											}, false),
+										},
+									},
+
+									"include_blob_versions": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+
+									"include_snapshots": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+
+									"prefix_match": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										MaxItems: 10,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringIsNotEmpty,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		CustomizeDiff: func(diff *schema.ResourceDiff, v interface{}) error {
+			return validateBlobInventoryPolicyRules(diff.Get("rules").(*schema.Set).List())
+		},
+	}
+}
+
+func resourceStorageBlobInventoryPolicyCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.BlobInventoryPoliciesClient
+	containersClient := meta.(*clients.Client).Storage.BlobContainersClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	storageAccountId, err := parse.StorageAccountID(d.Get("storage_account_id").(string))
+	if err != nil {
+		return err
+	}
+
+	id := parse.NewBlobInventoryPolicyID(storageAccountId.SubscriptionId, storageAccountId.ResourceGroup, storageAccountId.Name, blobInventoryPolicyName)
+
+	if d.IsNewResource() {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.StorageAccountName)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+		}
+
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return tf.ImportAsExistsError("azurerm_storage_blob_inventory_policy", id.ID())
+		}
+	}
+
+	// the Inventory reports are written to Containers within the same Storage Account, which must already exist - since
+	// these Containers are commonly created in the same apply their presence can only be checked at this point
+	rules := d.Get("rules").(*schema.Set).List()
+	checked := make(map[string]struct{})
+	for _, raw := range rules {
+		containerName := raw.(map[string]interface{})["storage_container_name"].(string)
+		if _, ok := checked[containerName]; ok {
+			continue
+		}
+		checked[containerName] = struct{}{}
+
+		container, err := containersClient.Get(ctx, id.ResourceGroup, id.StorageAccountName, containerName)
+		if err != nil {
+			if utils.ResponseWasNotFound(container.Response) {
+				return fmt.Errorf("the Storage Container %q used for the Inventory reports was not found within Storage Account %q (Resource Group %q)", containerName, id.StorageAccountName, id.ResourceGroup)
+			}
+
+			return fmt.Errorf("retrieving Storage Container %q (Storage Account %q / Resource Group %q): %+v", containerName, id.StorageAccountName, id.ResourceGroup, err)
+		}
+	}
+
+	props := inventory.BlobInventoryPolicy{
+		BlobInventoryPolicyProperties: &inventory.BlobInventoryPolicyProperties{
+			Policy: &inventory.BlobInventoryPolicySchema{
+				Enabled: utils.Bool(true),
+				Type:    utils.String("Inventory"),
+				Rules:   expandBlobInventoryPolicyRules(rules),
+			},
+		},
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.StorageAccountName, props); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+	return resourceStorageBlobInventoryPolicyRead(d, meta)
+}
+
+func resourceStorageBlobInventoryPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.BlobInventoryPoliciesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.BlobInventoryPolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.StorageAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("storage_account_id", parse.NewStorageAccountID(id.SubscriptionId, id.ResourceGroup, id.StorageAccountName).ID())
+
+	if props := resp.BlobInventoryPolicyProperties; props != nil {
+		if policy := props.Policy; policy != nil {
+			if err := d.Set("rules", flattenBlobInventoryPolicyRules(policy.Rules)); err != nil {
+				return fmt.Errorf("setting `rules`: %+v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceStorageBlobInventoryPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.BlobInventoryPoliciesClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.BlobInventoryPolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if resp, err := client.Delete(ctx, id.ResourceGroup, id.StorageAccountName); err != nil {
+		if !utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("deleting %s: %+v", *id, err)
+		}
+	}
+
+	return nil
+}
+
+// validateBlobInventoryPolicyRules validates the combinations of fields within each rule which the API rejects
+func validateBlobInventoryPolicyRules(input []interface{}) error {
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		name := v["name"].(string)
+		scope := v["scope"].(string)
+
+		schemaFields := make(map[string]struct{})
+		for _, field := range v["schema_fields"].([]interface{}) {
+			if field != nil {
+				schemaFields[field.(string)] = struct{}{}
+			}
+		}
+		if _, ok := schemaFields["Name"]; !ok {
+			return fmt.Errorf("`schema_fields` must contain `Name` for the rule %q", name)
+		}
+
+		var filter map[string]interface{}
+		if raw := v["filter"].([]interface{}); len(raw) > 0 && raw[0] != nil {
+			filter = raw[0].(map[string]interface{})
+		}
+
+		if scope == string(inventory.ObjectTypeContainer) {
+			if filter != nil && (filter["blob_types"].(*schema.Set).Len() > 0 || filter["include_blob_versions"].(bool) || filter["include_snapshots"].(bool)) {
+				return fmt.Errorf("only `prefix_match` can be specified within the `filter` block for the rule %q when the `scope` is %q", name, scope)
+			}
+			continue
+		}
+
+		if filter == nil || filter["blob_types"].(*schema.Set).Len() == 0 {
+			return fmt.Errorf("`blob_types` must be specified within the `filter` block for the rule %q when the `scope` is %q", name, scope)
+		}
+
+		// the Schema Fields for versions & snapshots must be specified only when these are included in the inventory
+		dependentFields := map[string][]string{
+			"include_blob_versions": {"VersionId", "IsCurrentVersion"},
+			"include_snapshots":     {"Snapshot"},
+		}
+		for _, key := range []string{"include_blob_versions", "include_snapshots"} {
+			included := filter[key].(bool)
+			for _, field := range dependentFields[key] {
+				_, ok := schemaFields[field]
+				if included && !ok {
+					return fmt.Errorf("`schema_fields` must contain %q for the rule %q when `%s` is enabled", field, name, key)
+				}
+				if !included && ok {
+					return fmt.Errorf("`schema_fields` can only contain %q for the rule %q when `%s` is enabled", field, name, key)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandBlobInventoryPolicyRules(input []interface{}) *[]inventory.BlobInventoryPolicyRule {
+	results := make([]inventory.BlobInventoryPolicyRule, 0)
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		results = append(results, inventory.BlobInventoryPolicyRule{
+			Enabled:     utils.Bool(true),
+			Name:        utils.String(v["name"].(string)),
+			Destination: utils.String(v["storage_container_name"].(string)),
+			Definition: &inventory.BlobInventoryPolicyDefinition{
+				Format:       inventory.Format(v["format"].(string)),
+				Schedule:     inventory.Schedule(v["schedule"].(string)),
+				ObjectType:   inventory.ObjectType(v["scope"].(string)),
+				SchemaFields: utils.ExpandStringSlice(v["schema_fields"].([]interface{})),
+				Filters:      expandBlobInventoryPolicyFilter(v["filter"].([]interface{})),
+			},
+		})
+	}
+	return &results
+}
+
+func expandBlobInventoryPolicyFilter(input []interface{}) *inventory.BlobInventoryPolicyFilter {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	v := input[0].(map[string]interface{})
+
+	filter := inventory.BlobInventoryPolicyFilter{}
+	if blobTypes := v["blob_types"].(*schema.Set).List(); len(blobTypes) > 0 {
+		filter.BlobTypes = utils.ExpandStringSlice(blobTypes)
+	}
+	if prefixes := v["prefix_match"].(*schema.Set).List(); len(prefixes) > 0 {
+		filter.PrefixMatch = utils.ExpandStringSlice(prefixes)
+	}
+	// these can only be specified when the `scope` is `Blob`
+	if v["include_blob_versions"].(bool) {
+		filter.IncludeBlobVersions = utils.Bool(true)
+	}
+	if v["include_snapshots"].(bool) {
+		filter.IncludeSnapshots = utils.Bool(true)
+	}
+	return &filter
+}
+
+func flattenBlobInventoryPolicyRules(input *[]inventory.BlobInventoryPolicyRule) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		name := ""
+		if item.Name != nil {
+			name = *item.Name
+		}
+
+		destination := ""
+		if item.Destination != nil {
+			destination = *item.Destination
+		}
+
+		format := ""
+		schedule := ""
+		scope := ""
+		var schemaFields []interface{}
+		var filter []interface{}
+		if def := item.Definition; def != nil {
+			format = string(def.Format)
+			schedule = string(def.Schedule)
+			scope = string(def.ObjectType)
+			schemaFields = utils.FlattenStringSlice(def.SchemaFields)
+			filter = flattenBlobInventoryPolicyFilter(def.Filters)
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":                   name,
+			"storage_container_name": destination,
+			"format":                 format,
+			"schedule":               schedule,
+			"scope":                  scope,
+			"schema_fields":          schemaFields,
+			"filter":                 filter,
+		})
+	}
+	return results
+}
+
+func flattenBlobInventoryPolicyFilter(input *inventory.BlobInventoryPolicyFilter) []interface{} {
+	if input == nil {
+		return make([]interface{}, 0)
+	}
+
+	includeBlobVersions := false
+	if input.IncludeBlobVersions != nil {
+		includeBlobVersions = *input.IncludeBlobVersions
+	}
+	includeSnapshots := false
+	if input.IncludeSnapshots != nil {
+		includeSnapshots = *input.IncludeSnapshots
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"blob_types":            utils.FlattenStringSlice(input.BlobTypes),
+			"include_blob_versions": includeBlobVersions,
+			"include_snapshots":     includeSnapshots,
+			"prefix_match":          utils.FlattenStringSlice(input.PrefixMatch),
+		},
+	}
+}
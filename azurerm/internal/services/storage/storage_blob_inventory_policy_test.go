@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func blobInventoryPolicyTestRule(scope string, schemaFields []interface{}, filter []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                   "rule1",
+		"storage_container_name": "inventory",
+		"format":                 "Csv",
+		"schedule":               "Daily",
+		"scope":                  scope,
+		"schema_fields":          schemaFields,
+		"filter":                 filter,
+	}
+}
+
+func blobInventoryPolicyTestFilter(blobTypes []interface{}, includeBlobVersions, includeSnapshots bool) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"blob_types":            schema.NewSet(schema.HashString, blobTypes),
+			"include_blob_versions": includeBlobVersions,
+			"include_snapshots":     includeSnapshots,
+			"prefix_match":          schema.NewSet(schema.HashString, []interface{}{}),
+		},
+	}
+}
+
+func TestValidateBlobInventoryPolicyRules(t *testing.T) {
+	testData := []struct {
+		Name  string
+		Input map[string]interface{}
+		Error bool
+	}{
+		{
+			Name:  "blob scope",
+			Input: blobInventoryPolicyTestRule("Blob", []interface{}{"Name", "Last-Modified"}, blobInventoryPolicyTestFilter([]interface{}{"blockBlob"}, false, false)),
+		},
+		{
+			Name:  "blob scope without a filter",
+			Input: blobInventoryPolicyTestRule("Blob", []interface{}{"Name"}, []interface{}{}),
+			Error: true,
+		},
+		{
+			Name:  "blob scope without blob types",
+			Input: blobInventoryPolicyTestRule("Blob", []interface{}{"Name"}, blobInventoryPolicyTestFilter([]interface{}{}, false, false)),
+			Error: true,
+		},
+		{
+			Name:  "missing the name field",
+			Input: blobInventoryPolicyTestRule("Blob", []interface{}{"Last-Modified"}, blobInventoryPolicyTestFilter([]interface{}{"blockBlob"}, false, false)),
+			Error: true,
+		},
+		{
+			Name:  "versions and snapshots included",
+			Input: blobInventoryPolicyTestRule("Blob", []interface{}{"Name", "VersionId", "IsCurrentVersion", "Snapshot"}, blobInventoryPolicyTestFilter([]interface{}{"blockBlob"}, true, true)),
+		},
+		{
+			Name:  "versions included without the version fields",
+			Input: blobInventoryPolicyTestRule("Blob", []interface{}{"Name", "VersionId"}, blobInventoryPolicyTestFilter([]interface{}{"blockBlob"}, true, false)),
+			Error: true,
+		},
+		{
+			Name:  "snapshot field without snapshots included",
+			Input: blobInventoryPolicyTestRule("Blob", []interface{}{"Name", "Snapshot"}, blobInventoryPolicyTestFilter([]interface{}{"blockBlob"}, false, false)),
+			Error: true,
+		},
+		{
+			Name:  "container scope without a filter",
+			Input: blobInventoryPolicyTestRule("Container", []interface{}{"Name", "Metadata"}, []interface{}{}),
+		},
+		{
+			Name:  "container scope with blob types",
+			Input: blobInventoryPolicyTestRule("Container", []interface{}{"Name"}, blobInventoryPolicyTestFilter([]interface{}{"blockBlob"}, false, false)),
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateBlobInventoryPolicyRules([]interface{}{v.Input})
+		if v.Error {
+			if err == nil {
+				t.Fatalf("Expected an error but didn't get one")
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+	}
+}
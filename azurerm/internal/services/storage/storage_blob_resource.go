@@ -123,6 +123,7 @@ func resourceStorageBlob() *schema.Resource {
 			"content_md5": {
 				Type:          schema.TypeString,
 				Optional:      true,
+				Computed:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"source_uri"},
 			},
@@ -133,7 +134,6 @@ func resourceStorageBlob() *schema.Resource {
 			},
 
 			"parallelism": {
-				// TODO: @tombuildsstuff - a note this only works for Page blobs
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      8,
@@ -143,7 +143,41 @@ func resourceStorageBlob() *schema.Resource {
 
 			"metadata": MetaDataComputedSchema(),
 		},
+
+		CustomizeDiff: resourceStorageBlobCustomizeDiff,
+	}
+}
+
+func resourceStorageBlobCustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
+	// computing the MD5 of the `source` file means reading the whole file, so this is only done when the Blob
+	// is going to be (re-)uploaded - rather than on every plan
+	if !strings.EqualFold(d.Get("type").(string), "Block") || (d.Id() != "" && !d.HasChange("source")) {
+		return nil
 	}
+
+	// a `content_md5` specified in the configuration takes precedence - when the Blob is being replaced the
+	// `content_md5` is only unknown at this point when it's not specified in the configuration
+	if !d.NewValueKnown("source") || (d.NewValueKnown("content_md5") && d.Get("content_md5").(string) != "") {
+		return nil
+	}
+
+	source := d.Get("source").(string)
+	if source == "" {
+		return nil
+	}
+
+	contentMD5, err := computeFileContentMD5(source)
+	if err != nil {
+		// the file may not exist yet if it's generated by another resource during the apply
+		log.Printf("[DEBUG] Unable to compute the MD5 of the `source` %q - it'll be computed during the upload: %+v", source, err)
+		return nil
+	}
+
+	if err := d.SetNew("content_md5", contentMD5); err != nil {
+		return fmt.Errorf("setting `content_md5`: %+v", err)
+	}
+
+	return nil
 }
 
 func resourceStorageBlobCreate(d *schema.ResourceData, meta interface{}) error {
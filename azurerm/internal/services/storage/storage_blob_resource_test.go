@@ -41,7 +41,7 @@ func TestAccStorageBlob_appendEmpty(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -56,7 +56,7 @@ func TestAccStorageBlob_appendEmptyMetaData(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -71,7 +71,7 @@ func TestAccStorageBlob_blockEmpty(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -86,7 +86,7 @@ func TestAccStorageBlob_blockEmptyAzureADAuth(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -101,7 +101,7 @@ func TestAccStorageBlob_blockEmptyMetaData(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -117,7 +117,7 @@ func TestAccStorageBlob_blockEmptyAccessTier(t *testing.T) {
 				check.That(data.ResourceName).Key("access_tier").HasValue("Cool"),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 		{
 			Config: r.blockEmptyAccessTier(data, blobs.Hot),
 			Check: resource.ComposeTestCheckFunc(
@@ -146,7 +146,7 @@ func TestAccStorageBlob_blockFromInlineContent(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "source_content", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -161,7 +161,7 @@ func TestAccStorageBlob_blockFromPublicBlob(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "source_uri", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -176,7 +176,7 @@ func TestAccStorageBlob_blockFromPublicFile(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "source_uri", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -191,7 +191,7 @@ func TestAccStorageBlob_blockFromExistingBlob(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "source_uri", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -215,7 +215,7 @@ func TestAccStorageBlob_blockFromLocalFile(t *testing.T) {
 				data.CheckWithClient(r.blobMatchesFile(blobs.BlockBlob, sourceBlob.Name())),
 			),
 		},
-		data.ImportStep("parallelism", "size", "source", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -240,7 +240,50 @@ func TestAccStorageBlob_blockFromLocalFileWithContentMd5(t *testing.T) {
 				resource.TestCheckResourceAttr(data.ResourceName, "source", sourceBlob.Name()),
 			),
 		},
-		data.ImportStep("parallelism", "size", "source", "type"),
+		data.ImportStepFromSchema(),
+	})
+}
+
+func TestAccStorageBlob_blockFromLocalFileUpdated(t *testing.T) {
+	sourceBlob, err := os.CreateTemp("", "")
+	if err != nil {
+		t.Fatalf("Failed to create local source blob file")
+	}
+
+	if err := populateTempFile(sourceBlob); err != nil {
+		t.Fatalf("Error populating temp file: %s", err)
+	}
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.contentMd5ForLocalFile(data, sourceBlob.Name()),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("content_md5").Exists(),
+				data.CheckWithClient(r.blobMatchesFile(blobs.BlockBlob, sourceBlob.Name())),
+			),
+		},
+		{
+			// the contents of the file change but the path doesn't, which changes the `content_md5` and triggers a re-upload
+			PreConfig: func() {
+				file, err := os.OpenFile(sourceBlob.Name(), os.O_RDWR, 0600)
+				if err != nil {
+					t.Fatalf("Failed to open local source blob file: %s", err)
+				}
+
+				if err := populateTempFile(file); err != nil {
+					t.Fatalf("Error populating temp file: %s", err)
+				}
+			},
+			Config: r.contentMd5ForLocalFile(data, sourceBlob.Name()),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.blobMatchesFile(blobs.BlockBlob, sourceBlob.Name())),
+			),
+		},
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -255,14 +298,14 @@ func TestAccStorageBlob_contentType(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 		{
 			Config: r.contentTypeUpdated(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -277,7 +320,7 @@ func TestAccStorageBlob_contentTypePremium(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -292,7 +335,7 @@ func TestAccStorageBlob_pageEmpty(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -307,7 +350,7 @@ func TestAccStorageBlob_pageEmptyPremium(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -322,7 +365,7 @@ func TestAccStorageBlob_pageEmptyMetaData(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -337,7 +380,7 @@ func TestAccStorageBlob_pageFromExistingBlob(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type", "source_uri"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -361,7 +404,7 @@ func TestAccStorageBlob_pageFromLocalFile(t *testing.T) {
 				data.CheckWithClient(r.blobMatchesFile(blobs.PageBlob, sourceBlob.Name())),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type", "source"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -391,14 +434,14 @@ func TestAccStorageBlob_update(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 		{
 			Config: r.updateUpdated(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("parallelism", "size", "type"),
+		data.ImportStepFromSchema(),
 	})
 }
 
@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-01-01/storage"
+
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/migration"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -14,6 +17,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/parse"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/containers"
 )
 
@@ -68,7 +72,44 @@ func resourceStorageContainer() *schema.Resource {
 
 			"metadata": MetaDataComputedSchema(),
 
-			// TODO: support for ACL's, Legal Holds and Immutability Policies
+			"immutability_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"immutability_period_in_days": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 146000),
+						},
+
+						"protected_append_writes_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"locked": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+
+			"legal_hold_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MaxItems: 10,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.StorageContainerLegalHoldTag,
+				},
+			},
+
+			// TODO: support for ACL's
 			"has_immutability_policy": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -84,6 +125,36 @@ func resourceStorageContainer() *schema.Resource {
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: func(d *schema.ResourceDiff, v interface{}) error {
+			// once locked an Immutability Policy can only be extended - it can't be unlocked, removed or shortened
+			old, new := d.GetChange("immutability_policy")
+			oldPolicies := old.([]interface{})
+			if len(oldPolicies) == 0 || oldPolicies[0] == nil {
+				return nil
+			}
+			oldPolicy := oldPolicies[0].(map[string]interface{})
+			if !oldPolicy["locked"].(bool) {
+				return nil
+			}
+
+			newPolicies := new.([]interface{})
+			if len(newPolicies) == 0 || newPolicies[0] == nil {
+				return fmt.Errorf("the `immutability_policy` is locked and cannot be removed")
+			}
+			newPolicy := newPolicies[0].(map[string]interface{})
+			if !newPolicy["locked"].(bool) {
+				return fmt.Errorf("the `immutability_policy` is locked and cannot be unlocked")
+			}
+			if newPolicy["immutability_period_in_days"].(int) < oldPolicy["immutability_period_in_days"].(int) {
+				return fmt.Errorf("the `immutability_period_in_days` of a locked `immutability_policy` can only be extended")
+			}
+			if newPolicy["protected_append_writes_enabled"].(bool) != oldPolicy["protected_append_writes_enabled"].(bool) {
+				return fmt.Errorf("`protected_append_writes_enabled` cannot be changed once the `immutability_policy` is locked")
+			}
+
+			return nil
+		},
 	}
 }
 
@@ -133,6 +204,22 @@ func resourceStorageContainerCreate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	d.SetId(id)
+
+	if v := d.Get("immutability_policy").([]interface{}); len(v) > 0 && v[0] != nil {
+		if err := updateStorageContainerImmutabilityPolicy(ctx, storageClient.BlobContainersClient, account.ResourceGroup, accountName, containerName, v[0].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if tags := d.Get("legal_hold_tags").(*schema.Set); tags.Len() > 0 {
+		legalHold := storage.LegalHold{
+			Tags: utils.ExpandStringSlice(tags.List()),
+		}
+		if _, err := storageClient.BlobContainersClient.SetLegalHold(ctx, account.ResourceGroup, accountName, containerName, legalHold); err != nil {
+			return fmt.Errorf("setting the Legal Hold for Container %q (Storage Account %q / Resource Group %q): %+v", containerName, accountName, account.ResourceGroup, err)
+		}
+	}
+
 	return resourceStorageContainerRead(d, meta)
 }
 
@@ -182,6 +269,46 @@ func resourceStorageContainerUpdate(d *schema.ResourceData, meta interface{}) er
 		log.Printf("[DEBUG] Updated the MetaData for Container %q (Storage Account %q / Resource Group %q)", id.Name, id.AccountName, account.ResourceGroup)
 	}
 
+	if d.HasChange("immutability_policy") {
+		log.Printf("[DEBUG] Updating the Immutability Policy for Container %q (Storage Account %q / Resource Group %q)..", id.Name, id.AccountName, account.ResourceGroup)
+		if v := d.Get("immutability_policy").([]interface{}); len(v) > 0 && v[0] != nil {
+			if err := updateStorageContainerImmutabilityPolicy(ctx, storageClient.BlobContainersClient, account.ResourceGroup, id.AccountName, id.Name, v[0].(map[string]interface{})); err != nil {
+				return err
+			}
+		} else {
+			if err := deleteStorageContainerImmutabilityPolicy(ctx, storageClient.BlobContainersClient, account.ResourceGroup, id.AccountName, id.Name); err != nil {
+				return err
+			}
+		}
+		log.Printf("[DEBUG] Updated the Immutability Policy for Container %q (Storage Account %q / Resource Group %q)", id.Name, id.AccountName, account.ResourceGroup)
+	}
+
+	if d.HasChange("legal_hold_tags") {
+		log.Printf("[DEBUG] Updating the Legal Hold for Container %q (Storage Account %q / Resource Group %q)..", id.Name, id.AccountName, account.ResourceGroup)
+		old, new := d.GetChange("legal_hold_tags")
+		oldTags := old.(*schema.Set)
+		newTags := new.(*schema.Set)
+
+		if removed := oldTags.Difference(newTags); removed.Len() > 0 {
+			legalHold := storage.LegalHold{
+				Tags: utils.ExpandStringSlice(removed.List()),
+			}
+			if _, err := storageClient.BlobContainersClient.ClearLegalHold(ctx, account.ResourceGroup, id.AccountName, id.Name, legalHold); err != nil {
+				return fmt.Errorf("clearing the Legal Hold for Container %q (Storage Account %q / Resource Group %q): %+v", id.Name, id.AccountName, account.ResourceGroup, err)
+			}
+		}
+
+		if added := newTags.Difference(oldTags); added.Len() > 0 {
+			legalHold := storage.LegalHold{
+				Tags: utils.ExpandStringSlice(added.List()),
+			}
+			if _, err := storageClient.BlobContainersClient.SetLegalHold(ctx, account.ResourceGroup, id.AccountName, id.Name, legalHold); err != nil {
+				return fmt.Errorf("setting the Legal Hold for Container %q (Storage Account %q / Resource Group %q): %+v", id.Name, id.AccountName, account.ResourceGroup, err)
+			}
+		}
+		log.Printf("[DEBUG] Updated the Legal Hold for Container %q (Storage Account %q / Resource Group %q)", id.Name, id.AccountName, account.ResourceGroup)
+	}
+
 	return resourceStorageContainerRead(d, meta)
 }
 
@@ -232,6 +359,34 @@ func resourceStorageContainerRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("has_immutability_policy", props.HasImmutabilityPolicy)
 	d.Set("has_legal_hold", props.HasLegalHold)
 
+	immutabilityPolicy := make([]interface{}, 0)
+	if props.HasImmutabilityPolicy {
+		policy, err := storageClient.BlobContainersClient.GetImmutabilityPolicy(ctx, account.ResourceGroup, id.AccountName, id.Name, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(policy.Response) {
+				return fmt.Errorf("retrieving the Immutability Policy for Container %q (Account %q / Resource Group %q): %+v", id.Name, id.AccountName, account.ResourceGroup, err)
+			}
+		}
+		immutabilityPolicy = flattenStorageContainerImmutabilityPolicy(policy.ImmutabilityPolicyProperty)
+	}
+	if err := d.Set("immutability_policy", immutabilityPolicy); err != nil {
+		return fmt.Errorf("setting `immutability_policy`: %+v", err)
+	}
+
+	legalHoldTags := make([]interface{}, 0)
+	if props.HasLegalHold {
+		container, err := storageClient.BlobContainersClient.Get(ctx, account.ResourceGroup, id.AccountName, id.Name)
+		if err != nil {
+			return fmt.Errorf("retrieving the Legal Hold for Container %q (Account %q / Resource Group %q): %+v", id.Name, id.AccountName, account.ResourceGroup, err)
+		}
+		if container.ContainerProperties != nil {
+			legalHoldTags = flattenStorageContainerLegalHoldTags(container.ContainerProperties.LegalHold)
+		}
+	}
+	if err := d.Set("legal_hold_tags", legalHoldTags); err != nil {
+		return fmt.Errorf("setting `legal_hold_tags`: %+v", err)
+	}
+
 	resourceManagerId := parse.NewStorageContainerResourceManagerID(subscriptionId, account.ResourceGroup, id.AccountName, "default", id.Name)
 	d.Set("resource_manager_id", resourceManagerId.ID())
 
@@ -260,6 +415,30 @@ func resourceStorageContainerDelete(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("building Containers Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
 	}
 
+	props, err := client.Get(ctx, account.ResourceGroup, id.AccountName, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving Container %q (Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
+	}
+	if props != nil {
+		if props.HasLegalHold {
+			return fmt.Errorf("unable to delete Container %q (Storage Account %q / Resource Group %q) since it has a Legal Hold - the `legal_hold_tags` must be removed first", id.Name, id.AccountName, account.ResourceGroup)
+		}
+
+		if props.HasImmutabilityPolicy {
+			policy, err := storageClient.BlobContainersClient.GetImmutabilityPolicy(ctx, account.ResourceGroup, id.AccountName, id.Name, "")
+			if err != nil && !utils.ResponseWasNotFound(policy.Response) {
+				return fmt.Errorf("retrieving the Immutability Policy for Container %q (Account %q / Resource Group %q): %+v", id.Name, id.AccountName, account.ResourceGroup, err)
+			}
+			if policy.ImmutabilityPolicyProperty != nil && policy.ImmutabilityPolicyProperty.State == storage.Locked {
+				return fmt.Errorf("unable to delete Container %q (Storage Account %q / Resource Group %q) since its Immutability Policy is locked", id.Name, id.AccountName, account.ResourceGroup)
+			}
+
+			if err := deleteStorageContainerImmutabilityPolicy(ctx, storageClient.BlobContainersClient, account.ResourceGroup, id.AccountName, id.Name); err != nil {
+				return err
+			}
+		}
+	}
+
 	if err := client.Delete(ctx, account.ResourceGroup, id.AccountName, id.Name); err != nil {
 		return fmt.Errorf("deleting Container %q (Storage Account %q / Resource Group %q): %s", id.Name, id.AccountName, account.ResourceGroup, err)
 	}
@@ -286,3 +465,114 @@ func flattenStorageContainerAccessLevel(input containers.AccessLevel) string {
 
 	return string(input)
 }
+
+// updateStorageContainerImmutabilityPolicy creates, updates, extends and/or locks the Immutability Policy for the
+// Container - once locked the policy can only be extended, which is enforced within the CustomizeDiff
+func updateStorageContainerImmutabilityPolicy(ctx context.Context, client *storage.BlobContainersClient, resourceGroup, accountName, containerName string, input map[string]interface{}) error {
+	period := int32(input["immutability_period_in_days"].(int))
+	protectedAppendWrites := input["protected_append_writes_enabled"].(bool)
+
+	existing, err := client.GetImmutabilityPolicy(ctx, resourceGroup, accountName, containerName, "")
+	if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+		return fmt.Errorf("retrieving the Immutability Policy for Container %q (Storage Account %q / Resource Group %q): %+v", containerName, accountName, resourceGroup, err)
+	}
+
+	etag := ""
+	if existing.Etag != nil {
+		etag = *existing.Etag
+	}
+
+	if props := existing.ImmutabilityPolicyProperty; props != nil && props.State == storage.Locked {
+		if props.ImmutabilityPeriodSinceCreationInDays != nil && *props.ImmutabilityPeriodSinceCreationInDays == period {
+			return nil
+		}
+
+		parameters := &storage.ImmutabilityPolicy{
+			ImmutabilityPolicyProperty: &storage.ImmutabilityPolicyProperty{
+				ImmutabilityPeriodSinceCreationInDays: utils.Int32(period),
+			},
+		}
+		if _, err := client.ExtendImmutabilityPolicy(ctx, resourceGroup, accountName, containerName, etag, parameters); err != nil {
+			return fmt.Errorf("extending the Immutability Policy for Container %q (Storage Account %q / Resource Group %q): %+v", containerName, accountName, resourceGroup, err)
+		}
+
+		return nil
+	}
+
+	parameters := &storage.ImmutabilityPolicy{
+		ImmutabilityPolicyProperty: &storage.ImmutabilityPolicyProperty{
+			ImmutabilityPeriodSinceCreationInDays: utils.Int32(period),
+			AllowProtectedAppendWrites:            utils.Bool(protectedAppendWrites),
+		},
+	}
+	policy, err := client.CreateOrUpdateImmutabilityPolicy(ctx, resourceGroup, accountName, containerName, parameters, etag)
+	if err != nil {
+		return fmt.Errorf("setting the Immutability Policy for Container %q (Storage Account %q / Resource Group %q): %+v", containerName, accountName, resourceGroup, err)
+	}
+
+	if input["locked"].(bool) {
+		if policy.Etag == nil {
+			return fmt.Errorf("locking the Immutability Policy for Container %q (Storage Account %q / Resource Group %q): `etag` was nil", containerName, accountName, resourceGroup)
+		}
+
+		if _, err := client.LockImmutabilityPolicy(ctx, resourceGroup, accountName, containerName, *policy.Etag); err != nil {
+			return fmt.Errorf("locking the Immutability Policy for Container %q (Storage Account %q / Resource Group %q): %+v", containerName, accountName, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func deleteStorageContainerImmutabilityPolicy(ctx context.Context, client *storage.BlobContainersClient, resourceGroup, accountName, containerName string) error {
+	existing, err := client.GetImmutabilityPolicy(ctx, resourceGroup, accountName, containerName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("retrieving the Immutability Policy for Container %q (Storage Account %q / Resource Group %q): %+v", containerName, accountName, resourceGroup, err)
+	}
+	if existing.Etag == nil {
+		return fmt.Errorf("retrieving the Immutability Policy for Container %q (Storage Account %q / Resource Group %q): `etag` was nil", containerName, accountName, resourceGroup)
+	}
+
+	if _, err := client.DeleteImmutabilityPolicy(ctx, resourceGroup, accountName, containerName, *existing.Etag); err != nil {
+		return fmt.Errorf("deleting the Immutability Policy for Container %q (Storage Account %q / Resource Group %q): %+v", containerName, accountName, resourceGroup, err)
+	}
+
+	return nil
+}
+
+func flattenStorageContainerImmutabilityPolicy(input *storage.ImmutabilityPolicyProperty) []interface{} {
+	if input == nil || input.ImmutabilityPeriodSinceCreationInDays == nil || *input.ImmutabilityPeriodSinceCreationInDays == 0 {
+		return []interface{}{}
+	}
+
+	protectedAppendWrites := false
+	if input.AllowProtectedAppendWrites != nil {
+		protectedAppendWrites = *input.AllowProtectedAppendWrites
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"immutability_period_in_days":     int(*input.ImmutabilityPeriodSinceCreationInDays),
+			"protected_append_writes_enabled": protectedAppendWrites,
+			"locked":                          input.State == storage.Locked,
+		},
+	}
+}
+
+func flattenStorageContainerLegalHoldTags(input *storage.LegalHoldProperties) []interface{} {
+	tags := make([]interface{}, 0)
+	if input == nil || input.Tags == nil {
+		return tags
+	}
+
+	for _, v := range *input.Tags {
+		if v.Tag != nil {
+			tags = append(tags, *v.Tag)
+		}
+	}
+
+	return tags
+}
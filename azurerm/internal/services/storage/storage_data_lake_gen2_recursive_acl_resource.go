@@ -0,0 +1,559 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/datalake"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+	azSchema "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/datalakestore/paths"
+	"github.com/tombuildsstuff/giovanni/storage/accesscontrol"
+)
+
+// dataLakeGen2RecursiveAclMaxFailedEntries is the number of failed Paths which are recorded in the state,
+// since a large directory tree could otherwise produce an unbounded number of failures
+const dataLakeGen2RecursiveAclMaxFailedEntries = 100
+
+func resourceStorageDataLakeGen2RecursiveAcl() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageDataLakeGen2RecursiveAclCreateUpdate,
+		Read:   resourceStorageDataLakeGen2RecursiveAclRead,
+		Update: resourceStorageDataLakeGen2RecursiveAclCreateUpdate,
+		Delete: resourceStorageDataLakeGen2RecursiveAclDelete,
+
+		Importer: azSchema.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := paths.ParseResourceID(id)
+			return err
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageAccountID,
+			},
+
+			"filesystem_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageDataLakeGen2FileSystemName,
+			},
+
+			// the root of the File System is used by default
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "/",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(datalake.AccessControlRecursiveModeModify),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(datalake.AccessControlRecursiveModeSet),
+					string(datalake.AccessControlRecursiveModeModify),
+					string(datalake.AccessControlRecursiveModeRemove),
+				}, false),
+			},
+
+			"ace": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"scope": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"default", "access"}, false),
+							Default:      "access",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"user", "group", "mask", "other"}, false),
+						},
+						"id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						// not used when the `mode` is `remove`
+						"permissions": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.ADLSAccessControlPermissions,
+						},
+					},
+				},
+			},
+
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      datalake.MaxRecordsPerBatch,
+				ValidateFunc: validation.IntBetween(1, datalake.MaxRecordsPerBatch),
+			},
+
+			"continue_on_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"directories_successful": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"files_successful": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"failure_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"failed_entries": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error_message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceStorageDataLakeGen2RecursiveAclCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	accountsClient := meta.(*clients.Client).Storage.AccountsClient
+	pathsClient := meta.(*clients.Client).Storage.ADLSGen2PathsClient
+	client := meta.(*clients.Client).Storage.ADLSGen2ACLRecursiveClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	storageID, err := parse.StorageAccountID(d.Get("storage_account_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// confirm the storage account exists, otherwise Data Plane API requests will fail
+	storageAccount, err := accountsClient.GetProperties(ctx, storageID.ResourceGroup, storageID.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(storageAccount.Response) {
+			return fmt.Errorf("Storage Account %q was not found in Resource Group %q!", storageID.Name, storageID.ResourceGroup)
+		}
+
+		return fmt.Errorf("checking for existence of Storage Account %q (Resource Group %q): %+v", storageID.Name, storageID.ResourceGroup, err)
+	}
+
+	fileSystemName := d.Get("filesystem_name").(string)
+	path := dataLakeGen2RecursiveAclPath(d.Get("path").(string))
+	mode := datalake.AccessControlRecursiveMode(d.Get("mode").(string))
+
+	// the ACLs are only applied to an existing directory tree, which this resource doesn't create
+	resp, err := pathsClient.GetProperties(ctx, storageID.Name, fileSystemName, path, paths.GetPropertiesActionGetStatus)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Path %q was not found in File System %q (Storage Account %q)", d.Get("path").(string), fileSystemName, storageID.Name)
+		}
+
+		return fmt.Errorf("checking for existence of Path %q in File System %q (Storage Account %q): %+v", d.Get("path").(string), fileSystemName, storageID.Name, err)
+	}
+
+	acl, err := expandDataLakeGen2RecursiveAcl(d.Get("ace").(*schema.Set).List(), mode)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Applying the ACL recursively (mode %q) to Path %q in File System %q (Storage Account %q)..", mode, d.Get("path").(string), fileSystemName, storageID.Name)
+	input := datalake.SetAccessControlRecursiveInput{
+		Mode:       mode,
+		ACL:        acl,
+		MaxRecords: d.Get("batch_size").(int),
+		ForceFlag:  d.Get("continue_on_failure").(bool),
+	}
+	summary, err := client.SetAccessControlRecursiveAll(ctx, storageID.Name, fileSystemName, path, input)
+	if err != nil {
+		return fmt.Errorf("applying the ACL recursively to Path %q in File System %q (Storage Account %q): %+v", d.Get("path").(string), fileSystemName, storageID.Name, err)
+	}
+
+	if summary.FailureCount > 0 && !input.ForceFlag {
+		return fmt.Errorf("applying the ACL recursively to Path %q in File System %q (Storage Account %q): the ACL couldn't be applied to %d Paths: %s", d.Get("path").(string), fileSystemName, storageID.Name, summary.FailureCount, dataLakeGen2RecursiveAclFailureSummary(summary.FailedEntries))
+	}
+	if summary.FailureCount > 0 {
+		log.Printf("[WARN] The ACL couldn't be applied to %d Paths beneath Path %q in File System %q (Storage Account %q)", summary.FailureCount, d.Get("path").(string), fileSystemName, storageID.Name)
+	}
+
+	// when modifying the ACL only the configured entries are applied, so any named entries which have been removed
+	// from the configuration (or added outside of Terraform) need to be removed from the directory tree explicitly
+	if !d.IsNewResource() && mode == datalake.AccessControlRecursiveModeModify && d.HasChange("ace") {
+		oldRaw, newRaw := d.GetChange("ace")
+		removed := dataLakeGen2RecursiveAclRemovedNamedEntries(oldRaw.(*schema.Set).List(), newRaw.(*schema.Set).List())
+		if len(removed) > 0 {
+			removeAcl, err := expandDataLakeGen2RecursiveAcl(removed, datalake.AccessControlRecursiveModeRemove)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("[INFO] Removing %d entries recursively from Path %q in File System %q (Storage Account %q)..", len(removed), d.Get("path").(string), fileSystemName, storageID.Name)
+			removeInput := datalake.SetAccessControlRecursiveInput{
+				Mode:       datalake.AccessControlRecursiveModeRemove,
+				ACL:        removeAcl,
+				MaxRecords: input.MaxRecords,
+				ForceFlag:  input.ForceFlag,
+			}
+			removeSummary, err := client.SetAccessControlRecursiveAll(ctx, storageID.Name, fileSystemName, path, removeInput)
+			if err != nil {
+				return fmt.Errorf("removing entries recursively from Path %q in File System %q (Storage Account %q): %+v", d.Get("path").(string), fileSystemName, storageID.Name, err)
+			}
+			if removeSummary.FailureCount > 0 && !removeInput.ForceFlag {
+				return fmt.Errorf("removing entries recursively from Path %q in File System %q (Storage Account %q): the entries couldn't be removed from %d Paths: %s", d.Get("path").(string), fileSystemName, storageID.Name, removeSummary.FailureCount, dataLakeGen2RecursiveAclFailureSummary(removeSummary.FailedEntries))
+			}
+
+			summary.FailureCount += removeSummary.FailureCount
+			summary.FailedEntries = append(summary.FailedEntries, removeSummary.FailedEntries...)
+		}
+	}
+
+	d.SetId(pathsClient.GetResourceID(storageID.Name, fileSystemName, path))
+
+	d.Set("directories_successful", summary.DirectoriesSuccessful)
+	d.Set("files_successful", summary.FilesSuccessful)
+	d.Set("failure_count", summary.FailureCount)
+	if err := d.Set("failed_entries", flattenDataLakeGen2RecursiveAclFailedEntries(summary.FailedEntries)); err != nil {
+		return fmt.Errorf("setting `failed_entries`: %+v", err)
+	}
+
+	return resourceStorageDataLakeGen2RecursiveAclRead(d, meta)
+}
+
+func resourceStorageDataLakeGen2RecursiveAclRead(d *schema.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	client := storageClient.ADLSGen2PathsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := paths.ParseResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Path %q in File System %q: %+v", id.AccountName, id.Path, id.FileSystemName, err)
+	}
+	if account == nil {
+		log.Printf("[INFO] Storage Account %q does not exist - removing from state...", id.AccountName)
+		d.SetId("")
+		return nil
+	}
+
+	// drift is detected using the ACL of the Path at the root of the directory tree, since
+	// reading the ACL of every Path beneath it isn't feasible for large directory trees
+	resp, err := client.GetProperties(ctx, id.AccountName, id.FileSystemName, id.Path, paths.GetPropertiesActionGetAccessControl)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Path %q does not exist in File System %q in Storage Account %q - removing from state...", id.Path, id.FileSystemName, id.AccountName)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving ACLs for Path %q in File System %q in Storage Account %q: %+v", id.Path, id.FileSystemName, id.AccountName, err)
+	}
+
+	acl, err := accesscontrol.ParseACL(resp.ACL)
+	if err != nil {
+		return fmt.Errorf("parsing response ACL %q: %s", resp.ACL, err)
+	}
+
+	d.Set("storage_account_id", account.ID)
+	d.Set("filesystem_name", id.FileSystemName)
+
+	// the Path can be specified with or without a leading slash, so it's only set when it differs (e.g. when importing)
+	if existing := d.Get("path").(string); existing == "" || dataLakeGen2RecursiveAclPath(existing) != id.Path {
+		d.Set("path", "/"+id.Path)
+	}
+
+	// these aren't returned by the API, so the defaults are used when importing
+	if d.Get("mode").(string) == "" {
+		d.Set("mode", string(datalake.AccessControlRecursiveModeModify))
+		d.Set("batch_size", datalake.MaxRecordsPerBatch)
+		d.Set("continue_on_failure", false)
+	}
+
+	mode := datalake.AccessControlRecursiveMode(d.Get("mode").(string))
+	if err := d.Set("ace", flattenDataLakeGen2RecursiveAcl(d.Get("ace").(*schema.Set).List(), acl, mode)); err != nil {
+		return fmt.Errorf("setting `ace`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceStorageDataLakeGen2RecursiveAclDelete(d *schema.ResourceData, meta interface{}) error {
+	pathsClient := meta.(*clients.Client).Storage.ADLSGen2PathsClient
+	client := meta.(*clients.Client).Storage.ADLSGen2ACLRecursiveClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	mode := datalake.AccessControlRecursiveMode(d.Get("mode").(string))
+	if mode == datalake.AccessControlRecursiveModeRemove {
+		log.Printf("[DEBUG] The ACL was removed from the directory tree - nothing to do")
+		return nil
+	}
+
+	id, err := paths.ParseResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// the entries for the owning user & group, the mask and other can't be removed - so only the named entries are
+	named := make([]interface{}, 0)
+	for _, raw := range d.Get("ace").(*schema.Set).List() {
+		if v := raw.(map[string]interface{}); v["id"].(string) != "" {
+			named = append(named, v)
+		}
+	}
+	if len(named) == 0 {
+		log.Printf("[DEBUG] No named entries were applied to the directory tree - nothing to remove")
+		return nil
+	}
+
+	resp, err := pathsClient.GetProperties(ctx, id.AccountName, id.FileSystemName, id.Path, paths.GetPropertiesActionGetStatus)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil
+		}
+
+		return fmt.Errorf("checking for existence of Path %q in File System %q (Storage Account %q): %+v", id.Path, id.FileSystemName, id.AccountName, err)
+	}
+
+	acl, err := expandDataLakeGen2RecursiveAcl(named, datalake.AccessControlRecursiveModeRemove)
+	if err != nil {
+		return err
+	}
+
+	input := datalake.SetAccessControlRecursiveInput{
+		Mode:       datalake.AccessControlRecursiveModeRemove,
+		ACL:        acl,
+		MaxRecords: d.Get("batch_size").(int),
+		ForceFlag:  d.Get("continue_on_failure").(bool),
+	}
+	summary, err := client.SetAccessControlRecursiveAll(ctx, id.AccountName, id.FileSystemName, id.Path, input)
+	if err != nil {
+		return fmt.Errorf("removing the ACL recursively from Path %q in File System %q (Storage Account %q): %+v", id.Path, id.FileSystemName, id.AccountName, err)
+	}
+	if summary.FailureCount > 0 && !input.ForceFlag {
+		return fmt.Errorf("removing the ACL recursively from Path %q in File System %q (Storage Account %q): the ACL couldn't be removed from %d Paths: %s", id.Path, id.FileSystemName, id.AccountName, summary.FailureCount, dataLakeGen2RecursiveAclFailureSummary(summary.FailedEntries))
+	}
+
+	return nil
+}
+
+// dataLakeGen2RecursiveAclPath returns the path used by the Data Plane API, where the root of the File System is empty
+func dataLakeGen2RecursiveAclPath(input string) string {
+	return strings.TrimPrefix(input, "/")
+}
+
+func dataLakeGen2AceKey(scope, tagType, id string) string {
+	return fmt.Sprintf("%s:%s:%s", scope, tagType, id)
+}
+
+// expandDataLakeGen2RecursiveAcl builds the ACL string for the specified mode, where the permissions are omitted
+// from the entries which are removed
+func expandDataLakeGen2RecursiveAcl(input []interface{}, mode datalake.AccessControlRecursiveMode) (string, error) {
+	entries := make([]string, 0)
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+		scope := v["scope"].(string)
+		tagType := v["type"].(string)
+		id := v["id"].(string)
+		permissions := v["permissions"].(string)
+
+		if id != "" && (tagType == string(accesscontrol.TagTypeMask) || tagType == string(accesscontrol.TagTypeOther)) {
+			return "", fmt.Errorf("an `id` cannot be specified for an `ace` with the type %q", tagType)
+		}
+
+		prefix := ""
+		if scope == "default" {
+			prefix = "default:"
+		}
+
+		if mode == datalake.AccessControlRecursiveModeRemove {
+			if permissions != "" {
+				return "", fmt.Errorf("`permissions` cannot be specified for an `ace` when the `mode` is `remove`")
+			}
+			entries = append(entries, fmt.Sprintf("%s%s:%s", prefix, tagType, id))
+			continue
+		}
+
+		if permissions == "" {
+			return "", fmt.Errorf("`permissions` must be specified for each `ace` when the `mode` is %q", string(mode))
+		}
+		entries = append(entries, fmt.Sprintf("%s%s:%s:%s", prefix, tagType, id, permissions))
+	}
+
+	return strings.Join(entries, ","), nil
+}
+
+// flattenDataLakeGen2RecursiveAcl compares the configured entries with the ACL of the root Path - returning the entries
+// which match the ACL, so that any differences are shown as drift
+func flattenDataLakeGen2RecursiveAcl(configured []interface{}, acl accesscontrol.ACL, mode datalake.AccessControlRecursiveMode) []interface{} {
+	actual := FlattenDataLakeGen2AceList(acl)
+
+	configuredKeys := make(map[string]struct{})
+	for _, raw := range configured {
+		v := raw.(map[string]interface{})
+		configuredKeys[dataLakeGen2AceKey(v["scope"].(string), v["type"].(string), v["id"].(string))] = struct{}{}
+	}
+
+	// when the ACL is set the root Path should have exactly the configured entries - other than the `mask`, which
+	// is computed by the service when named entries are present and as such is only returned when it's configured
+	if mode == datalake.AccessControlRecursiveModeSet {
+		output := make([]interface{}, 0)
+		for _, raw := range actual {
+			v := raw.(map[string]interface{})
+			if v["type"].(string) == string(accesscontrol.TagTypeMask) {
+				if _, ok := configuredKeys[dataLakeGen2AceKey(v["scope"].(string), v["type"].(string), v["id"].(string))]; !ok {
+					continue
+				}
+			}
+			output = append(output, v)
+		}
+		return output
+	}
+
+	existing := make(map[string]map[string]interface{})
+	for _, raw := range actual {
+		v := raw.(map[string]interface{})
+		existing[dataLakeGen2AceKey(v["scope"].(string), v["type"].(string), v["id"].(string))] = v
+	}
+
+	output := make([]interface{}, 0)
+	for _, raw := range configured {
+		v := raw.(map[string]interface{})
+		match, found := existing[dataLakeGen2AceKey(v["scope"].(string), v["type"].(string), v["id"].(string))]
+
+		// when removing entries, any entries which remain on the root Path are drift
+		if mode == datalake.AccessControlRecursiveModeRemove {
+			if !found {
+				output = append(output, v)
+			}
+			continue
+		}
+
+		if found {
+			output = append(output, match)
+		}
+	}
+
+	// when modifying the ACL any named entries which aren't configured are drift, since these are removed during
+	// an update - whereas the entries for the owning user & group, the mask and other always exist
+	if mode == datalake.AccessControlRecursiveModeModify {
+		for _, raw := range actual {
+			v := raw.(map[string]interface{})
+			if v["id"].(string) == "" {
+				continue
+			}
+			if _, ok := configuredKeys[dataLakeGen2AceKey(v["scope"].(string), v["type"].(string), v["id"].(string))]; !ok {
+				output = append(output, v)
+			}
+		}
+	}
+
+	return output
+}
+
+// dataLakeGen2RecursiveAclRemovedNamedEntries returns the named entries which exist in `old` but not in `new`
+func dataLakeGen2RecursiveAclRemovedNamedEntries(old, new []interface{}) []interface{} {
+	keys := make(map[string]struct{})
+	for _, raw := range new {
+		v := raw.(map[string]interface{})
+		keys[dataLakeGen2AceKey(v["scope"].(string), v["type"].(string), v["id"].(string))] = struct{}{}
+	}
+
+	output := make([]interface{}, 0)
+	for _, raw := range old {
+		v := raw.(map[string]interface{})
+		if v["id"].(string) == "" {
+			continue
+		}
+		if _, ok := keys[dataLakeGen2AceKey(v["scope"].(string), v["type"].(string), v["id"].(string))]; ok {
+			continue
+		}
+
+		// the permissions can't be specified when removing entries
+		output = append(output, map[string]interface{}{
+			"scope":       v["scope"],
+			"type":        v["type"],
+			"id":          v["id"],
+			"permissions": "",
+		})
+	}
+	return output
+}
+
+func flattenDataLakeGen2RecursiveAclFailedEntries(input []datalake.AccessControlFailedEntry) []interface{} {
+	output := make([]interface{}, 0)
+	for i, v := range input {
+		if i >= dataLakeGen2RecursiveAclMaxFailedEntries {
+			break
+		}
+
+		output = append(output, map[string]interface{}{
+			"name":          v.Name,
+			"type":          v.Type,
+			"error_message": v.ErrorMessage,
+		})
+	}
+	return output
+}
+
+func dataLakeGen2RecursiveAclFailureSummary(input []datalake.AccessControlFailedEntry) string {
+	failures := make([]string, 0)
+	for i, v := range input {
+		if i >= 10 {
+			failures = append(failures, fmt.Sprintf("and %d more", len(input)-i))
+			break
+		}
+
+		failures = append(failures, fmt.Sprintf("%q (%s): %s", v.Name, v.Type, v.ErrorMessage))
+	}
+	return strings.Join(failures, ", ")
+}
@@ -0,0 +1,318 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/datalakestore/paths"
+)
+
+type StorageDataLakeGen2RecursiveAclResource struct{}
+
+func TestAccStorageDataLakeGen2RecursiveAcl_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_data_lake_gen2_recursive_acl", "test")
+	r := StorageDataLakeGen2RecursiveAclResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("directories_successful").HasValue("3"),
+				check.That(data.ResourceName).Key("failure_count").HasValue("0"),
+			),
+		},
+		// the results of the last apply aren't returned by the API
+		data.ImportStep("directories_successful", "files_successful", "failure_count", "failed_entries"),
+	})
+}
+
+func TestAccStorageDataLakeGen2RecursiveAcl_set(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_data_lake_gen2_recursive_acl", "test")
+	r := StorageDataLakeGen2RecursiveAclResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.set(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("directories_successful").HasValue("2"),
+				check.That(data.ResourceName).Key("ace.#").HasValue("5"),
+			),
+		},
+	})
+}
+
+func TestAccStorageDataLakeGen2RecursiveAcl_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_data_lake_gen2_recursive_acl", "test")
+	r := StorageDataLakeGen2RecursiveAclResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config: r.basicUpdated(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ace.#").HasValue("2"),
+			),
+		},
+		{
+			// the `default` entry should be removed from the directory tree
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ace.#").HasValue("1"),
+			),
+		},
+		{
+			Config: r.remove(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func TestAccStorageDataLakeGen2RecursiveAcl_pathNotFound(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_data_lake_gen2_recursive_acl", "test")
+	r := StorageDataLakeGen2RecursiveAclResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.pathNotFound(data),
+			ExpectError: regexp.MustCompile("was not found in File System"),
+		},
+	})
+}
+
+func (r StorageDataLakeGen2RecursiveAclResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	id, err := paths.ParseResourceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Storage.ADLSGen2PathsClient.GetProperties(ctx, id.AccountName, id.FileSystemName, id.Path, paths.GetPropertiesActionGetAccessControl)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving ACLs for Path %q (File System %q / Account %q): %+v", id.Path, id.FileSystemName, id.AccountName, err)
+	}
+	return utils.Bool(true), nil
+}
+
+func (r StorageDataLakeGen2RecursiveAclResource) basic(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_data_lake_gen2_recursive_acl" "test" {
+  storage_account_id = azurerm_storage_account.test.id
+  filesystem_name    = azurerm_storage_data_lake_gen2_filesystem.test.name
+
+  ace {
+    type        = "user"
+    id          = azuread_service_principal.test.object_id
+    permissions = "r-x"
+  }
+
+  depends_on = [
+    azurerm_storage_data_lake_gen2_path.child,
+  ]
+}
+`, template)
+}
+
+func (r StorageDataLakeGen2RecursiveAclResource) basicUpdated(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_data_lake_gen2_recursive_acl" "test" {
+  storage_account_id = azurerm_storage_account.test.id
+  filesystem_name    = azurerm_storage_data_lake_gen2_filesystem.test.name
+  batch_size         = 1
+
+  ace {
+    type        = "user"
+    id          = azuread_service_principal.test.object_id
+    permissions = "rwx"
+  }
+
+  ace {
+    scope       = "default"
+    type        = "user"
+    id          = azuread_service_principal.test.object_id
+    permissions = "r-x"
+  }
+
+  depends_on = [
+    azurerm_storage_data_lake_gen2_path.child,
+  ]
+}
+`, template)
+}
+
+func (r StorageDataLakeGen2RecursiveAclResource) remove(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_data_lake_gen2_recursive_acl" "test" {
+  storage_account_id = azurerm_storage_account.test.id
+  filesystem_name    = azurerm_storage_data_lake_gen2_filesystem.test.name
+  mode               = "remove"
+
+  ace {
+    type = "user"
+    id   = azuread_service_principal.test.object_id
+  }
+
+  ace {
+    scope = "default"
+    type  = "user"
+    id    = azuread_service_principal.test.object_id
+  }
+
+  depends_on = [
+    azurerm_storage_data_lake_gen2_path.child,
+  ]
+}
+`, template)
+}
+
+func (r StorageDataLakeGen2RecursiveAclResource) set(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_data_lake_gen2_recursive_acl" "test" {
+  storage_account_id  = azurerm_storage_account.test.id
+  filesystem_name     = azurerm_storage_data_lake_gen2_filesystem.test.name
+  path                = azurerm_storage_data_lake_gen2_path.parent.path
+  mode                = "set"
+  continue_on_failure = true
+
+  ace {
+    type        = "user"
+    permissions = "rwx"
+  }
+  ace {
+    type        = "user"
+    id          = azuread_service_principal.test.object_id
+    permissions = "r-x"
+  }
+  ace {
+    type        = "group"
+    permissions = "r-x"
+  }
+  ace {
+    type        = "mask"
+    permissions = "r-x"
+  }
+  ace {
+    type        = "other"
+    permissions = "---"
+  }
+
+  depends_on = [
+    azurerm_storage_data_lake_gen2_path.child,
+  ]
+}
+`, template)
+}
+
+func (r StorageDataLakeGen2RecursiveAclResource) pathNotFound(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_data_lake_gen2_recursive_acl" "test" {
+  storage_account_id = azurerm_storage_account.test.id
+  filesystem_name    = azurerm_storage_data_lake_gen2_filesystem.test.name
+  path               = "doesnotexist"
+
+  ace {
+    type        = "user"
+    id          = azuread_service_principal.test.object_id
+    permissions = "r-x"
+  }
+}
+`, template)
+}
+
+func (r StorageDataLakeGen2RecursiveAclResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+provider "azuread" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_kind             = "BlobStorage"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+  is_hns_enabled           = true
+}
+
+data "azurerm_client_config" "current" {
+}
+
+resource "azurerm_role_assignment" "storageAccountRoleAssignment" {
+  scope                = azurerm_storage_account.test.id
+  role_definition_name = "Storage Blob Data Owner"
+  principal_id         = data.azurerm_client_config.current.object_id
+}
+
+resource "azuread_application" "test" {
+  name = "acctestspa%[1]d"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = azuread_application.test.application_id
+}
+
+resource "azurerm_storage_data_lake_gen2_filesystem" "test" {
+  name               = "fstest"
+  storage_account_id = azurerm_storage_account.test.id
+  depends_on = [
+    azurerm_role_assignment.storageAccountRoleAssignment
+  ]
+}
+
+resource "azurerm_storage_data_lake_gen2_path" "parent" {
+  storage_account_id = azurerm_storage_account.test.id
+  filesystem_name    = azurerm_storage_data_lake_gen2_filesystem.test.name
+  path               = "parent"
+  resource           = "directory"
+}
+
+resource "azurerm_storage_data_lake_gen2_path" "child" {
+  storage_account_id = azurerm_storage_account.test.id
+  filesystem_name    = azurerm_storage_data_lake_gen2_filesystem.test.name
+  path               = "${azurerm_storage_data_lake_gen2_path.parent.path}/child"
+  resource           = "directory"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/datalake"
+	"github.com/tombuildsstuff/giovanni/storage/accesscontrol"
+)
+
+func dataLakeGen2RecursiveAclTestAce(scope, tagType, id, permissions string) map[string]interface{} {
+	return map[string]interface{}{
+		"scope":       scope,
+		"type":        tagType,
+		"id":          id,
+		"permissions": permissions,
+	}
+}
+
+func TestExpandDataLakeGen2RecursiveAcl(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Mode     datalake.AccessControlRecursiveMode
+		Expected string
+		Error    bool
+	}{
+		{
+			Name: "modify",
+			Input: []interface{}{
+				dataLakeGen2RecursiveAclTestAce("access", "user", "00000000-0000-0000-0000-000000000001", "r-x"),
+				dataLakeGen2RecursiveAclTestAce("default", "group", "00000000-0000-0000-0000-000000000002", "rwx"),
+			},
+			Mode:     datalake.AccessControlRecursiveModeModify,
+			Expected: "user:00000000-0000-0000-0000-000000000001:r-x,default:group:00000000-0000-0000-0000-000000000002:rwx",
+		},
+		{
+			Name: "set with unnamed entries",
+			Input: []interface{}{
+				dataLakeGen2RecursiveAclTestAce("access", "user", "", "rwx"),
+				dataLakeGen2RecursiveAclTestAce("access", "other", "", "---"),
+			},
+			Mode:     datalake.AccessControlRecursiveModeSet,
+			Expected: "user::rwx,other::---",
+		},
+		{
+			Name: "remove omits the permissions",
+			Input: []interface{}{
+				dataLakeGen2RecursiveAclTestAce("default", "user", "00000000-0000-0000-0000-000000000001", ""),
+			},
+			Mode:     datalake.AccessControlRecursiveModeRemove,
+			Expected: "default:user:00000000-0000-0000-0000-000000000001",
+		},
+		{
+			Name: "remove with permissions",
+			Input: []interface{}{
+				dataLakeGen2RecursiveAclTestAce("access", "user", "00000000-0000-0000-0000-000000000001", "r-x"),
+			},
+			Mode:  datalake.AccessControlRecursiveModeRemove,
+			Error: true,
+		},
+		{
+			Name: "modify without permissions",
+			Input: []interface{}{
+				dataLakeGen2RecursiveAclTestAce("access", "user", "00000000-0000-0000-0000-000000000001", ""),
+			},
+			Mode:  datalake.AccessControlRecursiveModeModify,
+			Error: true,
+		},
+		{
+			Name: "named mask",
+			Input: []interface{}{
+				dataLakeGen2RecursiveAclTestAce("access", "mask", "00000000-0000-0000-0000-000000000001", "r-x"),
+			},
+			Mode:  datalake.AccessControlRecursiveModeModify,
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := expandDataLakeGen2RecursiveAcl(v.Input, v.Mode)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("Expected an error but got %q", actual)
+		}
+
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestFlattenDataLakeGen2RecursiveAcl(t *testing.T) {
+	acl, err := accesscontrol.ParseACL("user::rwx,user:00000000-0000-0000-0000-000000000001:r-x,group::r-x,group:00000000-0000-0000-0000-000000000002:r--,mask::r-x,other::---")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	configured := []interface{}{
+		dataLakeGen2RecursiveAclTestAce("access", "user", "00000000-0000-0000-0000-000000000001", ""),
+		dataLakeGen2RecursiveAclTestAce("default", "user", "00000000-0000-0000-0000-000000000001", ""),
+	}
+
+	testData := []struct {
+		Name       string
+		Mode       datalake.AccessControlRecursiveMode
+		Configured []interface{}
+		Expected   []map[string]interface{}
+	}{
+		{
+			Name:       "set without a mask",
+			Mode:       datalake.AccessControlRecursiveModeSet,
+			Configured: configured,
+			Expected: []map[string]interface{}{
+				dataLakeGen2RecursiveAclTestAce("access", "user", "", "rwx"),
+				dataLakeGen2RecursiveAclTestAce("access", "user", "00000000-0000-0000-0000-000000000001", "r-x"),
+				dataLakeGen2RecursiveAclTestAce("access", "group", "", "r-x"),
+				dataLakeGen2RecursiveAclTestAce("access", "group", "00000000-0000-0000-0000-000000000002", "r--"),
+				dataLakeGen2RecursiveAclTestAce("access", "other", "", "---"),
+			},
+		},
+		{
+			Name: "set with a mask",
+			Mode: datalake.AccessControlRecursiveModeSet,
+			Configured: []interface{}{
+				dataLakeGen2RecursiveAclTestAce("access", "mask", "", "r-x"),
+			},
+			Expected: []map[string]interface{}{
+				dataLakeGen2RecursiveAclTestAce("access", "user", "", "rwx"),
+				dataLakeGen2RecursiveAclTestAce("access", "user", "00000000-0000-0000-0000-000000000001", "r-x"),
+				dataLakeGen2RecursiveAclTestAce("access", "group", "", "r-x"),
+				dataLakeGen2RecursiveAclTestAce("access", "group", "00000000-0000-0000-0000-000000000002", "r--"),
+				dataLakeGen2RecursiveAclTestAce("access", "mask", "", "r-x"),
+				dataLakeGen2RecursiveAclTestAce("access", "other", "", "---"),
+			},
+		},
+		{
+			Name:       "modify",
+			Mode:       datalake.AccessControlRecursiveModeModify,
+			Configured: configured,
+			Expected: []map[string]interface{}{
+				dataLakeGen2RecursiveAclTestAce("access", "user", "00000000-0000-0000-0000-000000000001", "r-x"),
+				dataLakeGen2RecursiveAclTestAce("access", "group", "00000000-0000-0000-0000-000000000002", "r--"),
+			},
+		},
+		{
+			Name:       "remove",
+			Mode:       datalake.AccessControlRecursiveModeRemove,
+			Configured: configured,
+			Expected: []map[string]interface{}{
+				dataLakeGen2RecursiveAclTestAce("default", "user", "00000000-0000-0000-0000-000000000001", ""),
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := flattenDataLakeGen2RecursiveAcl(v.Configured, acl, v.Mode)
+		if len(actual) != len(v.Expected) {
+			t.Fatalf("Expected %d entries but got %d: %+v", len(v.Expected), len(actual), actual)
+		}
+		for i, expected := range v.Expected {
+			entry := actual[i].(map[string]interface{})
+			for _, key := range []string{"scope", "type", "id", "permissions"} {
+				if entry[key] != expected[key] {
+					t.Fatalf("Expected entry %d to have %s %q but got %q", i, key, expected[key], entry[key])
+				}
+			}
+		}
+	}
+}
+
+func TestDataLakeGen2RecursiveAclRemovedNamedEntries(t *testing.T) {
+	old := []interface{}{
+		dataLakeGen2RecursiveAclTestAce("access", "user", "", "rwx"),
+		dataLakeGen2RecursiveAclTestAce("access", "user", "00000000-0000-0000-0000-000000000001", "r-x"),
+		dataLakeGen2RecursiveAclTestAce("default", "user", "00000000-0000-0000-0000-000000000001", "r-x"),
+		dataLakeGen2RecursiveAclTestAce("access", "group", "00000000-0000-0000-0000-000000000002", "r--"),
+	}
+	new := []interface{}{
+		dataLakeGen2RecursiveAclTestAce("access", "user", "00000000-0000-0000-0000-000000000001", "rwx"),
+	}
+
+	actual := dataLakeGen2RecursiveAclRemovedNamedEntries(old, new)
+	expected := []map[string]interface{}{
+		dataLakeGen2RecursiveAclTestAce("default", "user", "00000000-0000-0000-0000-000000000001", ""),
+		dataLakeGen2RecursiveAclTestAce("access", "group", "00000000-0000-0000-0000-000000000002", ""),
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d entries but got %d: %+v", len(expected), len(actual), actual)
+	}
+	for i, v := range expected {
+		entry := actual[i].(map[string]interface{})
+		for _, key := range []string{"scope", "type", "id", "permissions"} {
+			if entry[key] != v[key] {
+				t.Fatalf("Expected entry %d to have %s %q but got %q", i, key, v[key], entry[key])
+			}
+		}
+	}
+}
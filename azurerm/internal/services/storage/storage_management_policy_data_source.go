@@ -78,6 +78,22 @@ func dataSourceStorageManagementPolicy() *schema.Resource {
 													Type:     schema.TypeInt,
 													Computed: true,
 												},
+												"tier_to_cool_after_days_since_last_access_time_greater_than": {
+													Type:     schema.TypeInt,
+													Computed: true,
+												},
+												"tier_to_archive_after_days_since_last_access_time_greater_than": {
+													Type:     schema.TypeInt,
+													Computed: true,
+												},
+												"delete_after_days_since_last_access_time_greater_than": {
+													Type:     schema.TypeInt,
+													Computed: true,
+												},
+												"auto_tier_to_hot_from_cool_enabled": {
+													Type:     schema.TypeBool,
+													Computed: true,
+												},
 											},
 										},
 									},
@@ -93,6 +109,26 @@ func dataSourceStorageManagementPolicy() *schema.Resource {
 											},
 										},
 									},
+									"version": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"change_tier_to_cool_after_days_since_creation": {
+													Type:     schema.TypeInt,
+													Computed: true,
+												},
+												"change_tier_to_archive_after_days_since_creation": {
+													Type:     schema.TypeInt,
+													Computed: true,
+												},
+												"delete_after_days_since_creation": {
+													Type:     schema.TypeInt,
+													Computed: true,
+												},
+											},
+										},
+									},
 								},
 							},
 						},
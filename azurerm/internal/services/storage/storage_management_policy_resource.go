@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"time"
@@ -114,6 +115,26 @@ func resourceStorageManagementPolicy() *schema.Resource {
 													Default:      nil,
 													ValidateFunc: validation.IntAtLeast(0),
 												},
+												"tier_to_cool_after_days_since_last_access_time_greater_than": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ValidateFunc: validation.IntAtLeast(0),
+												},
+												"tier_to_archive_after_days_since_last_access_time_greater_than": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ValidateFunc: validation.IntAtLeast(0),
+												},
+												"delete_after_days_since_last_access_time_greater_than": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ValidateFunc: validation.IntAtLeast(0),
+												},
+												"auto_tier_to_hot_from_cool_enabled": {
+													Type:     schema.TypeBool,
+													Optional: true,
+													Default:  false,
+												},
 											},
 										},
 									},
@@ -131,6 +152,30 @@ func resourceStorageManagementPolicy() *schema.Resource {
 											},
 										},
 									},
+									"version": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"change_tier_to_cool_after_days_since_creation": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ValidateFunc: validation.IntAtLeast(0),
+												},
+												"change_tier_to_archive_after_days_since_creation": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ValidateFunc: validation.IntAtLeast(0),
+												},
+												"delete_after_days_since_creation": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ValidateFunc: validation.IntAtLeast(0),
+												},
+											},
+										},
+									},
 								},
 							},
 						},
@@ -166,6 +211,11 @@ func resourceStorageManagementPolicyCreateOrUpdate(d *schema.ResourceData, meta
 		return fmt.Errorf("Error expanding Azure Storage Management Policy Rules %q: %+v", storageAccountId, err)
 	}
 
+	blobServicesClient := meta.(*clients.Client).Storage.BlobServicesClient
+	if err := validateStorageManagementPolicyDependencies(ctx, blobServicesClient, resourceGroupName, storageAccountName, armRules); err != nil {
+		return err
+	}
+
 	parameters.ManagementPolicyProperties = &storage.ManagementPolicyProperties{
 		Policy: &storage.ManagementPolicySchema{
 			Rules: armRules,
@@ -242,7 +292,57 @@ func resourceStorageManagementPolicyDelete(d *schema.ResourceData, meta interfac
 	return nil
 }
 
-// nolint unparam
+// validateStorageManagementPolicyDependencies validates that the Blob Service features required by the rules are
+// enabled on the Storage Account - since these are otherwise silently ignored (or rejected with an unclear error)
+func validateStorageManagementPolicyDependencies(ctx context.Context, client *storage.BlobServicesClient, resourceGroupName, storageAccountName string, rules *[]storage.ManagementPolicyRule) error {
+	usesLastAccessTime := false
+	usesVersions := false
+	for _, rule := range *rules {
+		if rule.Definition == nil || rule.Definition.Actions == nil {
+			continue
+		}
+
+		if baseBlob := rule.Definition.Actions.BaseBlob; baseBlob != nil {
+			for _, v := range []*storage.DateAfterModification{baseBlob.TierToCool, baseBlob.TierToArchive, baseBlob.Delete} {
+				if v != nil && v.DaysAfterLastAccessTimeGreaterThan != nil {
+					usesLastAccessTime = true
+				}
+			}
+		}
+		if rule.Definition.Actions.Version != nil {
+			usesVersions = true
+		}
+	}
+
+	if !usesLastAccessTime && !usesVersions {
+		return nil
+	}
+
+	props, err := client.GetServiceProperties(ctx, resourceGroupName, storageAccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving the Blob Service Properties for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+	if props.BlobServicePropertiesProperties == nil {
+		return fmt.Errorf("retrieving the Blob Service Properties for Storage Account %q (Resource Group %q): `properties` was nil", storageAccountName, resourceGroupName)
+	}
+
+	if usesLastAccessTime {
+		policy := props.BlobServicePropertiesProperties.LastAccessTimeTrackingPolicy
+		if policy == nil || policy.Enable == nil || !*policy.Enable {
+			return fmt.Errorf("rules based on the last access time require `last_access_time_enabled` to be set to `true` within the `blob_properties` block of the Storage Account %q (Resource Group %q)", storageAccountName, resourceGroupName)
+		}
+	}
+
+	if usesVersions {
+		enabled := props.BlobServicePropertiesProperties.IsVersioningEnabled
+		if enabled == nil || !*enabled {
+			return fmt.Errorf("rules containing a `version` block require `versioning_enabled` to be set to `true` within the `blob_properties` block of the Storage Account %q (Resource Group %q)", storageAccountName, resourceGroupName)
+		}
+	}
+
+	return nil
+}
+
 func expandStorageManagementPolicyRules(d *schema.ResourceData) (*[]storage.ManagementPolicyRule, error) {
 	var result []storage.ManagementPolicyRule
 
@@ -250,13 +350,17 @@ func expandStorageManagementPolicyRules(d *schema.ResourceData) (*[]storage.Mana
 
 	for k, v := range rules {
 		if v != nil {
-			result = append(result, expandStorageManagementPolicyRule(d, k))
+			rule, err := expandStorageManagementPolicyRule(d, k)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, *rule)
 		}
 	}
 	return &result, nil
 }
 
-func expandStorageManagementPolicyRule(d *schema.ResourceData, ruleIndex int) storage.ManagementPolicyRule {
+func expandStorageManagementPolicyRule(d *schema.ResourceData, ruleIndex int) (*storage.ManagementPolicyRule, error) {
 	name := d.Get(fmt.Sprintf("rule.%d.name", ruleIndex)).(string)
 	enabled := d.Get(fmt.Sprintf("rule.%d.enabled", ruleIndex)).(bool)
 	typeVal := "Lifecycle"
@@ -292,26 +396,37 @@ func expandStorageManagementPolicyRule(d *schema.ResourceData, ruleIndex int) st
 	if _, ok := d.GetOk(fmt.Sprintf("rule.%d.actions", ruleIndex)); ok {
 		if _, ok := d.GetOk(fmt.Sprintf("rule.%d.actions.0.base_blob", ruleIndex)); ok {
 			baseBlob := &storage.ManagementPolicyBaseBlob{}
-			if v, ok := d.GetOk(fmt.Sprintf("rule.%d.actions.0.base_blob.0.tier_to_cool_after_days_since_modification_greater_than", ruleIndex)); ok {
-				if v != nil {
-					baseBlob.TierToCool = &storage.DateAfterModification{
-						DaysAfterModificationGreaterThan: utils.Float(float64(v.(int))),
+			for _, action := range []struct {
+				name   string
+				target **storage.DateAfterModification
+			}{
+				{name: "tier_to_cool", target: &baseBlob.TierToCool},
+				{name: "tier_to_archive", target: &baseBlob.TierToArchive},
+				{name: "delete", target: &baseBlob.Delete},
+			} {
+				sinceModification, hasSinceModification := d.GetOk(fmt.Sprintf("rule.%d.actions.0.base_blob.0.%s_after_days_since_modification_greater_than", ruleIndex, action.name))
+				sinceLastAccess, hasSinceLastAccess := d.GetOk(fmt.Sprintf("rule.%d.actions.0.base_blob.0.%s_after_days_since_last_access_time_greater_than", ruleIndex, action.name))
+				if hasSinceModification && hasSinceLastAccess {
+					return nil, fmt.Errorf("only one of `%[1]s_after_days_since_modification_greater_than` and `%[1]s_after_days_since_last_access_time_greater_than` can be specified for the rule %q", action.name, name)
+				}
+
+				if hasSinceModification {
+					*action.target = &storage.DateAfterModification{
+						DaysAfterModificationGreaterThan: utils.Float(float64(sinceModification.(int))),
 					}
 				}
-			}
-			if v, ok := d.GetOk(fmt.Sprintf("rule.%d.actions.0.base_blob.0.tier_to_archive_after_days_since_modification_greater_than", ruleIndex)); ok {
-				if v != nil {
-					baseBlob.TierToArchive = &storage.DateAfterModification{
-						DaysAfterModificationGreaterThan: utils.Float(float64(v.(int))),
+				if hasSinceLastAccess {
+					*action.target = &storage.DateAfterModification{
+						DaysAfterLastAccessTimeGreaterThan: utils.Float(float64(sinceLastAccess.(int))),
 					}
 				}
 			}
-			if v, ok := d.GetOk(fmt.Sprintf("rule.%d.actions.0.base_blob.0.delete_after_days_since_modification_greater_than", ruleIndex)); ok {
-				if v != nil {
-					baseBlob.Delete = &storage.DateAfterModification{
-						DaysAfterModificationGreaterThan: utils.Float(float64(v.(int))),
-					}
+
+			if d.Get(fmt.Sprintf("rule.%d.actions.0.base_blob.0.auto_tier_to_hot_from_cool_enabled", ruleIndex)).(bool) {
+				if baseBlob.TierToCool == nil || baseBlob.TierToCool.DaysAfterLastAccessTimeGreaterThan == nil {
+					return nil, fmt.Errorf("`auto_tier_to_hot_from_cool_enabled` can only be set when `tier_to_cool_after_days_since_last_access_time_greater_than` is specified for the rule %q", name)
 				}
+				baseBlob.EnableAutoTierToHotFromCool = utils.Bool(true)
 			}
 			definition.Actions.BaseBlob = baseBlob
 		}
@@ -324,6 +439,20 @@ func expandStorageManagementPolicyRule(d *schema.ResourceData, ruleIndex int) st
 			}
 			definition.Actions.Snapshot = snapshot
 		}
+
+		if _, ok := d.GetOk(fmt.Sprintf("rule.%d.actions.0.version", ruleIndex)); ok {
+			version := &storage.ManagementPolicyVersion{}
+			if v, ok := d.GetOk(fmt.Sprintf("rule.%d.actions.0.version.0.change_tier_to_cool_after_days_since_creation", ruleIndex)); ok {
+				version.TierToCool = &storage.DateAfterCreation{DaysAfterCreationGreaterThan: utils.Float(float64(v.(int)))}
+			}
+			if v, ok := d.GetOk(fmt.Sprintf("rule.%d.actions.0.version.0.change_tier_to_archive_after_days_since_creation", ruleIndex)); ok {
+				version.TierToArchive = &storage.DateAfterCreation{DaysAfterCreationGreaterThan: utils.Float(float64(v.(int)))}
+			}
+			if v, ok := d.GetOk(fmt.Sprintf("rule.%d.actions.0.version.0.delete_after_days_since_creation", ruleIndex)); ok {
+				version.Delete = &storage.DateAfterCreation{DaysAfterCreationGreaterThan: utils.Float(float64(v.(int)))}
+			}
+			definition.Actions.Version = version
+		}
 	}
 
 	rule := storage.ManagementPolicyRule{
@@ -332,7 +461,7 @@ func expandStorageManagementPolicyRule(d *schema.ResourceData, ruleIndex int) st
 		Type:       &typeVal,
 		Definition: &definition,
 	}
-	return rule
+	return &rule, nil
 }
 
 func flattenStorageManagementPolicyRules(armRules *[]storage.ManagementPolicyRule) []interface{} {
@@ -390,6 +519,21 @@ func flattenStorageManagementPolicyRules(armRules *[]storage.ManagementPolicyRul
 						intTemp := int(*armActionBaseBlob.Delete.DaysAfterModificationGreaterThan)
 						baseBlob["delete_after_days_since_modification_greater_than"] = intTemp
 					}
+					if armActionBaseBlob.TierToCool != nil && armActionBaseBlob.TierToCool.DaysAfterLastAccessTimeGreaterThan != nil {
+						intTemp := int(*armActionBaseBlob.TierToCool.DaysAfterLastAccessTimeGreaterThan)
+						baseBlob["tier_to_cool_after_days_since_last_access_time_greater_than"] = intTemp
+					}
+					if armActionBaseBlob.TierToArchive != nil && armActionBaseBlob.TierToArchive.DaysAfterLastAccessTimeGreaterThan != nil {
+						intTemp := int(*armActionBaseBlob.TierToArchive.DaysAfterLastAccessTimeGreaterThan)
+						baseBlob["tier_to_archive_after_days_since_last_access_time_greater_than"] = intTemp
+					}
+					if armActionBaseBlob.Delete != nil && armActionBaseBlob.Delete.DaysAfterLastAccessTimeGreaterThan != nil {
+						intTemp := int(*armActionBaseBlob.Delete.DaysAfterLastAccessTimeGreaterThan)
+						baseBlob["delete_after_days_since_last_access_time_greater_than"] = intTemp
+					}
+					if armActionBaseBlob.EnableAutoTierToHotFromCool != nil {
+						baseBlob["auto_tier_to_hot_from_cool_enabled"] = *armActionBaseBlob.EnableAutoTierToHotFromCool
+					}
 					action["base_blob"] = [1]interface{}{baseBlob}
 				}
 
@@ -403,6 +547,20 @@ func flattenStorageManagementPolicyRules(armRules *[]storage.ManagementPolicyRul
 					action["snapshot"] = [1]interface{}{snapshot}
 				}
 
+				if armActionVersion := armAction.Version; armActionVersion != nil {
+					version := make(map[string]interface{})
+					if armActionVersion.TierToCool != nil && armActionVersion.TierToCool.DaysAfterCreationGreaterThan != nil {
+						version["change_tier_to_cool_after_days_since_creation"] = int(*armActionVersion.TierToCool.DaysAfterCreationGreaterThan)
+					}
+					if armActionVersion.TierToArchive != nil && armActionVersion.TierToArchive.DaysAfterCreationGreaterThan != nil {
+						version["change_tier_to_archive_after_days_since_creation"] = int(*armActionVersion.TierToArchive.DaysAfterCreationGreaterThan)
+					}
+					if armActionVersion.Delete != nil && armActionVersion.Delete.DaysAfterCreationGreaterThan != nil {
+						version["delete_after_days_since_creation"] = int(*armActionVersion.Delete.DaysAfterCreationGreaterThan)
+					}
+					action["version"] = [1]interface{}{version}
+				}
+
 				rule["actions"] = [1]interface{}{action}
 			}
 		}
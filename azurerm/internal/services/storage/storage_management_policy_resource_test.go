@@ -264,6 +264,26 @@ func TestAccStorageManagementPolicy_blobTypes(t *testing.T) {
 	})
 }
 
+func TestAccStorageManagementPolicy_lastAccessTimeAndVersion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_management_policy", "test")
+	r := StorageManagementPolicyResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.lastAccessTimeAndVersion(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("rule.0.actions.0.base_blob.0.tier_to_cool_after_days_since_last_access_time_greater_than").HasValue("10"),
+				check.That(data.ResourceName).Key("rule.0.actions.0.base_blob.0.auto_tier_to_hot_from_cool_enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("rule.0.actions.0.base_blob.0.delete_after_days_since_last_access_time_greater_than").HasValue("100"),
+				check.That(data.ResourceName).Key("rule.0.actions.0.version.0.change_tier_to_cool_after_days_since_creation").HasValue("7"),
+				check.That(data.ResourceName).Key("rule.0.actions.0.version.0.delete_after_days_since_creation").HasValue("90"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (r StorageManagementPolicyResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	storageAccountId := state.Attributes["storage_account_id"]
 	id, err := parse.StorageAccountID(storageAccountId)
@@ -585,3 +605,55 @@ resource "azurerm_storage_management_policy" "test" {
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
+
+func (r StorageManagementPolicyResource) lastAccessTimeAndVersion(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                = "unlikely23exst2acct%s"
+  resource_group_name = azurerm_resource_group.test.name
+
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+  account_kind             = "StorageV2"
+
+  blob_properties {
+    versioning_enabled       = true
+    last_access_time_enabled = true
+  }
+}
+
+resource "azurerm_storage_management_policy" "test" {
+  storage_account_id = azurerm_storage_account.test.id
+
+  rule {
+    name    = "rule1"
+    enabled = true
+    filters {
+      prefix_match = ["container1/prefix1"]
+      blob_types   = ["blockBlob"]
+    }
+    actions {
+      base_blob {
+        tier_to_cool_after_days_since_last_access_time_greater_than = 10
+        auto_tier_to_hot_from_cool_enabled                          = true
+        delete_after_days_since_last_access_time_greater_than       = 100
+      }
+      version {
+        change_tier_to_cool_after_days_since_creation = 7
+        delete_after_days_since_creation              = 90
+      }
+    }
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
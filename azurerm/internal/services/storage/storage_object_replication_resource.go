@@ -0,0 +1,432 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2021-01-01/storage"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/parse"
+	storageValidate "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+	azSchema "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const (
+	// objectReplicationCopyOnlyNewObjects specifies that only Blobs created after the Policy should be replicated
+	objectReplicationCopyOnlyNewObjects = "OnlyNewObjects"
+
+	// objectReplicationCopyEverything specifies that all Blobs should be replicated, which the API represents
+	// as a Minimum Creation Time of the earliest possible date
+	objectReplicationCopyEverything  = "Everything"
+	objectReplicationMinCreationTime = "1601-01-01T00:00:00Z"
+)
+
+func resourceStorageObjectReplication() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageObjectReplicationCreate,
+		Read:   resourceStorageObjectReplicationRead,
+		Update: resourceStorageObjectReplicationUpdate,
+		Delete: resourceStorageObjectReplicationDelete,
+
+		Importer: azSchema.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := parse.ObjectReplicationID(id)
+			return err
+		}),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source_storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: storageValidate.StorageAccountID,
+			},
+
+			"destination_storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: storageValidate.StorageAccountID,
+			},
+
+			"rules": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_container_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: storageValidate.StorageContainerName,
+						},
+
+						"destination_container_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: storageValidate.StorageContainerName,
+						},
+
+						"copy_blobs_created_after": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  objectReplicationCopyOnlyNewObjects,
+							ValidateFunc: validation.Any(
+								validation.StringInSlice([]string{
+									objectReplicationCopyOnlyNewObjects,
+									objectReplicationCopyEverything,
+								}, false),
+								validation.IsRFC3339Time,
+							),
+						},
+
+						"filter_out_blobs_with_prefix": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+					},
+				},
+			},
+
+			"source_object_replication_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"destination_object_replication_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceStorageObjectReplicationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.ObjectReplicationClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	srcAccount, err := parse.StorageAccountID(d.Get("source_storage_account_id").(string))
+	if err != nil {
+		return err
+	}
+	dstAccount, err := parse.StorageAccountID(d.Get("destination_storage_account_id").(string))
+	if err != nil {
+		return err
+	}
+
+	existing, err := findObjectReplicationPolicy(ctx, client, *srcAccount, *dstAccount)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		id := parse.NewObjectReplicationId(
+			parse.NewObjectReplicationPolicyID(srcAccount.SubscriptionId, srcAccount.ResourceGroup, srcAccount.Name, *existing),
+			parse.NewObjectReplicationPolicyID(dstAccount.SubscriptionId, dstAccount.ResourceGroup, dstAccount.Name, *existing),
+		)
+		return tf.ImportAsExistsError("azurerm_storage_object_replication", id.ID())
+	}
+
+	props := storage.ObjectReplicationPolicy{
+		ObjectReplicationPolicyProperties: &storage.ObjectReplicationPolicyProperties{
+			SourceAccount:      utils.String(srcAccount.ID()),
+			DestinationAccount: utils.String(dstAccount.ID()),
+			Rules:              expandObjectReplicationRules(d.Get("rules").(*schema.Set).List(), nil),
+		},
+	}
+
+	// the Policy must be created on the Destination Storage Account first, which generates the Policy ID and the
+	// ID of each Rule - which are then required to create the corresponding Policy on the Source Storage Account
+	dstResp, err := client.CreateOrUpdate(ctx, dstAccount.ResourceGroup, dstAccount.Name, "default", props)
+	if err != nil {
+		return fmt.Errorf("creating Object Replication Policy on the Destination %s: %+v", *dstAccount, err)
+	}
+	if dstResp.ObjectReplicationPolicyProperties == nil || dstResp.ObjectReplicationPolicyProperties.PolicyID == nil {
+		return fmt.Errorf("creating Object Replication Policy on the Destination %s: `policyId` was nil", *dstAccount)
+	}
+	policyId := *dstResp.ObjectReplicationPolicyProperties.PolicyID
+	id := parse.NewObjectReplicationId(
+		parse.NewObjectReplicationPolicyID(srcAccount.SubscriptionId, srcAccount.ResourceGroup, srcAccount.Name, policyId),
+		parse.NewObjectReplicationPolicyID(dstAccount.SubscriptionId, dstAccount.ResourceGroup, dstAccount.Name, policyId),
+	)
+
+	props.ObjectReplicationPolicyProperties.Rules = dstResp.ObjectReplicationPolicyProperties.Rules
+	if _, err := client.CreateOrUpdate(ctx, srcAccount.ResourceGroup, srcAccount.Name, policyId, props); err != nil {
+		// remove the Policy on the Destination Storage Account, so that this can be retried
+		if _, deleteErr := client.Delete(ctx, dstAccount.ResourceGroup, dstAccount.Name, policyId); deleteErr != nil {
+			log.Printf("[WARN] Unable to delete %s following the failed creation on the Source Storage Account: %+v", id.Dst, deleteErr)
+		}
+		return fmt.Errorf("creating Object Replication Policy on the Source %s: %+v", *srcAccount, err)
+	}
+
+	d.SetId(id.ID())
+	return resourceStorageObjectReplicationRead(d, meta)
+}
+
+func resourceStorageObjectReplicationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.ObjectReplicationClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ObjectReplicationID(d.Id())
+	if err != nil {
+		return err
+	}
+	srcAccount := parse.NewStorageAccountID(id.Src.SubscriptionId, id.Src.ResourceGroup, id.Src.StorageAccountName)
+	dstAccount := parse.NewStorageAccountID(id.Dst.SubscriptionId, id.Dst.ResourceGroup, id.Dst.StorageAccountName)
+
+	existing, err := client.Get(ctx, id.Dst.ResourceGroup, id.Dst.StorageAccountName, id.Dst.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", id.Dst, err)
+	}
+	var existingRules *[]storage.ObjectReplicationPolicyRule
+	if existing.ObjectReplicationPolicyProperties != nil {
+		existingRules = existing.ObjectReplicationPolicyProperties.Rules
+	}
+
+	props := storage.ObjectReplicationPolicy{
+		ObjectReplicationPolicyProperties: &storage.ObjectReplicationPolicyProperties{
+			SourceAccount:      utils.String(srcAccount.ID()),
+			DestinationAccount: utils.String(dstAccount.ID()),
+			Rules:              expandObjectReplicationRules(d.Get("rules").(*schema.Set).List(), existingRules),
+		},
+	}
+
+	// as during creation, the Destination is updated first to obtain the ID of any new Rules
+	dstResp, err := client.CreateOrUpdate(ctx, id.Dst.ResourceGroup, id.Dst.StorageAccountName, id.Dst.Name, props)
+	if err != nil {
+		return fmt.Errorf("updating %s: %+v", id.Dst, err)
+	}
+	if dstResp.ObjectReplicationPolicyProperties != nil {
+		props.ObjectReplicationPolicyProperties.Rules = dstResp.ObjectReplicationPolicyProperties.Rules
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, id.Src.ResourceGroup, id.Src.StorageAccountName, id.Src.Name, props); err != nil {
+		return fmt.Errorf("updating %s: %+v", id.Src, err)
+	}
+
+	return resourceStorageObjectReplicationRead(d, meta)
+}
+
+func resourceStorageObjectReplicationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.ObjectReplicationClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ObjectReplicationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	dstResp, err := client.Get(ctx, id.Dst.ResourceGroup, id.Dst.StorageAccountName, id.Dst.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(dstResp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", id.Dst)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id.Dst, err)
+	}
+
+	srcResp, err := client.Get(ctx, id.Src.ResourceGroup, id.Src.StorageAccountName, id.Src.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(srcResp.Response) {
+			log.Printf("[INFO] %s does not exist - removing from state", id.Src)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", id.Src, err)
+	}
+
+	d.Set("source_storage_account_id", parse.NewStorageAccountID(id.Src.SubscriptionId, id.Src.ResourceGroup, id.Src.StorageAccountName).ID())
+	d.Set("destination_storage_account_id", parse.NewStorageAccountID(id.Dst.SubscriptionId, id.Dst.ResourceGroup, id.Dst.StorageAccountName).ID())
+	d.Set("source_object_replication_id", id.Src.ID())
+	d.Set("destination_object_replication_id", id.Dst.ID())
+
+	if props := srcResp.ObjectReplicationPolicyProperties; props != nil {
+		if err := d.Set("rules", flattenObjectReplicationRules(props.Rules)); err != nil {
+			return fmt.Errorf("setting `rules`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceStorageObjectReplicationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Storage.ObjectReplicationClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ObjectReplicationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// the Source is deleted first, so that Blobs aren't replicated to a Destination without a Policy
+	if resp, err := client.Delete(ctx, id.Src.ResourceGroup, id.Src.StorageAccountName, id.Src.Name); err != nil {
+		if !utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("deleting %s: %+v", id.Src, err)
+		}
+	}
+
+	if resp, err := client.Delete(ctx, id.Dst.ResourceGroup, id.Dst.StorageAccountName, id.Dst.Name); err != nil {
+		if !utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("deleting %s: %+v", id.Dst, err)
+		}
+	}
+
+	return nil
+}
+
+// findObjectReplicationPolicy returns the ID of the existing Object Replication Policy between the Source and
+// Destination Storage Accounts (if any), since only one Policy can exist between two Storage Accounts
+func findObjectReplicationPolicy(ctx context.Context, client *storage.ObjectReplicationPoliciesClient, srcAccount, dstAccount parse.StorageAccountId) (*string, error) {
+	resp, err := client.List(ctx, dstAccount.ResourceGroup, dstAccount.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("listing the Object Replication Policies on the Destination %s: %+v", dstAccount, err)
+	}
+
+	if resp.Value == nil {
+		return nil, nil
+	}
+
+	for _, v := range *resp.Value {
+		props := v.ObjectReplicationPolicyProperties
+		if props == nil || props.PolicyID == nil || props.SourceAccount == nil {
+			continue
+		}
+
+		// the Source Account can be returned as either the Name or the Resource ID
+		if strings.EqualFold(*props.SourceAccount, srcAccount.Name) || strings.EqualFold(*props.SourceAccount, srcAccount.ID()) {
+			return props.PolicyID, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func expandObjectReplicationRules(input []interface{}, existing *[]storage.ObjectReplicationPolicyRule) *[]storage.ObjectReplicationPolicyRule {
+	// the ID of each existing Rule is retained, so that the Rule is updated rather than replaced
+	existingRuleIds := make(map[string]string)
+	if existing != nil {
+		for _, v := range *existing {
+			if v.RuleID == nil || v.SourceContainer == nil || v.DestinationContainer == nil {
+				continue
+			}
+			existingRuleIds[objectReplicationRuleKey(*v.SourceContainer, *v.DestinationContainer)] = *v.RuleID
+		}
+	}
+
+	rules := make([]storage.ObjectReplicationPolicyRule, 0)
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		sourceContainer := v["source_container_name"].(string)
+		destinationContainer := v["destination_container_name"].(string)
+		rule := storage.ObjectReplicationPolicyRule{
+			SourceContainer:      utils.String(sourceContainer),
+			DestinationContainer: utils.String(destinationContainer),
+			Filters: &storage.ObjectReplicationPolicyFilter{
+				PrefixMatch:     utils.ExpandStringSlice(v["filter_out_blobs_with_prefix"].(*schema.Set).List()),
+				MinCreationTime: expandObjectReplicationMinCreationTime(v["copy_blobs_created_after"].(string)),
+			},
+		}
+		if ruleId, ok := existingRuleIds[objectReplicationRuleKey(sourceContainer, destinationContainer)]; ok {
+			rule.RuleID = utils.String(ruleId)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return &rules
+}
+
+func expandObjectReplicationMinCreationTime(input string) *string {
+	switch input {
+	case "", objectReplicationCopyOnlyNewObjects:
+		return nil
+	case objectReplicationCopyEverything:
+		return utils.String(objectReplicationMinCreationTime)
+	default:
+		return utils.String(input)
+	}
+}
+
+func flattenObjectReplicationRules(input *[]storage.ObjectReplicationPolicyRule) []interface{} {
+	rules := make([]interface{}, 0)
+	if input == nil {
+		return rules
+	}
+
+	for _, rule := range *input {
+		sourceContainer := ""
+		if rule.SourceContainer != nil {
+			sourceContainer = *rule.SourceContainer
+		}
+
+		destinationContainer := ""
+		if rule.DestinationContainer != nil {
+			destinationContainer = *rule.DestinationContainer
+		}
+
+		copyBlobsCreatedAfter := objectReplicationCopyOnlyNewObjects
+		prefixes := make([]interface{}, 0)
+		if filters := rule.Filters; filters != nil {
+			if filters.MinCreationTime != nil {
+				copyBlobsCreatedAfter = flattenObjectReplicationMinCreationTime(*filters.MinCreationTime)
+			}
+			if filters.PrefixMatch != nil {
+				prefixes = utils.FlattenStringSlice(filters.PrefixMatch)
+			}
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"source_container_name":        sourceContainer,
+			"destination_container_name":   destinationContainer,
+			"copy_blobs_created_after":     copyBlobsCreatedAfter,
+			"filter_out_blobs_with_prefix": schema.NewSet(schema.HashString, prefixes),
+		})
+	}
+
+	return rules
+}
+
+func flattenObjectReplicationMinCreationTime(input string) string {
+	// the API returns the earliest possible date in a different format (e.g. `1601-01-01T00:00:00.0000000Z`)
+	if v, err := time.Parse(time.RFC3339, input); err == nil {
+		if earliest, _ := time.Parse(time.RFC3339, objectReplicationMinCreationTime); v.Equal(earliest) {
+			return objectReplicationCopyEverything
+		}
+
+		return v.UTC().Format(time.RFC3339)
+	}
+
+	return input
+}
+
+func objectReplicationRuleKey(sourceContainer, destinationContainer string) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(sourceContainer), strings.ToLower(destinationContainer))
+}
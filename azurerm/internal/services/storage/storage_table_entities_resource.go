@@ -0,0 +1,419 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/table/entities"
+)
+
+func resourceStorageTableEntities() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStorageTableEntitiesCreate,
+		Read:   resourceStorageTableEntitiesRead,
+		Update: resourceStorageTableEntitiesUpdate,
+		Delete: resourceStorageTableEntitiesDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"table_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageTableName,
+			},
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: ValidateStorageAccountName,
+			},
+
+			"entity": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"partition_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"row_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"properties": {
+							Type:     schema.TypeMap,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceStorageTableEntitiesCreate(d *schema.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	storageClient := meta.(*clients.Client).Storage
+
+	accountName := d.Get("storage_account_name").(string)
+	tableName := d.Get("table_name").(string)
+
+	operations, err := expandStorageTableEntitiesUpserts(d.Get("entity").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", accountName, tableName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("unable to locate Storage Account %q for Storage Table %q", accountName, tableName)
+	}
+
+	client, err := storageClient.TableEntityClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Entity Client: %s", err)
+	}
+
+	// since the Entities are upserted, ensure none of them already exist to avoid taking over Entities managed elsewhere
+	for _, operation := range operations {
+		exists, err := tableEntityExists(ctx, client, accountName, tableName, operation.PartitionKey, operation.RowKey)
+		if err != nil {
+			return fmt.Errorf("checking for presence of existing Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %+v", operation.PartitionKey, operation.RowKey, tableName, accountName, account.ResourceGroup, err)
+		}
+		if exists {
+			return fmt.Errorf("the Entity with Partition Key %q and Row Key %q already exists in Table %q (Storage Account %q) - to be managed via Terraform this Entity needs to be removed from the Table, or imported individually using the `azurerm_storage_table_entity` resource and removed from `entity`", operation.PartitionKey, operation.RowKey, tableName, accountName)
+		}
+	}
+
+	batches, err := groupTableEntityBatchOperations(operations)
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		if err := executeTableEntityBatch(ctx, client, accountName, tableName, batch); err != nil {
+			return fmt.Errorf("upserting Entities with Partition Key %q (Table %q / Storage Account %q / Resource Group %q): %+v", batch[0].PartitionKey, tableName, accountName, account.ResourceGroup, err)
+		}
+	}
+
+	d.SetId(parse.NewStorageTableEntitiesDataPlaneId(accountName, storageClient.Environment.StorageEndpointSuffix, tableName).ID())
+
+	return resourceStorageTableEntitiesRead(d, meta)
+}
+
+func resourceStorageTableEntitiesUpdate(d *schema.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	storageClient := meta.(*clients.Client).Storage
+
+	id, err := parse.StorageTableEntitiesDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.TableName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("unable to locate Storage Account %q for Storage Table %q", id.AccountName, id.TableName)
+	}
+
+	client, err := storageClient.TableEntityClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Entity Client: %s", err)
+	}
+
+	if d.HasChange("entity") {
+		old, new := d.GetChange("entity")
+		oldEntities := old.(*schema.Set)
+		newEntities := new.(*schema.Set)
+		if _, err := expandStorageTableEntitiesUpserts(newEntities.List()); err != nil {
+			return err
+		}
+
+		// only the Entities which have been added or changed need to be upserted
+		operations, err := expandStorageTableEntitiesUpserts(newEntities.Difference(oldEntities).List())
+		if err != nil {
+			return err
+		}
+
+		// Entities which are no longer defined are removed, however Entities whose properties have changed show
+		// up in both sets - and are replaced as a part of the upsert
+		keys := make(map[string]struct{})
+		for _, raw := range newEntities.List() {
+			v := raw.(map[string]interface{})
+			keys[tableEntityKey(v["partition_key"].(string), v["row_key"].(string))] = struct{}{}
+		}
+		for _, raw := range oldEntities.Difference(newEntities).List() {
+			v := raw.(map[string]interface{})
+			partitionKey := v["partition_key"].(string)
+			rowKey := v["row_key"].(string)
+			if _, ok := keys[tableEntityKey(partitionKey, rowKey)]; ok {
+				continue
+			}
+
+			operations = append(operations, tableEntityBatchOperation{
+				Method:       http.MethodDelete,
+				PartitionKey: partitionKey,
+				RowKey:       rowKey,
+			})
+		}
+
+		// as during creation, Entities which are newly added must not already exist to avoid taking over Entities managed elsewhere
+		oldKeys := make(map[string]struct{})
+		for _, raw := range oldEntities.List() {
+			v := raw.(map[string]interface{})
+			oldKeys[tableEntityKey(v["partition_key"].(string), v["row_key"].(string))] = struct{}{}
+		}
+		for _, operation := range operations {
+			if _, ok := oldKeys[tableEntityKey(operation.PartitionKey, operation.RowKey)]; ok || operation.Method != http.MethodPut {
+				continue
+			}
+
+			exists, err := tableEntityExists(ctx, client, id.AccountName, id.TableName, operation.PartitionKey, operation.RowKey)
+			if err != nil {
+				return fmt.Errorf("checking for presence of existing Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %+v", operation.PartitionKey, operation.RowKey, id.TableName, id.AccountName, account.ResourceGroup, err)
+			}
+			if exists {
+				return fmt.Errorf("the Entity with Partition Key %q and Row Key %q already exists in Table %q (Storage Account %q) - to be managed via Terraform this Entity needs to be removed from the Table, or imported individually using the `azurerm_storage_table_entity` resource and removed from `entity`", operation.PartitionKey, operation.RowKey, id.TableName, id.AccountName)
+			}
+		}
+
+		operations, err = withoutMissingTableEntities(ctx, client, id.AccountName, id.TableName, operations)
+		if err != nil {
+			return fmt.Errorf("checking for presence of the Entities to remove (Table %q / Storage Account %q / Resource Group %q): %+v", id.TableName, id.AccountName, account.ResourceGroup, err)
+		}
+
+		batches, err := groupTableEntityBatchOperations(operations)
+		if err != nil {
+			return err
+		}
+		for _, batch := range batches {
+			if err := executeTableEntityBatch(ctx, client, id.AccountName, id.TableName, batch); err != nil {
+				return fmt.Errorf("updating Entities with Partition Key %q (Table %q / Storage Account %q / Resource Group %q): %+v", batch[0].PartitionKey, id.TableName, id.AccountName, account.ResourceGroup, err)
+			}
+		}
+	}
+
+	return resourceStorageTableEntitiesRead(d, meta)
+}
+
+func resourceStorageTableEntitiesRead(d *schema.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	storageClient := meta.(*clients.Client).Storage
+
+	id, err := parse.StorageTableEntitiesDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.TableName, err)
+	}
+	if account == nil {
+		log.Printf("[WARN] Unable to determine Resource Group for Storage Table %q (Account %s) - assuming removed & removing from state", id.TableName, id.AccountName)
+		d.SetId("")
+		return nil
+	}
+
+	client, err := storageClient.TableEntityClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Table Entity Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
+	}
+
+	output := make([]interface{}, 0)
+	for _, raw := range d.Get("entity").(*schema.Set).List() {
+		v := raw.(map[string]interface{})
+		partitionKey := v["partition_key"].(string)
+		rowKey := v["row_key"].(string)
+
+		input := entities.GetEntityInput{
+			PartitionKey:  partitionKey,
+			RowKey:        rowKey,
+			MetaDataLevel: entities.FullMetaData,
+		}
+		result, err := client.Get(ctx, id.AccountName, id.TableName, input)
+		if err != nil {
+			if utils.ResponseWasNotFound(result.Response) {
+				log.Printf("[DEBUG] Entity (Partition Key %q / Row Key %q) was not found in Table %q (Storage Account %q) - removing from state", partitionKey, rowKey, id.TableName, id.AccountName)
+				continue
+			}
+
+			return fmt.Errorf("retrieving Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %s", partitionKey, rowKey, id.TableName, id.AccountName, account.ResourceGroup, err)
+		}
+
+		output = append(output, map[string]interface{}{
+			"partition_key": partitionKey,
+			"row_key":       rowKey,
+			"properties":    flattenStorageTableEntityProperties(result.Entity, v["properties"].(map[string]interface{})),
+		})
+	}
+
+	d.Set("storage_account_name", id.AccountName)
+	d.Set("table_name", id.TableName)
+	if err := d.Set("entity", output); err != nil {
+		return fmt.Errorf("setting `entity`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceStorageTableEntitiesDelete(d *schema.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+	storageClient := meta.(*clients.Client).Storage
+
+	id, err := parse.StorageTableEntitiesDataPlaneID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Table %q: %s", id.AccountName, id.TableName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("Storage Account %q was not found!", id.AccountName)
+	}
+
+	client, err := storageClient.TableEntityClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Entity Client for Storage Account %q (Resource Group %q): %s", id.AccountName, account.ResourceGroup, err)
+	}
+
+	operations := make([]tableEntityBatchOperation, 0)
+	for _, raw := range d.Get("entity").(*schema.Set).List() {
+		v := raw.(map[string]interface{})
+		operations = append(operations, tableEntityBatchOperation{
+			Method:       http.MethodDelete,
+			PartitionKey: v["partition_key"].(string),
+			RowKey:       v["row_key"].(string),
+		})
+	}
+
+	// Entities which have already been removed (e.g. outside of Terraform) can't be deleted within an Entity Group
+	// Transaction, since this would fail the Transaction as a whole
+	operations, err = withoutMissingTableEntities(ctx, client, id.AccountName, id.TableName, operations)
+	if err != nil {
+		return fmt.Errorf("checking for presence of the Entities to delete (Table %q / Storage Account %q / Resource Group %q): %+v", id.TableName, id.AccountName, account.ResourceGroup, err)
+	}
+
+	batches, err := groupTableEntityBatchOperations(operations)
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		if err := executeTableEntityBatch(ctx, client, id.AccountName, id.TableName, batch); err != nil {
+			return fmt.Errorf("deleting Entities with Partition Key %q (Table %q / Storage Account %q / Resource Group %q): %+v", batch[0].PartitionKey, id.TableName, id.AccountName, account.ResourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandStorageTableEntitiesUpserts(input []interface{}) ([]tableEntityBatchOperation, error) {
+	operations := make([]tableEntityBatchOperation, 0)
+	keys := make(map[string]struct{})
+
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+		partitionKey := v["partition_key"].(string)
+		rowKey := v["row_key"].(string)
+
+		key := tableEntityKey(partitionKey, rowKey)
+		if _, ok := keys[key]; ok {
+			return nil, fmt.Errorf("the Entity with Partition Key %q and Row Key %q is defined more than once", partitionKey, rowKey)
+		}
+		keys[key] = struct{}{}
+
+		properties, err := expandStorageTableEntityProperties(v["properties"].(map[string]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("expanding `properties` for the Entity with Partition Key %q and Row Key %q: %+v", partitionKey, rowKey, err)
+		}
+
+		operations = append(operations, tableEntityBatchOperation{
+			Method:       http.MethodPut,
+			PartitionKey: partitionKey,
+			RowKey:       rowKey,
+			Entity:       properties,
+		})
+	}
+
+	return operations, nil
+}
+
+// tableEntityExists returns whether the Entity with the specified Partition Key and Row Key exists within the Table
+func tableEntityExists(ctx context.Context, client *entities.Client, accountName, tableName, partitionKey, rowKey string) (bool, error) {
+	input := entities.GetEntityInput{
+		PartitionKey:  partitionKey,
+		RowKey:        rowKey,
+		MetaDataLevel: entities.NoMetaData,
+	}
+	existing, err := client.Get(ctx, accountName, tableName, input)
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// withoutMissingTableEntities returns the operations excluding the deletion of any Entities which no longer exist
+func withoutMissingTableEntities(ctx context.Context, client *entities.Client, accountName, tableName string, operations []tableEntityBatchOperation) ([]tableEntityBatchOperation, error) {
+	output := make([]tableEntityBatchOperation, 0)
+	for _, operation := range operations {
+		if operation.Method == http.MethodDelete {
+			exists, err := tableEntityExists(ctx, client, accountName, tableName, operation.PartitionKey, operation.RowKey)
+			if err != nil {
+				return nil, fmt.Errorf("retrieving Entity (Partition Key %q / Row Key %q): %+v", operation.PartitionKey, operation.RowKey, err)
+			}
+			if !exists {
+				log.Printf("[DEBUG] Entity (Partition Key %q / Row Key %q) was not found in Table %q (Storage Account %q) - skipping removal", operation.PartitionKey, operation.RowKey, tableName, accountName)
+				continue
+			}
+		}
+
+		output = append(output, operation)
+	}
+
+	return output, nil
+}
+
+func tableEntityKey(partitionKey, rowKey string) string {
+	return fmt.Sprintf("%s/%s", partitionKey, rowKey)
+}
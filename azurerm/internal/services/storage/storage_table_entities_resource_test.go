@@ -0,0 +1,283 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/check"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/storage/parse"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/table/entities"
+)
+
+type StorageTableEntitiesResource struct{}
+
+func TestAccTableEntities_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_table_entities", "test")
+	r := StorageTableEntitiesResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("entity.#").HasValue("3"),
+			),
+		},
+	})
+}
+
+func TestAccTableEntities_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_table_entities", "test")
+	r := StorageTableEntitiesResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("entity.#").HasValue("3"),
+			),
+		},
+		{
+			Config: r.updated(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("entity.#").HasValue("2"),
+			),
+		},
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("entity.#").HasValue("3"),
+			),
+		},
+	})
+}
+
+func TestAccTableEntities_existingEntity(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_table_entities", "test")
+	r := StorageTableEntitiesResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.existingEntity(data),
+			ExpectError: regexp.MustCompile("already exists in Table"),
+		},
+	})
+}
+
+func TestAccTableEntities_existingEntityAdded(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_table_entities", "test")
+	r := StorageTableEntitiesResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.existingEntityAdded(data, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.existingEntityAdded(data, true),
+			ExpectError: regexp.MustCompile("already exists in Table"),
+		},
+	})
+}
+
+func (r StorageTableEntitiesResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	id, err := parse.StorageTableEntitiesDataPlaneID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := client.Storage.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q for Table %q: %+v", id.AccountName, id.TableName, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("storage Account %q was not found", id.AccountName)
+	}
+
+	entitiesClient, err := client.Storage.TableEntityClient(ctx, *account)
+	if err != nil {
+		return nil, fmt.Errorf("building Table Entity Client: %+v", err)
+	}
+
+	// every Entity defined in the state must exist, e.g. `entity.1234567.partition_key`
+	for k, partitionKey := range state.Attributes {
+		if !strings.HasPrefix(k, "entity.") || !strings.HasSuffix(k, ".partition_key") {
+			continue
+		}
+		rowKey := state.Attributes[strings.TrimSuffix(k, "partition_key")+"row_key"]
+
+		input := entities.GetEntityInput{
+			PartitionKey:  partitionKey,
+			RowKey:        rowKey,
+			MetaDataLevel: entities.NoMetaData,
+		}
+		resp, err := entitiesClient.Get(ctx, id.AccountName, id.TableName, input)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return utils.Bool(false), nil
+			}
+			return nil, fmt.Errorf("retrieving Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %+v", partitionKey, rowKey, id.TableName, id.AccountName, account.ResourceGroup, err)
+		}
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r StorageTableEntitiesResource) basic(data acceptance.TestData) string {
+	template := StorageTableEntityResource{}.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_table_entities" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  table_name           = azurerm_storage_table.test.name
+
+  entity {
+    partition_key = "settings"
+    row_key       = "feature-a"
+    properties = {
+      Enabled              = "true"
+      "Enabled@odata.type" = "Edm.Boolean"
+    }
+  }
+
+  entity {
+    partition_key = "settings"
+    row_key       = "feature-b"
+    properties = {
+      Enabled              = "false"
+      "Enabled@odata.type" = "Edm.Boolean"
+      Limit                = "100"
+      "Limit@odata.type"   = "Edm.Int32"
+    }
+  }
+
+  entity {
+    partition_key = "regions"
+    row_key       = "%s"
+    properties = {
+      DisplayName = "Primary"
+    }
+  }
+}
+`, template, data.Locations.Primary)
+}
+
+func (r StorageTableEntitiesResource) updated(data acceptance.TestData) string {
+	template := StorageTableEntityResource{}.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_table_entities" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  table_name           = azurerm_storage_table.test.name
+
+  entity {
+    partition_key = "settings"
+    row_key       = "feature-a"
+    properties = {
+      Enabled              = "false"
+      "Enabled@odata.type" = "Edm.Boolean"
+    }
+  }
+
+  entity {
+    partition_key = "regions"
+    row_key       = "%s"
+    properties = {
+      DisplayName = "Primary Region"
+    }
+  }
+}
+`, template, data.Locations.Primary)
+}
+
+func (r StorageTableEntitiesResource) existingEntity(data acceptance.TestData) string {
+	template := StorageTableEntityResource{}.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_table_entity" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  table_name           = azurerm_storage_table.test.name
+
+  partition_key = "settings"
+  row_key       = "feature-a"
+  entity = {
+    Enabled = "true"
+  }
+}
+
+resource "azurerm_storage_table_entities" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  table_name           = azurerm_storage_table.test.name
+
+  entity {
+    partition_key = "settings"
+    row_key       = "feature-a"
+    properties = {
+      Enabled = "false"
+    }
+  }
+
+  depends_on = [azurerm_storage_table_entity.test]
+}
+`, template)
+}
+
+func (r StorageTableEntitiesResource) existingEntityAdded(data acceptance.TestData, includeExisting bool) string {
+	template := StorageTableEntityResource{}.template(data)
+	existing := ""
+	if includeExisting {
+		existing = `
+  entity {
+    partition_key = "settings"
+    row_key       = "feature-b"
+    properties = {
+      Enabled = "false"
+    }
+  }
+`
+	}
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_table_entity" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  table_name           = azurerm_storage_table.test.name
+
+  partition_key = "settings"
+  row_key       = "feature-b"
+  entity = {
+    Enabled = "true"
+  }
+}
+
+resource "azurerm_storage_table_entities" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  table_name           = azurerm_storage_table.test.name
+
+  entity {
+    partition_key = "settings"
+    row_key       = "feature-a"
+    properties = {
+      Enabled = "false"
+    }
+  }
+%s
+  depends_on = [azurerm_storage_table_entity.test]
+}
+`, template, existing)
+}
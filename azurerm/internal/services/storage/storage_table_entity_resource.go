@@ -77,7 +77,10 @@ func resourceStorageTableEntityCreateUpdate(d *schema.ResourceData, meta interfa
 	tableName := d.Get("table_name").(string)
 	partitionKey := d.Get("partition_key").(string)
 	rowKey := d.Get("row_key").(string)
-	entity := d.Get("entity").(map[string]interface{})
+	entity, err := expandStorageTableEntityProperties(d.Get("entity").(map[string]interface{}))
+	if err != nil {
+		return fmt.Errorf("expanding `entity`: %+v", err)
+	}
 
 	account, err := storageClient.FindAccount(ctx, accountName)
 	if err != nil {
@@ -161,7 +164,7 @@ func resourceStorageTableEntityRead(d *schema.ResourceData, meta interface{}) er
 	input := entities.GetEntityInput{
 		PartitionKey:  id.PartitionKey,
 		RowKey:        id.RowKey,
-		MetaDataLevel: entities.NoMetaData,
+		MetaDataLevel: entities.FullMetaData,
 	}
 
 	result, err := client.Get(ctx, id.AccountName, id.TableName, input)
@@ -173,7 +176,7 @@ func resourceStorageTableEntityRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("table_name", id.TableName)
 	d.Set("partition_key", id.PartitionKey)
 	d.Set("row_key", id.RowKey)
-	if err := d.Set("entity", flattenEntity(result.Entity)); err != nil {
+	if err := d.Set("entity", flattenStorageTableEntityProperties(result.Entity, d.Get("entity").(map[string]interface{}))); err != nil {
 		return fmt.Errorf("Error setting `entity` for Entity (Partition Key %q / Row Key %q) (Table %q / Storage Account %q / Resource Group %q): %s", id.PartitionKey, id.RowKey, id.TableName, id.AccountName, account.ResourceGroup, err)
 	}
 
@@ -214,12 +217,3 @@ func resourceStorageTableEntityDelete(d *schema.ResourceData, meta interface{})
 
 	return nil
 }
-
-// The api returns extra information that we already have. We'll remove it here before setting it in state.
-func flattenEntity(entity map[string]interface{}) map[string]interface{} {
-	delete(entity, "PartitionKey")
-	delete(entity, "RowKey")
-	delete(entity, "Timestamp")
-
-	return entity
-}
@@ -68,6 +68,23 @@ func TestAccTableEntity_update(t *testing.T) {
 	})
 }
 
+func TestAccTableEntity_typed(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_table_entity", "test")
+	r := StorageTableEntityResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.typed(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("entity.Count@odata.type").HasValue("Edm.Int32"),
+				check.That(data.ResourceName).Key("entity.Enabled@odata.type").HasValue("Edm.Boolean"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (r StorageTableEntityResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	id, err := entities.ParseResourceID(state.ID)
 	if err != nil {
@@ -156,6 +173,36 @@ resource "azurerm_storage_table_entity" "test" {
 `, template, data.RandomInteger, data.RandomInteger)
 }
 
+func (r StorageTableEntityResource) typed(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_table_entity" "test" {
+  storage_account_name = azurerm_storage_account.test.name
+  table_name           = azurerm_storage_table.test.name
+
+  partition_key = "test_partition%d"
+  row_key       = "test_row%d"
+  entity = {
+    Foo                  = "Bar"
+    Count                = "42"
+    "Count@odata.type"   = "Edm.Int32"
+    Big                  = "9223372036854775807"
+    "Big@odata.type"     = "Edm.Int64"
+    Enabled              = "true"
+    "Enabled@odata.type" = "Edm.Boolean"
+    Ratio                = "1.5"
+    "Ratio@odata.type"   = "Edm.Double"
+    Created              = "2021-04-01T12:00:00Z"
+    "Created@odata.type" = "Edm.DateTime"
+    Id                   = "a6b40a6c-5d2f-4c8a-9a2b-0a7d3c1c2f11"
+    "Id@odata.type"      = "Edm.Guid"
+  }
+}
+`, template, data.RandomInteger, data.RandomInteger)
+}
+
 func (r StorageTableEntityResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
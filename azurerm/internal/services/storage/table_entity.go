@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+// tableEntityTypeAnnotationSuffix is the suffix of the OData annotation used to specify the type of a property,
+// for example `Count@odata.type = "Edm.Int64"`
+const tableEntityTypeAnnotationSuffix = "@odata.type"
+
+const (
+	tableEntityTypeBinary   = "Edm.Binary"
+	tableEntityTypeBoolean  = "Edm.Boolean"
+	tableEntityTypeDateTime = "Edm.DateTime"
+	tableEntityTypeDouble   = "Edm.Double"
+	tableEntityTypeGuid     = "Edm.Guid"
+	tableEntityTypeInt32    = "Edm.Int32"
+	tableEntityTypeInt64    = "Edm.Int64"
+	tableEntityTypeString   = "Edm.String"
+)
+
+func tableEntityTypes() []string {
+	return []string{
+		tableEntityTypeBinary,
+		tableEntityTypeBoolean,
+		tableEntityTypeDateTime,
+		tableEntityTypeDouble,
+		tableEntityTypeGuid,
+		tableEntityTypeInt32,
+		tableEntityTypeInt64,
+		tableEntityTypeString,
+	}
+}
+
+// expandStorageTableEntityProperties converts the string values of an Entity into the JSON representation of the
+// type specified in the corresponding OData annotation - properties without an annotation are sent as strings
+func expandStorageTableEntityProperties(input map[string]interface{}) (map[string]interface{}, error) {
+	output := make(map[string]interface{})
+
+	for k, v := range input {
+		if strings.HasSuffix(k, tableEntityTypeAnnotationSuffix) {
+			name := strings.TrimSuffix(k, tableEntityTypeAnnotationSuffix)
+			if _, ok := input[name]; !ok {
+				return nil, fmt.Errorf("the type annotation %q was specified but the property %q was not", k, name)
+			}
+			continue
+		}
+
+		value := v.(string)
+		propertyType := tableEntityTypeString
+		if raw, ok := input[k+tableEntityTypeAnnotationSuffix]; ok {
+			propertyType = raw.(string)
+		}
+
+		expanded, err := expandStorageTableEntityPropertyValue(propertyType, value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the value of property %q: %+v", k, err)
+		}
+
+		output[k] = expanded
+		if propertyType != tableEntityTypeString {
+			output[k+tableEntityTypeAnnotationSuffix] = propertyType
+		}
+	}
+
+	return output, nil
+}
+
+func expandStorageTableEntityPropertyValue(propertyType, value string) (interface{}, error) {
+	switch propertyType {
+	case tableEntityTypeBinary:
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			return nil, fmt.Errorf("expected a base64 encoded value for %s: %+v", propertyType, err)
+		}
+		return value, nil
+
+	case tableEntityTypeBoolean:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean value for %s: %+v", propertyType, err)
+		}
+		return v, nil
+
+	case tableEntityTypeDateTime:
+		v, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("expected an RFC3339 value for %s: %+v", propertyType, err)
+		}
+		return v.UTC().Format(time.RFC3339Nano), nil
+
+	case tableEntityTypeDouble:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a floating point value for %s: %+v", propertyType, err)
+		}
+		// NaN and Infinity aren't representable as JSON numbers, so are sent as strings
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return value, nil
+		}
+		return v, nil
+
+	case tableEntityTypeGuid:
+		if _, err := uuid.ParseUUID(value); err != nil {
+			return nil, fmt.Errorf("expected a GUID for %s: %+v", propertyType, err)
+		}
+		return value, nil
+
+	case tableEntityTypeInt32:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("expected a 32-bit integer for %s: %+v", propertyType, err)
+		}
+		return int32(v), nil
+
+	case tableEntityTypeInt64:
+		// 64-bit integers are sent as strings, since they can't be represented exactly as JSON numbers
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("expected a 64-bit integer for %s: %+v", propertyType, err)
+		}
+		return value, nil
+
+	case tableEntityTypeString:
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %q - supported types are %s", propertyType, strings.Join(tableEntityTypes(), ", "))
+}
+
+// flattenStorageTableEntityProperties converts an Entity retrieved using `fullmetadata` into a map of strings,
+// including an OData type annotation for each property which isn't a string. Where the value within `existing`
+// is equivalent to the value returned from the API the existing representation is retained, to avoid a diff
+// where (for example) `1.50` and `1.5` are both valid representations of the same Edm.Double.
+func flattenStorageTableEntityProperties(entity map[string]interface{}, existing map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{})
+
+	for k, v := range entity {
+		// these are either system properties or OData metadata about the Entity itself
+		if k == "PartitionKey" || k == "RowKey" || k == "Timestamp" || strings.HasPrefix(k, "odata.") {
+			continue
+		}
+		if strings.HasSuffix(k, tableEntityTypeAnnotationSuffix) {
+			continue
+		}
+
+		propertyType := ""
+		if raw, ok := entity[k+tableEntityTypeAnnotationSuffix]; ok {
+			propertyType, _ = raw.(string)
+		}
+
+		value := ""
+		switch t := v.(type) {
+		case bool:
+			value = strconv.FormatBool(t)
+			if propertyType == "" {
+				propertyType = tableEntityTypeBoolean
+			}
+		case float64:
+			value = strconv.FormatFloat(t, 'f', -1, 64)
+			if propertyType == "" {
+				// the type of whole numbers without an annotation is inferred as an Edm.Int32
+				propertyType = tableEntityTypeInt32
+				if t != math.Trunc(t) {
+					propertyType = tableEntityTypeDouble
+				}
+			}
+		case string:
+			value = t
+		default:
+			value = fmt.Sprintf("%v", t)
+		}
+
+		if existingValue, ok := existing[k].(string); ok {
+			existingType, _ := existing[k+tableEntityTypeAnnotationSuffix].(string)
+			if normalizeTableEntityType(existingType) == normalizeTableEntityType(propertyType) && tableEntityPropertyValuesAreEquivalent(normalizeTableEntityType(propertyType), existingValue, value) {
+				value = existingValue
+				// an explicit Edm.String annotation is never returned from the API, so is retained if specified
+				propertyType = existingType
+			}
+		}
+
+		output[k] = value
+		if propertyType != "" {
+			output[k+tableEntityTypeAnnotationSuffix] = propertyType
+		}
+	}
+
+	return output
+}
+
+// normalizeTableEntityType returns the type of a property, where properties without an annotation are strings
+func normalizeTableEntityType(input string) string {
+	if input == "" {
+		return tableEntityTypeString
+	}
+
+	return input
+}
+
+func tableEntityPropertyValuesAreEquivalent(propertyType, first, second string) bool {
+	if first == second {
+		return true
+	}
+
+	switch propertyType {
+	case tableEntityTypeBoolean:
+		a, errA := strconv.ParseBool(first)
+		b, errB := strconv.ParseBool(second)
+		return errA == nil && errB == nil && a == b
+
+	case tableEntityTypeDateTime:
+		a, errA := time.Parse(time.RFC3339, first)
+		b, errB := time.Parse(time.RFC3339, second)
+		return errA == nil && errB == nil && a.Equal(b)
+
+	case tableEntityTypeDouble:
+		a, errA := strconv.ParseFloat(first, 64)
+		b, errB := strconv.ParseFloat(second, 64)
+		return errA == nil && errB == nil && a == b
+
+	case tableEntityTypeGuid:
+		return strings.EqualFold(first, second)
+
+	case tableEntityTypeInt32, tableEntityTypeInt64:
+		a, errA := strconv.ParseInt(first, 10, 64)
+		b, errB := strconv.ParseInt(second, 10, 64)
+		return errA == nil && errB == nil && a == b
+	}
+
+	return false
+}
@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-uuid"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/table/entities"
+)
+
+// tableEntityBatchMaxOperations is the maximum number of operations which can be included in a single
+// Entity Group Transaction
+const tableEntityBatchMaxOperations = 100
+
+// tableEntityBatchMaxSize is the maximum size in bytes of the payload of a single Entity Group Transaction
+const tableEntityBatchMaxSize = 4 * 1024 * 1024
+
+// tableEntityBatchOperationOverhead is an upper bound on the size in bytes of the headers and URI of an operation
+// within an Entity Group Transaction, excluding the Partition Key, Row Key and Entity
+const tableEntityBatchOperationOverhead = 1024
+
+type tableEntityBatchOperation struct {
+	// Method is either `PUT` (Insert or Replace) or `DELETE`
+	Method       string
+	PartitionKey string
+	RowKey       string
+	Entity       map[string]interface{}
+}
+
+// groupTableEntityBatchOperations splits the operations into Entity Group Transactions - which must all target
+// the same Partition Key, contain at most 100 operations and have a payload of at most 4MB - ordered by
+// Partition Key and then Row Key
+func groupTableEntityBatchOperations(input []tableEntityBatchOperation) ([][]tableEntityBatchOperation, error) {
+	operations := make([]tableEntityBatchOperation, len(input))
+	copy(operations, input)
+	sort.SliceStable(operations, func(i, j int) bool {
+		if operations[i].PartitionKey != operations[j].PartitionKey {
+			return operations[i].PartitionKey < operations[j].PartitionKey
+		}
+		return operations[i].RowKey < operations[j].RowKey
+	})
+
+	batches := make([][]tableEntityBatchOperation, 0)
+	batchSize := 0
+	for _, operation := range operations {
+		size, err := tableEntityBatchOperationSize(operation)
+		if err != nil {
+			return nil, err
+		}
+
+		last := len(batches) - 1
+		if last < 0 || batches[last][0].PartitionKey != operation.PartitionKey || len(batches[last]) == tableEntityBatchMaxOperations || batchSize+size > tableEntityBatchMaxSize {
+			batches = append(batches, make([]tableEntityBatchOperation, 0))
+			batchSize = 0
+			last++
+		}
+		batches[last] = append(batches[last], operation)
+		batchSize += size
+	}
+
+	return batches, nil
+}
+
+// tableEntityBatchOperationSize returns an upper bound on the size in bytes of the operation within the payload
+// of an Entity Group Transaction
+func tableEntityBatchOperationSize(operation tableEntityBatchOperation) (int, error) {
+	size := tableEntityBatchOperationOverhead + len(encodeTableEntityKey(operation.PartitionKey)) + len(encodeTableEntityKey(operation.RowKey))
+	if operation.Method == http.MethodPut {
+		payload, err := json.Marshal(operation.Entity)
+		if err != nil {
+			return 0, fmt.Errorf("serializing Entity (Partition Key %q / Row Key %q): %+v", operation.PartitionKey, operation.RowKey, err)
+		}
+		size += len(payload)
+	}
+
+	return size, nil
+}
+
+// executeTableEntityBatch submits the operations as a single Entity Group Transaction, which either
+// succeeds or fails as a whole
+func executeTableEntityBatch(ctx context.Context, client *entities.Client, accountName, tableName string, operations []tableEntityBatchOperation) error {
+	endpoint := fmt.Sprintf("https://%s.table.%s", accountName, client.BaseURI)
+
+	batchId, err := uuid.GenerateUUID()
+	if err != nil {
+		return fmt.Errorf("generating a Batch ID: %+v", err)
+	}
+	changeSetId, err := uuid.GenerateUUID()
+	if err != nil {
+		return fmt.Errorf("generating a Change Set ID: %+v", err)
+	}
+	batchBoundary := fmt.Sprintf("batch_%s", batchId)
+	changeSetBoundary := fmt.Sprintf("changeset_%s", changeSetId)
+
+	body, err := buildTableEntityBatchBody(endpoint, tableName, batchBoundary, changeSetBoundary, operations)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]interface{}{
+		"x-ms-version":          entities.APIVersion,
+		"Accept":                "application/json;odata=minimalmetadata",
+		"DataServiceVersion":    "3.0;",
+		"MaxDataServiceVersion": "3.0;NetFx",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.AsContentType(fmt.Sprintf("multipart/mixed; boundary=%s", batchBoundary)),
+		autorest.WithBaseURL(endpoint),
+		autorest.WithPath("/$batch"),
+		autorest.WithHeaders(headers),
+		autorest.WithBytes(&body))
+	req, err := preparer.Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("preparing the Entity Group Transaction: %+v", err)
+	}
+
+	resp, err := autorest.SendWithSender(client, req, azure.DoRetryWithRegistration(client.Client))
+	if err != nil {
+		return fmt.Errorf("sending the Entity Group Transaction: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading the response of the Entity Group Transaction: %+v", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status %d for the Entity Group Transaction: %s", resp.StatusCode, string(responseBody))
+	}
+
+	return parseTableEntityBatchResponse(string(responseBody))
+}
+
+func buildTableEntityBatchBody(endpoint, tableName, batchBoundary, changeSetBoundary string, operations []tableEntityBatchOperation) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "--%s\r\n", batchBoundary)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", changeSetBoundary)
+
+	for _, operation := range operations {
+		uri := fmt.Sprintf("%s/%s(PartitionKey='%s',RowKey='%s')", endpoint, tableName, encodeTableEntityKey(operation.PartitionKey), encodeTableEntityKey(operation.RowKey))
+
+		fmt.Fprintf(&buf, "--%s\r\n", changeSetBoundary)
+		buf.WriteString("Content-Type: application/http\r\n")
+		buf.WriteString("Content-Transfer-Encoding: binary\r\n\r\n")
+		fmt.Fprintf(&buf, "%s %s HTTP/1.1\r\n", operation.Method, uri)
+		buf.WriteString("Accept: application/json;odata=minimalmetadata\r\n")
+		buf.WriteString("DataServiceVersion: 3.0;\r\n")
+
+		switch operation.Method {
+		case http.MethodDelete:
+			buf.WriteString("If-Match: *\r\n\r\n")
+
+		case http.MethodPut:
+			payload, err := json.Marshal(operation.Entity)
+			if err != nil {
+				return nil, fmt.Errorf("serializing Entity (Partition Key %q / Row Key %q): %+v", operation.PartitionKey, operation.RowKey, err)
+			}
+			buf.WriteString("Content-Type: application/json\r\n")
+			buf.WriteString("Prefer: return-no-content\r\n\r\n")
+			buf.Write(payload)
+			buf.WriteString("\r\n")
+
+		default:
+			return nil, fmt.Errorf("unsupported method %q for Entity (Partition Key %q / Row Key %q)", operation.Method, operation.PartitionKey, operation.RowKey)
+		}
+	}
+
+	fmt.Fprintf(&buf, "--%s--\r\n", changeSetBoundary)
+	fmt.Fprintf(&buf, "--%s--\r\n", batchBoundary)
+
+	return buf.Bytes(), nil
+}
+
+// encodeTableEntityKey escapes a Partition Key or Row Key for use within the URI of an Entity
+func encodeTableEntityKey(input string) string {
+	return url.PathEscape(strings.ReplaceAll(input, "'", "''"))
+}
+
+// parseTableEntityBatchResponse returns an error containing the first failed operation within the response to
+// an Entity Group Transaction, since when any operation fails the service only returns the failed operation
+func parseTableEntityBatchResponse(input string) error {
+	lines := strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+
+	found := false
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "HTTP/1.1 ") {
+			continue
+		}
+		found = true
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("parsing the status line %q of the Entity Group Transaction", line)
+		}
+		statusCode, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fmt.Errorf("parsing the status code from %q of the Entity Group Transaction: %+v", line, err)
+		}
+		if statusCode < 300 {
+			continue
+		}
+
+		details := make([]string, 0)
+		for _, v := range lines[i+1:] {
+			if strings.HasPrefix(v, "--") {
+				break
+			}
+			if strings.HasPrefix(v, "{") {
+				details = append(details, strings.TrimSpace(v))
+			}
+		}
+
+		return fmt.Errorf("the Entity Group Transaction failed with %q: %s", strings.TrimPrefix(line, "HTTP/1.1 "), strings.Join(details, " "))
+	}
+
+	if !found {
+		return fmt.Errorf("the response of the Entity Group Transaction contained no operations")
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGroupTableEntityBatchOperations(t *testing.T) {
+	operations := make([]tableEntityBatchOperation, 0)
+	for i := 0; i < 150; i++ {
+		operations = append(operations, tableEntityBatchOperation{
+			Method:       http.MethodPut,
+			PartitionKey: "partition2",
+			RowKey:       fmt.Sprintf("row%03d", i),
+		})
+	}
+	operations = append(operations, tableEntityBatchOperation{
+		Method:       http.MethodDelete,
+		PartitionKey: "partition1",
+		RowKey:       "row1",
+	})
+
+	batches, err := groupTableEntityBatchOperations(operations)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if len(batches) != 3 {
+		t.Fatalf("Expected 3 batches but got %d", len(batches))
+	}
+
+	expected := []struct {
+		PartitionKey string
+		Count        int
+	}{
+		{PartitionKey: "partition1", Count: 1},
+		{PartitionKey: "partition2", Count: 100},
+		{PartitionKey: "partition2", Count: 50},
+	}
+	for i, v := range expected {
+		if len(batches[i]) != v.Count {
+			t.Fatalf("Expected batch %d to contain %d operations but got %d", i, v.Count, len(batches[i]))
+		}
+		for _, operation := range batches[i] {
+			if operation.PartitionKey != v.PartitionKey {
+				t.Fatalf("Expected batch %d to only contain Partition Key %q but got %q", i, v.PartitionKey, operation.PartitionKey)
+			}
+		}
+	}
+
+	if batches[1][0].RowKey != "row000" || batches[2][0].RowKey != "row100" {
+		t.Fatalf("Expected the operations to be ordered by Row Key")
+	}
+}
+
+func TestGroupTableEntityBatchOperationsBySize(t *testing.T) {
+	// each Entity is ~512KB, so at most 7 fit within the 4MB limit of an Entity Group Transaction
+	value := strings.Repeat("a", 512*1024)
+	operations := make([]tableEntityBatchOperation, 0)
+	for i := 0; i < 10; i++ {
+		operations = append(operations, tableEntityBatchOperation{
+			Method:       http.MethodPut,
+			PartitionKey: "partition1",
+			RowKey:       fmt.Sprintf("row%03d", i),
+			Entity: map[string]interface{}{
+				"Value": value,
+			},
+		})
+	}
+
+	batches, err := groupTableEntityBatchOperations(operations)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("Expected 2 batches but got %d", len(batches))
+	}
+	if len(batches[0]) != 7 || len(batches[1]) != 3 {
+		t.Fatalf("Expected batches of 7 and 3 operations but got %d and %d", len(batches[0]), len(batches[1]))
+	}
+
+	for i, batch := range batches {
+		body, err := buildTableEntityBatchBody("https://account1.table.core.windows.net", "table1", "batch_1", "changeset_1", batch)
+		if err != nil {
+			t.Fatalf("Expected no error building batch %d but got: %+v", i, err)
+		}
+		if len(body) > tableEntityBatchMaxSize {
+			t.Fatalf("Expected batch %d to be at most %d bytes but got %d", i, tableEntityBatchMaxSize, len(body))
+		}
+	}
+}
+
+func TestBuildTableEntityBatchBody(t *testing.T) {
+	operations := []tableEntityBatchOperation{
+		{
+			Method:       http.MethodPut,
+			PartitionKey: "partition1",
+			RowKey:       "o'brien",
+			Entity: map[string]interface{}{
+				"Name": "hello",
+			},
+		},
+		{
+			Method:       http.MethodDelete,
+			PartitionKey: "partition1",
+			RowKey:       "row2",
+		},
+	}
+
+	body, err := buildTableEntityBatchBody("https://account1.table.core.windows.net", "table1", "batch_1", "changeset_1", operations)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	expected := strings.Join([]string{
+		"--batch_1",
+		"Content-Type: multipart/mixed; boundary=changeset_1",
+		"",
+		"--changeset_1",
+		"Content-Type: application/http",
+		"Content-Transfer-Encoding: binary",
+		"",
+		"PUT https://account1.table.core.windows.net/table1(PartitionKey='partition1',RowKey='o%27%27brien') HTTP/1.1",
+		"Accept: application/json;odata=minimalmetadata",
+		"DataServiceVersion: 3.0;",
+		"Content-Type: application/json",
+		"Prefer: return-no-content",
+		"",
+		`{"Name":"hello"}`,
+		"--changeset_1",
+		"Content-Type: application/http",
+		"Content-Transfer-Encoding: binary",
+		"",
+		"DELETE https://account1.table.core.windows.net/table1(PartitionKey='partition1',RowKey='row2') HTTP/1.1",
+		"Accept: application/json;odata=minimalmetadata",
+		"DataServiceVersion: 3.0;",
+		"If-Match: *",
+		"",
+		"--changeset_1--",
+		"--batch_1--",
+		"",
+	}, "\r\n")
+
+	if string(body) != expected {
+		t.Fatalf("Expected:\n%s\nbut got:\n%s", expected, string(body))
+	}
+
+	if _, err := buildTableEntityBatchBody("https://account1.table.core.windows.net", "table1", "batch_1", "changeset_1", []tableEntityBatchOperation{{Method: http.MethodPatch}}); err == nil {
+		t.Fatalf("Expected an error for an unsupported method but didn't get one")
+	}
+}
+
+func TestParseTableEntityBatchResponse(t *testing.T) {
+	testData := []struct {
+		Name  string
+		Input string
+		Error bool
+	}{
+		{
+			Name: "success",
+			Input: strings.Join([]string{
+				"--batchresponse_1",
+				"Content-Type: multipart/mixed; boundary=changesetresponse_1",
+				"",
+				"--changesetresponse_1",
+				"Content-Type: application/http",
+				"Content-Transfer-Encoding: binary",
+				"",
+				"HTTP/1.1 204 No Content",
+				"X-Content-Type-Options: nosniff",
+				"",
+				"--changesetresponse_1",
+				"Content-Type: application/http",
+				"Content-Transfer-Encoding: binary",
+				"",
+				"HTTP/1.1 204 No Content",
+				"",
+				"--changesetresponse_1--",
+				"--batchresponse_1--",
+			}, "\r\n"),
+		},
+		{
+			Name: "failure",
+			Input: strings.Join([]string{
+				"--batchresponse_1",
+				"Content-Type: multipart/mixed; boundary=changesetresponse_1",
+				"",
+				"--changesetresponse_1",
+				"Content-Type: application/http",
+				"Content-Transfer-Encoding: binary",
+				"",
+				"HTTP/1.1 404 Not Found",
+				"Content-Type: application/json;odata=minimalmetadata;streaming=true;charset=utf-8",
+				"",
+				`{"odata.error":{"code":"ResourceNotFound","message":{"lang":"en-US","value":"1:The specified resource does not exist."}}}`,
+				"--changesetresponse_1--",
+				"--batchresponse_1--",
+			}, "\r\n"),
+			Error: true,
+		},
+		{
+			Name:  "empty",
+			Input: "",
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := parseTableEntityBatchResponse(v.Input)
+		if v.Error && err == nil {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+		if !v.Error && err != nil {
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+	}
+}
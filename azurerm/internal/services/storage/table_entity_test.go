@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandStorageTableEntityProperties(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    map[string]interface{}
+		Expected map[string]interface{}
+		Error    bool
+	}{
+		{
+			Name: "untyped",
+			Input: map[string]interface{}{
+				"Name": "hello",
+			},
+			Expected: map[string]interface{}{
+				"Name": "hello",
+			},
+		},
+		{
+			Name: "explicit string",
+			Input: map[string]interface{}{
+				"Name":            "hello",
+				"Name@odata.type": "Edm.String",
+			},
+			Expected: map[string]interface{}{
+				"Name": "hello",
+			},
+		},
+		{
+			Name: "typed",
+			Input: map[string]interface{}{
+				"Enabled":             "true",
+				"Enabled@odata.type":  "Edm.Boolean",
+				"Count":               "42",
+				"Count@odata.type":    "Edm.Int32",
+				"Big":                 "9223372036854775807",
+				"Big@odata.type":      "Edm.Int64",
+				"Ratio":               "1.5",
+				"Ratio@odata.type":    "Edm.Double",
+				"Created":             "2021-04-01T12:00:00+01:00",
+				"Created@odata.type":  "Edm.DateTime",
+				"Id":                  "a6b40a6c-5d2f-4c8a-9a2b-0a7d3c1c2f11",
+				"Id@odata.type":       "Edm.Guid",
+				"Payload":             "aGVsbG8=",
+				"Payload@odata.type":  "Edm.Binary",
+				"Infinite":            "Infinity",
+				"Infinite@odata.type": "Edm.Double",
+			},
+			Expected: map[string]interface{}{
+				"Enabled":             true,
+				"Enabled@odata.type":  "Edm.Boolean",
+				"Count":               int32(42),
+				"Count@odata.type":    "Edm.Int32",
+				"Big":                 "9223372036854775807",
+				"Big@odata.type":      "Edm.Int64",
+				"Ratio":               1.5,
+				"Ratio@odata.type":    "Edm.Double",
+				"Created":             "2021-04-01T11:00:00Z",
+				"Created@odata.type":  "Edm.DateTime",
+				"Id":                  "a6b40a6c-5d2f-4c8a-9a2b-0a7d3c1c2f11",
+				"Id@odata.type":       "Edm.Guid",
+				"Payload":             "aGVsbG8=",
+				"Payload@odata.type":  "Edm.Binary",
+				"Infinite":            "Infinity",
+				"Infinite@odata.type": "Edm.Double",
+			},
+		},
+		{
+			Name: "annotation without a property",
+			Input: map[string]interface{}{
+				"Count@odata.type": "Edm.Int32",
+			},
+			Error: true,
+		},
+		{
+			Name: "unsupported type",
+			Input: map[string]interface{}{
+				"Count":            "1",
+				"Count@odata.type": "Edm.Decimal",
+			},
+			Error: true,
+		},
+		{
+			Name: "int32 overflow",
+			Input: map[string]interface{}{
+				"Count":            "2147483648",
+				"Count@odata.type": "Edm.Int32",
+			},
+			Error: true,
+		},
+		{
+			Name: "invalid guid",
+			Input: map[string]interface{}{
+				"Id":            "not-a-guid",
+				"Id@odata.type": "Edm.Guid",
+			},
+			Error: true,
+		},
+		{
+			Name: "invalid binary",
+			Input: map[string]interface{}{
+				"Payload":            "!!",
+				"Payload@odata.type": "Edm.Binary",
+			},
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := expandStorageTableEntityProperties(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+
+		if !reflect.DeepEqual(v.Expected, actual) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestFlattenStorageTableEntityProperties(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    map[string]interface{}
+		Existing map[string]interface{}
+		Expected map[string]interface{}
+	}{
+		{
+			Name: "system properties and metadata are removed",
+			Input: map[string]interface{}{
+				"odata.metadata":       "https://account1.table.core.windows.net/$metadata#table1/@Element",
+				"odata.etag":           "W/\"datetime'2021-04-01T00%3A00%3A00Z'\"",
+				"PartitionKey":         "partition1",
+				"RowKey":               "row1",
+				"Timestamp":            "2021-04-01T00:00:00Z",
+				"Timestamp@odata.type": "Edm.DateTime",
+				"Name":                 "hello",
+			},
+			Expected: map[string]interface{}{
+				"Name": "hello",
+			},
+		},
+		{
+			Name: "inferred types",
+			Input: map[string]interface{}{
+				"Enabled": true,
+				"Count":   float64(42),
+				"Ratio":   1.5,
+			},
+			Expected: map[string]interface{}{
+				"Enabled":            "true",
+				"Enabled@odata.type": "Edm.Boolean",
+				"Count":              "42",
+				"Count@odata.type":   "Edm.Int32",
+				"Ratio":              "1.5",
+				"Ratio@odata.type":   "Edm.Double",
+			},
+		},
+		{
+			Name: "annotated types",
+			Input: map[string]interface{}{
+				"Big":                "9223372036854775807",
+				"Big@odata.type":     "Edm.Int64",
+				"Whole":              float64(2),
+				"Whole@odata.type":   "Edm.Double",
+				"Created":            "2021-04-01T11:00:00Z",
+				"Created@odata.type": "Edm.DateTime",
+			},
+			Expected: map[string]interface{}{
+				"Big":                "9223372036854775807",
+				"Big@odata.type":     "Edm.Int64",
+				"Whole":              "2",
+				"Whole@odata.type":   "Edm.Double",
+				"Created":            "2021-04-01T11:00:00Z",
+				"Created@odata.type": "Edm.DateTime",
+			},
+		},
+		{
+			Name: "existing representations are retained",
+			Input: map[string]interface{}{
+				"Name":               "hello",
+				"Whole":              float64(2),
+				"Whole@odata.type":   "Edm.Double",
+				"Created":            "2021-04-01T11:00:00Z",
+				"Created@odata.type": "Edm.DateTime",
+				"Enabled":            true,
+			},
+			Existing: map[string]interface{}{
+				"Name":               "hello",
+				"Name@odata.type":    "Edm.String",
+				"Whole":              "2.0",
+				"Whole@odata.type":   "Edm.Double",
+				"Created":            "2021-04-01T12:00:00+01:00",
+				"Created@odata.type": "Edm.DateTime",
+				"Enabled":            "True",
+				"Enabled@odata.type": "Edm.Boolean",
+			},
+			Expected: map[string]interface{}{
+				"Name":               "hello",
+				"Name@odata.type":    "Edm.String",
+				"Whole":              "2.0",
+				"Whole@odata.type":   "Edm.Double",
+				"Created":            "2021-04-01T12:00:00+01:00",
+				"Created@odata.type": "Edm.DateTime",
+				"Enabled":            "True",
+				"Enabled@odata.type": "Edm.Boolean",
+			},
+		},
+		{
+			Name: "changed values are returned",
+			Input: map[string]interface{}{
+				"Count": float64(43),
+			},
+			Existing: map[string]interface{}{
+				"Count":            "42",
+				"Count@odata.type": "Edm.Int32",
+			},
+			Expected: map[string]interface{}{
+				"Count":            "43",
+				"Count@odata.type": "Edm.Int32",
+			},
+		},
+		{
+			Name: "changed types are returned",
+			Input: map[string]interface{}{
+				"Count": "42",
+			},
+			Existing: map[string]interface{}{
+				"Count":            "42",
+				"Count@odata.type": "Edm.Int32",
+			},
+			Expected: map[string]interface{}{
+				"Count": "42",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := flattenStorageTableEntityProperties(v.Input, v.Existing)
+		if !reflect.DeepEqual(v.Expected, actual) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func BlobPropertiesDefaultServiceVersion(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	// the API Versions for the Storage data-plane are dates, the oldest of which is `2008-10-27`
+	if !regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a Storage API Version in the format `YYYY-MM-DD`, got %q", k, value))
+		return
+	}
+
+	if value < "2008-10-27" {
+		errors = append(errors, fmt.Errorf("%q must be `2008-10-27` or later, got %q", k, value))
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,39 @@
+package validate
+
+import "testing"
+
+func TestBlobPropertiesDefaultServiceVersion(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "2019-07",
+			ErrCount: 1,
+		},
+		{
+			Value:    "2006-01-01",
+			ErrCount: 1,
+		},
+		{
+			Value:    "2008-10-27",
+			ErrCount: 0,
+		},
+		{
+			Value:    "2020-06-12",
+			ErrCount: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := BlobPropertiesDefaultServiceVersion(tc.Value, "default_service_version")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d errors for %q but got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
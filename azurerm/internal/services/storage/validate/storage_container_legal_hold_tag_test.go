@@ -0,0 +1,53 @@
+package validate
+
+import (
+	"testing"
+)
+
+func TestStorageContainerLegalHoldTag(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "ab",
+			ErrCount: 1,
+		},
+		{
+			Value:    "abc",
+			ErrCount: 0,
+		},
+		{
+			Value:    "case2021audit",
+			ErrCount: 0,
+		},
+		{
+			Value:    "Case2021",
+			ErrCount: 1,
+		},
+		{
+			Value:    "case-2021",
+			ErrCount: 1,
+		},
+		{
+			Value:    "abcdefghijklmnopqrstuvw",
+			ErrCount: 0,
+		},
+		{
+			Value:    "abcdefghijklmnopqrstuvwx",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := StorageContainerLegalHoldTag(tc.Value, "legal_hold_tags")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected the Legal Hold Tag %q to trigger %d validation errors but got %d", tc.Value, tc.ErrCount, len(errors))
+		}
+	}
+}
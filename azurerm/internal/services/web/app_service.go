@@ -9,12 +9,14 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/parse"
-	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/msi/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/identity"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tf/suppress"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+type appServiceIdentity = identity.SystemAssignedUserAssigned
+
 func schemaAppServiceAadAuthSettings() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
@@ -228,47 +230,13 @@ func schemaAppServiceAuthSettings() *schema.Schema {
 }
 
 func schemaAppServiceIdentity() *schema.Schema {
-	return &schema.Schema{
-		Type:     schema.TypeList,
-		Optional: true,
-		Computed: true,
-		MaxItems: 1,
-		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{
-				"identity_ids": {
-					Type:     schema.TypeList,
-					Optional: true,
-					MinItems: 1,
-					Elem: &schema.Schema{
-						Type:         schema.TypeString,
-						ValidateFunc: validate.UserAssignedIdentityID,
-					},
-				},
-
-				"type": {
-					Type:     schema.TypeString,
-					Required: true,
-					ValidateFunc: validation.StringInSlice([]string{
-						string(web.ManagedServiceIdentityTypeNone),
-						string(web.ManagedServiceIdentityTypeSystemAssigned),
-						string(web.ManagedServiceIdentityTypeSystemAssignedUserAssigned),
-						string(web.ManagedServiceIdentityTypeUserAssigned),
-					}, true),
-					DiffSuppressFunc: suppress.CaseDifference,
-				},
-
-				"principal_id": {
-					Type:     schema.TypeString,
-					Computed: true,
-				},
-
-				"tenant_id": {
-					Type:     schema.TypeString,
-					Computed: true,
-				},
-			},
-		},
+	output := appServiceIdentity{}.Schema()
+	output.Computed = true
+	if !features.ThreePointOh() {
+		// `identity_ids` was previously a List
+		output.Elem.(*schema.Resource).Schema["identity_ids"].Type = schema.TypeList
 	}
+	return output
 }
 
 func schemaAppServiceSiteConfig() *schema.Schema {
@@ -1469,63 +1437,30 @@ func expandAppServiceLogs(input interface{}) web.SiteLogsConfigProperties {
 	return logs
 }
 
-func expandAppServiceIdentity(input []interface{}) *web.ManagedServiceIdentity {
+func expandAppServiceIdentity(input []interface{}) (*web.ManagedServiceIdentity, error) {
 	if len(input) == 0 {
-		return nil
-	}
-	identity := input[0].(map[string]interface{})
-	identityType := web.ManagedServiceIdentityType(identity["type"].(string))
-
-	identityIds := make(map[string]*web.ManagedServiceIdentityUserAssignedIdentitiesValue)
-	for _, id := range identity["identity_ids"].([]interface{}) {
-		identityIds[id.(string)] = &web.ManagedServiceIdentityUserAssignedIdentitiesValue{}
+		return nil, nil
 	}
 
-	managedServiceIdentity := web.ManagedServiceIdentity{
-		Type: identityType,
+	// the `identity_ids` were previously passed through as-is, regardless of the `type`
+	config, err := appServiceIdentity{}.ExpandWithoutValidation(input)
+	if err != nil {
+		return nil, err
 	}
 
-	if managedServiceIdentity.Type == web.ManagedServiceIdentityTypeUserAssigned || managedServiceIdentity.Type == web.ManagedServiceIdentityTypeSystemAssignedUserAssigned {
-		managedServiceIdentity.UserAssignedIdentities = identityIds
+	var output web.ManagedServiceIdentity
+	if err := identity.ExpandTo(config, &output); err != nil {
+		return nil, err
 	}
-
-	return &managedServiceIdentity
+	return &output, nil
 }
 
-func flattenAppServiceIdentity(identity *web.ManagedServiceIdentity) ([]interface{}, error) {
-	if identity == nil {
-		return make([]interface{}, 0), nil
-	}
-
-	principalId := ""
-	if identity.PrincipalID != nil {
-		principalId = *identity.PrincipalID
+func flattenAppServiceIdentity(input *web.ManagedServiceIdentity) ([]interface{}, error) {
+	config, err := identity.FlattenFrom(input)
+	if err != nil {
+		return nil, err
 	}
-
-	tenantId := ""
-	if identity.TenantID != nil {
-		tenantId = *identity.TenantID
-	}
-
-	identityIds := make([]string, 0)
-	if identity.UserAssignedIdentities != nil {
-		for key := range identity.UserAssignedIdentities {
-			parsedId, err := parse.UserAssignedIdentityID(key)
-			if err != nil {
-				return nil, err
-			}
-			identityIds = append(identityIds, parsedId.ID())
-		}
-	}
-
-	return []interface{}{
-		map[string]interface{}{
-			"identity_ids": identityIds,
-			"principal_id": principalId,
-			"tenant_id":    tenantId,
-			"type":         string(identity.Type),
-		},
-	}, nil
+	return appServiceIdentity{}.FlattenIncludingNone(config), nil
 }
 
 func expandAppServiceSiteConfig(input interface{}) (*web.SiteConfig, error) {
@@ -16,6 +16,10 @@ import (
 
 type AppServiceEnvironmentResource struct{}
 
+func (AppServiceEnvironmentResource) ResourceCost() int {
+	return 3
+}
+
 func TestAccAppServiceEnvironment_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_app_service_environment", "test")
 	r := AppServiceEnvironmentResource{}
@@ -0,0 +1,163 @@
+package web
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/web/mgmt/2020-06-01/web"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const testUserAssignedIdentityId = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"
+
+func TestExpandAppServiceIdentity(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    []interface{}
+		expected *web.ManagedServiceIdentity
+	}{
+		{
+			name:     "omitted",
+			input:    []interface{}{},
+			expected: nil,
+		},
+		{
+			name: "none",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "None",
+					"identity_ids": []interface{}{},
+				},
+			},
+			expected: &web.ManagedServiceIdentity{
+				Type: web.ManagedServiceIdentityTypeNone,
+			},
+		},
+		{
+			name: "system assigned",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned",
+					"identity_ids": []interface{}{},
+				},
+			},
+			expected: &web.ManagedServiceIdentity{
+				Type: web.ManagedServiceIdentityTypeSystemAssigned,
+			},
+		},
+		{
+			name: "system assigned, user assigned",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned, UserAssigned",
+					"identity_ids": []interface{}{testUserAssignedIdentityId},
+				},
+			},
+			expected: &web.ManagedServiceIdentity{
+				Type: web.ManagedServiceIdentityTypeSystemAssignedUserAssigned,
+				UserAssignedIdentities: map[string]*web.ManagedServiceIdentityUserAssignedIdentitiesValue{
+					testUserAssignedIdentityId: {},
+				},
+			},
+		},
+		{
+			// this was previously passed through to the API as-is
+			name: "user assigned without identity ids",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "UserAssigned",
+					"identity_ids": []interface{}{},
+				},
+			},
+			expected: &web.ManagedServiceIdentity{
+				Type:                   web.ManagedServiceIdentityTypeUserAssigned,
+				UserAssignedIdentities: map[string]*web.ManagedServiceIdentityUserAssignedIdentitiesValue{},
+			},
+		},
+		{
+			// these were previously ignored
+			name: "system assigned with identity ids",
+			input: []interface{}{
+				map[string]interface{}{
+					"type":         "SystemAssigned",
+					"identity_ids": []interface{}{testUserAssignedIdentityId},
+				},
+			},
+			expected: &web.ManagedServiceIdentity{
+				Type: web.ManagedServiceIdentityTypeSystemAssigned,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := expandAppServiceIdentity(v.input)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestFlattenAppServiceIdentity(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    *web.ManagedServiceIdentity
+		expected []interface{}
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: []interface{}{},
+		},
+		{
+			// `None` was previously flattened into the `identity` block, removing it would cause a diff
+			name: "none",
+			input: &web.ManagedServiceIdentity{
+				Type: web.ManagedServiceIdentityTypeNone,
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"type":         "None",
+					"identity_ids": []interface{}{},
+					"principal_id": "",
+					"tenant_id":    "",
+				},
+			},
+		},
+		{
+			name: "user assigned",
+			input: &web.ManagedServiceIdentity{
+				Type:        web.ManagedServiceIdentityTypeUserAssigned,
+				PrincipalID: utils.String("11111111-1111-1111-1111-111111111111"),
+				TenantID:    utils.String("22222222-2222-2222-2222-222222222222"),
+				UserAssignedIdentities: map[string]*web.ManagedServiceIdentityUserAssignedIdentitiesValue{
+					testUserAssignedIdentityId: {},
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"type":         "UserAssigned",
+					"identity_ids": []interface{}{testUserAssignedIdentityId},
+					"principal_id": "11111111-1111-1111-1111-111111111111",
+					"tenant_id":    "22222222-2222-2222-2222-222222222222",
+				},
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := flattenAppServiceIdentity(v.input)
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
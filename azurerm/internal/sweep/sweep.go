@@ -0,0 +1,185 @@
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/provider"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+)
+
+// randomTimeIntRegex matches the value generated by `acceptance.RandTimeInt`, which is in the format
+// YYMMddHHmmsshhRRRR - where the first 12 characters are the (local) time the test was started
+var randomTimeIntRegex = regexp.MustCompile(`\d{18}`)
+
+type Options struct {
+	// OlderThan is the minimum age of a resource for it to be swept
+	OlderThan time.Duration
+
+	// DryRun specifies that resources which would be swept should only be reported, rather than deleted
+	DryRun bool
+
+	// now returns the current time, which can be overridden in tests
+	now func() time.Time
+}
+
+type Result struct {
+	// Swept is the list of resources which were swept (or, in Dry Run mode, would have been)
+	Swept []SweptResource
+
+	// Errors is a list of errors encountered whilst listing or deleting resources
+	Errors []error
+}
+
+type SweptResource struct {
+	// Sweeper is the Name of the Sweeper which found this resource
+	Sweeper string
+
+	// Resource is the resource which was swept
+	Resource sdk.SweepableResource
+
+	// Age is the age of this resource when it was swept
+	Age time.Duration
+}
+
+// Sweepers returns the Sweepers declared by the Service Registrations, optionally filtered
+// to the Service with the specified Name (e.g. `KeyVault`)
+func Sweepers(serviceName string) ([]sdk.Sweeper, error) {
+	registrations := make([]interface{}, 0)
+	for _, service := range provider.SupportedTypedServices() {
+		registrations = append(registrations, service)
+	}
+	for _, service := range provider.SupportedUntypedServices() {
+		registrations = append(registrations, service)
+	}
+
+	output := make([]sdk.Sweeper, 0)
+	found := false
+
+	// a Service Registration can be both Typed and Untyped, so is only included once
+	seen := make(map[string]struct{})
+	for _, registration := range registrations {
+		name := registrationName(registration)
+		if serviceName != "" && !strings.EqualFold(name, serviceName) {
+			continue
+		}
+		found = true
+
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		if v, ok := registration.(sdk.ServiceRegistrationWithSweepers); ok {
+			output = append(output, v.Sweepers()...)
+		}
+	}
+
+	if serviceName != "" && !found {
+		return nil, fmt.Errorf("the Service %q was not found", serviceName)
+	}
+
+	return output, nil
+}
+
+func registrationName(input interface{}) string {
+	if v, ok := input.(sdk.TypedServiceRegistration); ok {
+		return v.Name()
+	}
+	if v, ok := input.(sdk.UntypedServiceRegistration); ok {
+		return v.Name()
+	}
+	return ""
+}
+
+// Run lists the resources for each of the specified Sweepers and deletes those whose Name begins
+// with one of the Sweeper's Prefixes and which are older than the specified threshold
+func Run(ctx context.Context, client *clients.Client, sweepers []sdk.Sweeper, options Options) Result {
+	now := time.Now
+	if options.now != nil {
+		now = options.now
+	}
+
+	result := Result{
+		Swept:  make([]SweptResource, 0),
+		Errors: make([]error, 0),
+	}
+
+	for _, sweeper := range sweepers {
+		log.Printf("[DEBUG] Listing %s..", sweeper.Name)
+		resources, err := sweeper.List(ctx, client)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %+v", sweeper.Name, err))
+			continue
+		}
+		if resources == nil {
+			continue
+		}
+
+		for _, resource := range *resources {
+			if !hasPrefix(resource.Name, sweeper.Prefixes) {
+				continue
+			}
+
+			age, ok := ageOf(resource, now())
+			if !ok {
+				log.Printf("[DEBUG] Skipping %q since its age couldn't be determined", resource.ID)
+				continue
+			}
+			if age < options.OlderThan {
+				log.Printf("[DEBUG] Skipping %q since it's only %s old", resource.ID, age)
+				continue
+			}
+
+			if !options.DryRun {
+				log.Printf("[DEBUG] Deleting %q..", resource.ID)
+				if err := sweeper.Delete(ctx, client, resource); err != nil {
+					result.Errors = append(result.Errors, fmt.Errorf("%s: %+v", sweeper.Name, err))
+					continue
+				}
+			}
+
+			result.Swept = append(result.Swept, SweptResource{
+				Sweeper:  sweeper.Name,
+				Resource: resource,
+				Age:      age,
+			})
+		}
+	}
+
+	return result
+}
+
+func hasPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ageOf returns the age of the specified resource - either from its Timestamp (when known) or from
+// the timestamp embedded in its Name by `acceptance.RandTimeInt`
+func ageOf(resource sdk.SweepableResource, now time.Time) (time.Duration, bool) {
+	if resource.Timestamp != nil {
+		return now.Sub(*resource.Timestamp), true
+	}
+
+	match := randomTimeIntRegex.FindString(resource.Name)
+	if match == "" {
+		return 0, false
+	}
+
+	created, err := time.ParseInLocation("060102150405", match[0:12], time.Local)
+	if err != nil {
+		return 0, false
+	}
+
+	return now.Sub(created), true
+}
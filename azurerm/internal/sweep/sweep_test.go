@@ -0,0 +1,323 @@
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/common"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sdk"
+	apiManagementClient "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/apimanagement/client"
+	keyVaultClient "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/keyvault/client"
+	logAnalyticsClient "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/loganalytics/client"
+	resourceClient "github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/services/resource/client"
+)
+
+const testSubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+var testResourceGroups = []string{"acctestRG-210101120000001234", "acctestRG-210110110000001234", "acctestRG-manual", "production"}
+
+// fakeResourceManager is a fake Azure Resource Manager API containing Resource Groups and Soft-Deleted
+// Key Vaults, API Management Services and Log Analytics Workspaces
+type fakeResourceManager struct {
+	lock     sync.Mutex
+	requests []string
+
+	// failWorkspaceRecovery specifies that recovering a Soft-Deleted Log Analytics Workspace should fail
+	failWorkspaceRecovery bool
+}
+
+func (f *fakeResourceManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		f.requests = append(f.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	}
+	f.lock.Unlock()
+
+	subscriptionPrefix := fmt.Sprintf("/subscriptions/%s", testSubscriptionId)
+	path := strings.TrimPrefix(r.URL.Path, subscriptionPrefix)
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodGet && path == "/resourcegroups":
+		groups := make([]string, 0)
+		for _, name := range testResourceGroups {
+			groups = append(groups, fmt.Sprintf(`{"id": "%s/resourceGroups/%s", "name": "%s", "location": "westeurope"}`, subscriptionPrefix, name, name))
+		}
+		fmt.Fprintf(w, `{"value": [%s]}`, strings.Join(groups, ","))
+
+	case r.Method == http.MethodGet && path == "/providers/Microsoft.KeyVault/deletedVaults":
+		vaults := make([]string, 0)
+		for name, deletionDate := range map[string]string{"acctestkv-old": "2021-01-01T12:00:00Z", "acctestkv-new": "2021-01-10T11:00:00Z", "vault-production": "2021-01-01T12:00:00Z"} {
+			vaults = append(vaults, fmt.Sprintf(`{"id": "%s/providers/Microsoft.KeyVault/locations/westeurope/deletedVaults/%s", "name": "%s", "properties": {"location": "westeurope", "deletionDate": "%s"}}`, subscriptionPrefix, name, name, deletionDate))
+		}
+		fmt.Fprintf(w, `{"value": [%s]}`, strings.Join(vaults, ","))
+
+	case r.Method == http.MethodGet && path == "/providers/Microsoft.ApiManagement/deletedservices":
+		services := make([]string, 0)
+		for name, deletionDate := range map[string]string{"acctestAM-old": "2021-01-01T12:00:00Z", "acctestAM-new": "2021-01-10T11:00:00Z", "production": "2021-01-01T12:00:00Z"} {
+			services = append(services, fmt.Sprintf(`{"id": "%s/providers/Microsoft.ApiManagement/locations/westeurope/deletedservices/%s", "name": "%s", "location": "westeurope", "properties": {"deletionDate": "%s"}}`, subscriptionPrefix, name, name, deletionDate))
+		}
+		fmt.Fprintf(w, `{"value": [%s]}`, strings.Join(services, ","))
+
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/providers/Microsoft.ApiManagement/locations/"):
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodGet && path == "/providers/Microsoft.OperationalInsights/deletedWorkspaces":
+		workspaces := make([]string, 0)
+		for name, resourceGroup := range map[string]string{"acctestLAW-210101120000001234": "acctestRG-210101120000009999", "acctestLAW-210110110000001234": "acctestRG-210110110000001234", "production": "production"} {
+			workspaces = append(workspaces, fmt.Sprintf(`{"id": "%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s", "name": "%s", "location": "westeurope"}`, subscriptionPrefix, resourceGroup, name, name))
+		}
+		fmt.Fprintf(w, `{"value": [%s]}`, strings.Join(workspaces, ","))
+
+	case r.Method == http.MethodPut && strings.Contains(path, "/providers/Microsoft.OperationalInsights/workspaces/"):
+		if f.failWorkspaceRecovery {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": "BadRequest", "message": "unable to recover the workspace"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"location": "westeurope", "properties": {"provisioningState": "Succeeded"}}`)
+
+	case r.Method == http.MethodDelete && strings.Contains(path, "/providers/Microsoft.OperationalInsights/workspaces/"):
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodHead && strings.HasPrefix(path, "/resourcegroups/"):
+		for _, name := range testResourceGroups {
+			if path == "/resourcegroups/"+name {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "/resourcegroups/"):
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"location": "westeurope", "properties": {"provisioningState": "Succeeded"}}`)
+
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/resourcegroups/"):
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/purge"):
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"error": {"code": "NotFound", "message": "%s %s"}}`, r.Method, r.URL.Path)
+	}
+}
+
+func (f *fakeResourceManager) modifyingRequests() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	output := append([]string{}, f.requests...)
+	sort.Strings(output)
+	return output
+}
+
+func testClient(endpoint string) *clients.Client {
+	o := &common.ClientOptions{
+		SubscriptionId:              testSubscriptionId,
+		ResourceManagerAuthorizer:   autorest.NullAuthorizer{},
+		ResourceManagerEndpoint:     endpoint,
+		SkipProviderReg:             true,
+		DisableCorrelationRequestID: true,
+	}
+	return &clients.Client{
+		ApiManagement: apiManagementClient.NewClient(o),
+		KeyVault:      keyVaultClient.NewClient(o),
+		LogAnalytics:  logAnalyticsClient.NewClient(o),
+		Resource:      resourceClient.NewClient(o),
+	}
+}
+
+func testSweepers(t *testing.T, serviceNames ...string) []sdk.Sweeper {
+	output := make([]sdk.Sweeper, 0)
+	for _, serviceName := range serviceNames {
+		sweepers, err := Sweepers(serviceName)
+		if err != nil {
+			t.Fatalf("retrieving Sweepers for %q: %+v", serviceName, err)
+		}
+		output = append(output, sweepers...)
+	}
+	return output
+}
+
+func testOptions(dryRun bool) Options {
+	return Options{
+		OlderThan: 24 * time.Hour,
+		DryRun:    dryRun,
+		now: func() time.Time {
+			return time.Date(2021, 1, 10, 12, 0, 0, 0, time.Local)
+		},
+	}
+}
+
+func sweptNames(result Result) []string {
+	output := make([]string, 0)
+	for _, v := range result.Swept {
+		output = append(output, v.Resource.Name)
+	}
+	sort.Strings(output)
+	return output
+}
+
+func TestRunDryRun(t *testing.T) {
+	fake := &fakeResourceManager{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	result := Run(context.TODO(), testClient(server.URL), testSweepers(t, "Resources", "KeyVault"), testOptions(true))
+	if len(result.Errors) > 0 {
+		t.Fatalf("expected no errors but got %+v", result.Errors)
+	}
+
+	expected := []string{"acctestRG-210101120000001234", "acctestkv-old"}
+	if actual := sweptNames(result); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v to be swept but got %+v", expected, actual)
+	}
+
+	if requests := fake.modifyingRequests(); len(requests) > 0 {
+		t.Fatalf("expected no resources to be deleted in Dry Run mode but got %+v", requests)
+	}
+}
+
+func TestRun(t *testing.T) {
+	fake := &fakeResourceManager{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	result := Run(context.TODO(), testClient(server.URL), testSweepers(t, "Resources", "KeyVault"), testOptions(false))
+	if len(result.Errors) > 0 {
+		t.Fatalf("expected no errors but got %+v", result.Errors)
+	}
+
+	expected := []string{
+		fmt.Sprintf("DELETE /subscriptions/%s/resourcegroups/acctestRG-210101120000001234", testSubscriptionId),
+		fmt.Sprintf("POST /subscriptions/%s/providers/Microsoft.KeyVault/locations/westeurope/deletedVaults/acctestkv-old/purge", testSubscriptionId),
+	}
+	if actual := fake.modifyingRequests(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the requests %+v but got %+v", expected, actual)
+	}
+}
+
+func TestRunApiManagement(t *testing.T) {
+	fake := &fakeResourceManager{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	result := Run(context.TODO(), testClient(server.URL), testSweepers(t, "API Management"), testOptions(false))
+	if len(result.Errors) > 0 {
+		t.Fatalf("expected no errors but got %+v", result.Errors)
+	}
+
+	expected := []string{
+		fmt.Sprintf("DELETE /subscriptions/%s/providers/Microsoft.ApiManagement/locations/westeurope/deletedservices/acctestAM-old", testSubscriptionId),
+	}
+	if actual := fake.modifyingRequests(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the requests %+v but got %+v", expected, actual)
+	}
+}
+
+func TestRunLogAnalytics(t *testing.T) {
+	fake := &fakeResourceManager{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	result := Run(context.TODO(), testClient(server.URL), testSweepers(t, "Log Analytics"), testOptions(false))
+	if len(result.Errors) > 0 {
+		t.Fatalf("expected no errors but got %+v", result.Errors)
+	}
+
+	// the Resource Group no longer exists, so is re-created to recover the Workspace and then removed
+	expected := []string{
+		fmt.Sprintf("DELETE /subscriptions/%s/resourcegroups/acctestRG-210101120000009999", testSubscriptionId),
+		fmt.Sprintf("DELETE /subscriptions/%s/resourcegroups/acctestRG-210101120000009999/providers/Microsoft.OperationalInsights/workspaces/acctestLAW-210101120000001234", testSubscriptionId),
+		fmt.Sprintf("PUT /subscriptions/%s/resourcegroups/acctestRG-210101120000009999", testSubscriptionId),
+		fmt.Sprintf("PUT /subscriptions/%s/resourcegroups/acctestRG-210101120000009999/providers/Microsoft.OperationalInsights/workspaces/acctestLAW-210101120000001234", testSubscriptionId),
+	}
+	if actual := fake.modifyingRequests(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the requests %+v but got %+v", expected, actual)
+	}
+}
+
+func TestRunLogAnalyticsRecoveryFailure(t *testing.T) {
+	fake := &fakeResourceManager{
+		failWorkspaceRecovery: true,
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	result := Run(context.TODO(), testClient(server.URL), testSweepers(t, "Log Analytics"), testOptions(false))
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected a single error but got %+v", result.Errors)
+	}
+
+	// the re-created Resource Group must still be removed when the Workspace can't be recovered
+	expected := []string{
+		fmt.Sprintf("DELETE /subscriptions/%s/resourcegroups/acctestRG-210101120000009999", testSubscriptionId),
+		fmt.Sprintf("PUT /subscriptions/%s/resourcegroups/acctestRG-210101120000009999", testSubscriptionId),
+		fmt.Sprintf("PUT /subscriptions/%s/resourcegroups/acctestRG-210101120000009999/providers/Microsoft.OperationalInsights/workspaces/acctestLAW-210101120000001234", testSubscriptionId),
+	}
+	if actual := fake.modifyingRequests(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the requests %+v but got %+v", expected, actual)
+	}
+}
+
+func TestSweepersUnknownService(t *testing.T) {
+	if _, err := Sweepers("NotAService"); err == nil {
+		t.Fatalf("expected an error for an unknown Service but didn't get one")
+	}
+}
+
+func TestAgeOf(t *testing.T) {
+	now := time.Date(2021, 1, 10, 12, 0, 0, 0, time.Local)
+	deletedAt := now.Add(-2 * time.Hour)
+
+	testData := []struct {
+		resource sdk.SweepableResource
+		expected time.Duration
+		ok       bool
+	}{
+		{
+			resource: sdk.SweepableResource{Name: "acctestRG-210109120000001234"},
+			expected: 24 * time.Hour,
+			ok:       true,
+		},
+		{
+			resource: sdk.SweepableResource{Name: "acctestRG-storage-210110113000001234"},
+			expected: 30 * time.Minute,
+			ok:       true,
+		},
+		{
+			resource: sdk.SweepableResource{Name: "acctestkv-abcde", Timestamp: &deletedAt},
+			expected: 2 * time.Hour,
+			ok:       true,
+		},
+		{
+			resource: sdk.SweepableResource{Name: "acctestkv-abcde"},
+			ok:       false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.resource.Name)
+
+		actual, ok := ageOf(v.resource, now)
+		if ok != v.ok {
+			t.Fatalf("expected ok to be %t but got %t", v.ok, ok)
+		}
+		if actual != v.expected {
+			t.Fatalf("expected an age of %s but got %s", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ImportVerifyIgnoreFields returns the list of fields (in the flattened format used in the
+// State) which can't be verified following an Import, since they've been declared as not
+// being returned from the API - where fields within a nested block are separated by a `.`
+// (e.g. `site_config.password`).
+//
+// Sensitive fields are intentionally still verified, since many are returned from the API -
+// Write-Only values (such as passwords) need to be declared as not being returned.
+//
+// Since `ImportStateVerifyIgnore` matches on a prefix, nested fields within a Set (or a List
+// which can contain multiple items) can't be targeted individually - and as such the whole
+// block is ignored.
+func ImportVerifyIgnoreFields(input map[string]*schema.Schema, notReturnedByAPI []string) ([]string, error) {
+	output := make([]string, 0)
+	for _, field := range notReturnedByAPI {
+		v, err := importVerifyIgnoreField(input, field)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, existing := range output {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			output = append(output, v)
+		}
+	}
+
+	sort.Strings(output)
+	return output, nil
+}
+
+func importVerifyIgnoreField(input map[string]*schema.Schema, field string) (string, error) {
+	segments := strings.Split(field, ".")
+	path := make([]string, 0)
+	fields := input
+
+	// once a block which can contain multiple items is reached the whole block is ignored, however the
+	// remaining segments are still checked to exist within the Schema
+	truncated := false
+	for i, name := range segments {
+		v, ok := fields[name]
+		if !ok {
+			return "", fmt.Errorf("the field %q was not found in the Schema", field)
+		}
+		if !truncated {
+			path = append(path, name)
+		}
+
+		if i == len(segments)-1 {
+			break
+		}
+
+		nested, ok := v.Elem.(*schema.Resource)
+		if !ok {
+			return "", fmt.Errorf("the field %q was not found in the Schema - %q isn't a block", field, strings.Join(segments[:i+1], "."))
+		}
+
+		if !truncated {
+			if v.Type == schema.TypeList && v.MaxItems == 1 {
+				path = append(path, "0")
+			} else {
+				truncated = true
+			}
+		}
+		fields = nested.Schema
+	}
+
+	return strings.Join(path, "."), nil
+}
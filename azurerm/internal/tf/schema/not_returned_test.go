@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestImportVerifyIgnoreFields(t *testing.T) {
+	input := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"password": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+		},
+		"connection_string": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+		},
+		"primary_key": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+		"source": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"single_block": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"secret": {
+						Type:      schema.TypeString,
+						Required:  true,
+						Sensitive: true,
+					},
+					"readable_secret": {
+						Type:      schema.TypeString,
+						Optional:  true,
+						Sensitive: true,
+					},
+					"value": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"set_block": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"token": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"other_block": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"value": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	expected := []string{
+		"password",
+		"set_block",
+		"single_block.0.secret",
+		"source",
+	}
+	notReturnedByAPI := []string{
+		"password",
+		"source",
+		"single_block.secret",
+		"set_block.token",
+		"set_block.token",
+	}
+	actual, err := ImportVerifyIgnoreFields(input, notReturnedByAPI)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestImportVerifyIgnoreFieldsInvalid(t *testing.T) {
+	input := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"set_block": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"token": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	testData := []string{
+		"missing",
+		"name.nested",
+		"set_block.missing",
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v)
+
+		if _, err := ImportVerifyIgnoreFields(input, []string{v}); err == nil {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+	}
+}
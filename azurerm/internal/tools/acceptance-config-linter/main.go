@@ -0,0 +1,918 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/provider"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// NOTE: since we're using `go run` for these tools all of the code needs to live within the main.go
+
+func main() {
+	f := flag.NewFlagSet("acceptance-config-linter", flag.ExitOnError)
+
+	servicesPath := f.String("path", "", "The relative path to the `azurerm/internal/services` directory")
+	serviceName := f.String("service", "", "(Optional) Only lint the Test Configurations for this Service Package, e.g. `storage`")
+	verbose := f.Bool("verbose", false, "(Optional) Output the Test Configurations which couldn't be rendered")
+
+	_ = f.Parse(os.Args[1:])
+
+	if servicesPath == nil || *servicesPath == "" {
+		log.Print("The path to the Services directory must be specified via `-path`")
+		os.Exit(1)
+	}
+
+	schemas := providerSchemas()
+	result, err := run(*servicesPath, *serviceName, schemas)
+	if err != nil {
+		log.Printf("Error linting Test Configurations: %+v", err)
+		os.Exit(1)
+	}
+
+	if *verbose {
+		for _, skipped := range result.skipped {
+			log.Printf("[SKIPPED] %s", skipped)
+		}
+	}
+
+	for _, problem := range result.problems {
+		fmt.Println(problem.String())
+	}
+
+	log.Printf("Linted %d Test Configurations (%d skipped) - found %d problems", result.linted, len(result.skipped), len(result.problems))
+	if len(result.problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// stubTestData is the Test Data used to render each Test Configuration - these values are
+// intentionally recognisable so that hard-coded values can be detected in the rendered output
+var stubTestData = acceptance.TestData{
+	Locations: acceptance.Regions{
+		Primary:   "stublocationprimary",
+		Secondary: "stublocationsecondary",
+		Ternary:   "stublocationternary",
+	},
+	RandomInteger:   210101000000000001,
+	RandomString:    "stubs",
+	ResourceName:    "azurerm_stub.test",
+	ResourceType:    "azurerm_stub",
+	EnvironmentName: "public",
+}
+
+type schemaLookup struct {
+	dataSources map[string]map[string]*schema.Schema
+	resources   map[string]map[string]*schema.Schema
+
+	// importable is a list of Resources which support being Imported
+	importable map[string]struct{}
+}
+
+func providerSchemas() schemaLookup {
+	p := provider.AzureProvider().(*schema.Provider)
+
+	lookup := schemaLookup{
+		dataSources: make(map[string]map[string]*schema.Schema),
+		resources:   make(map[string]map[string]*schema.Schema),
+		importable:  make(map[string]struct{}),
+	}
+	for k, v := range p.DataSourcesMap {
+		lookup.dataSources[k] = v.Schema
+	}
+	for k, v := range p.ResourcesMap {
+		lookup.resources[k] = v.Schema
+		if v.Importer != nil {
+			lookup.importable[k] = struct{}{}
+		}
+	}
+	return lookup
+}
+
+type problem struct {
+	file     string
+	function string
+	message  string
+}
+
+func (p problem) String() string {
+	return fmt.Sprintf("%s: %s: %s", p.file, p.function, p.message)
+}
+
+// invalidCallError is returned when a Test Configuration calls a supported function with
+// arguments that would fail (or panic) at runtime - which is reported as a problem
+type invalidCallError struct {
+	message string
+}
+
+func (e invalidCallError) Error() string {
+	return e.message
+}
+
+type lintResult struct {
+	linted   int
+	problems []problem
+	skipped  []string
+}
+
+func run(servicesPath, serviceName string, schemas schemaLookup) (*lintResult, error) {
+	packages, err := findTestPackages(servicesPath, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := lintResult{
+		problems: make([]problem, 0),
+		skipped:  make([]string, 0),
+	}
+	for _, directory := range packages {
+		if err := lintPackage(directory, schemas, &result); err != nil {
+			return nil, fmt.Errorf("linting %q: %+v", directory, err)
+		}
+	}
+
+	sort.Slice(result.problems, func(i, j int) bool {
+		return result.problems[i].String() < result.problems[j].String()
+	})
+	sort.Strings(result.skipped)
+
+	return &result, nil
+}
+
+// findTestPackages returns the directories containing `*_resource_test.go` files
+func findTestPackages(servicesPath, serviceName string) ([]string, error) {
+	root := servicesPath
+	if serviceName != "" {
+		root = filepath.Join(servicesPath, serviceName)
+	}
+
+	directories := make(map[string]struct{})
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), "_resource_test.go") {
+			return nil
+		}
+
+		directories[filepath.Dir(path)] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]string, 0)
+	for k := range directories {
+		output = append(output, k)
+	}
+	sort.Strings(output)
+	return output, nil
+}
+
+// configEntryPoint is a call to a Test Configuration function found within a Test Step
+type configEntryPoint struct {
+	file     string
+	receiver string
+	method   string
+	args     []ast.Expr
+}
+
+func lintPackage(directory string, schemas schemaLookup, result *lintResult) error {
+	fileSet := token.NewFileSet()
+	packages, err := parser.ParseDir(fileSet, directory, func(info os.FileInfo) bool {
+		return strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return err
+	}
+
+	r := renderer{
+		functions: make(map[string]*ast.FuncDecl),
+	}
+	entryPoints := make([]configEntryPoint, 0)
+	importSteps := make(map[string]*importStepUsage)
+	for _, pkg := range packages {
+		for fileName, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+					continue
+				}
+				r.functions[functionKey(receiverTypeName(fn.Recv.List[0].Type), fn.Name.Name)] = fn
+			}
+
+			if !strings.HasSuffix(fileName, "_resource_test.go") {
+				continue
+			}
+			entryPoints = append(entryPoints, findEntryPoints(filepath.Base(fileName), file)...)
+			findImportSteps(filepath.Base(fileName), file, importSteps)
+		}
+	}
+
+	for resourceType, usage := range importSteps {
+		if _, importable := schemas.importable[resourceType]; !importable || usage.found {
+			continue
+		}
+
+		result.problems = append(result.problems, problem{
+			file:     filepath.Join(filepath.Base(directory), usage.file),
+			function: resourceType,
+			message:  "this Resource supports Import but none of the Tests include an Import Step",
+		})
+	}
+
+	seen := make(map[string]struct{})
+	for _, entryPoint := range entryPoints {
+		name := functionKey(entryPoint.receiver, entryPoint.method)
+		relativePath := filepath.Join(filepath.Base(directory), entryPoint.file)
+
+		config, err := r.renderEntryPoint(entryPoint)
+		if err != nil {
+			// a Test Configuration which can't be rendered at all is a problem, rather than something to skip
+			if invalid, ok := err.(invalidCallError); ok {
+				result.problems = append(result.problems, problem{
+					file:     relativePath,
+					function: name,
+					message:  invalid.Error(),
+				})
+				continue
+			}
+
+			result.skipped = append(result.skipped, fmt.Sprintf("%s: %s: %+v", relativePath, name, err))
+			continue
+		}
+
+		// the same configuration can be used in multiple Test Steps
+		uniqueKey := fmt.Sprintf("%s/%s", name, config)
+		if _, exists := seen[uniqueKey]; exists {
+			continue
+		}
+		seen[uniqueKey] = struct{}{}
+
+		result.linted++
+		for _, message := range lintConfig(config, schemas) {
+			result.problems = append(result.problems, problem{
+				file:     relativePath,
+				function: name,
+				message:  message,
+			})
+		}
+	}
+
+	return nil
+}
+
+func functionKey(receiver, method string) string {
+	return fmt.Sprintf("%s.%s", receiver, method)
+}
+
+func receiverTypeName(expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.Ident:
+		return v.Name
+	case *ast.StarExpr:
+		return receiverTypeName(v.X)
+	case *ast.CompositeLit:
+		return receiverTypeName(v.Type)
+	}
+	return ""
+}
+
+// findEntryPoints returns each Test Configuration referenced from a Test Step, either as
+// `Config: r.basic(data)` or passed by reference e.g. `data.RequiresImportErrorStep(r.requiresImport)`
+func findEntryPoints(fileName string, file *ast.File) []configEntryPoint {
+	output := make([]configEntryPoint, 0)
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+
+		// track `r := SomeResource{}` so that `r.basic(data)` can be resolved
+		variables := make(map[string]string)
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			switch v := node.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range v.Lhs {
+					ident, ok := lhs.(*ast.Ident)
+					if !ok || i >= len(v.Rhs) {
+						continue
+					}
+					if lit, ok := v.Rhs[i].(*ast.CompositeLit); ok {
+						variables[ident.Name] = receiverTypeName(lit.Type)
+					}
+				}
+
+			case *ast.KeyValueExpr:
+				key, ok := v.Key.(*ast.Ident)
+				if !ok || key.Name != "Config" {
+					return true
+				}
+				if entryPoint := entryPointFromExpr(fileName, v.Value, variables); entryPoint != nil {
+					output = append(output, *entryPoint)
+				}
+
+			case *ast.CallExpr:
+				selector, ok := v.Fun.(*ast.SelectorExpr)
+				if !ok || selector.Sel.Name != "RequiresImportErrorStep" || len(v.Args) != 1 {
+					return true
+				}
+				if entryPoint := entryPointFromExpr(fileName, v.Args[0], variables); entryPoint != nil {
+					output = append(output, *entryPoint)
+				}
+			}
+			return true
+		})
+	}
+
+	return output
+}
+
+type importStepUsage struct {
+	// file is the name of the file containing the Tests for this Resource
+	file string
+
+	// found is whether any of the Tests for this Resource include an Import Step
+	found bool
+}
+
+// findImportSteps determines the Resource Type for each Test (from the call to `acceptance.BuildTestData`)
+// and whether any of the Test Steps for this Resource are an Import Step (e.g. `data.ImportStep()`)
+func findImportSteps(fileName string, file *ast.File, usages map[string]*importStepUsage) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "TestAcc") {
+			continue
+		}
+
+		resourceTypes := make([]string, 0)
+		hasImportStep := false
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			if strings.HasPrefix(selector.Sel.Name, "ImportStep") {
+				hasImportStep = true
+			}
+
+			if selector.Sel.Name == "BuildTestData" && len(call.Args) == 3 {
+				if lit, ok := call.Args[1].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if resourceType, err := strconv.Unquote(lit.Value); err == nil {
+						resourceTypes = append(resourceTypes, resourceType)
+					}
+				}
+			}
+			return true
+		})
+
+		for _, resourceType := range resourceTypes {
+			usage, ok := usages[resourceType]
+			if !ok {
+				usage = &importStepUsage{
+					file: fileName,
+				}
+				usages[resourceType] = usage
+			}
+			usage.found = usage.found || hasImportStep
+		}
+	}
+}
+
+func entryPointFromExpr(fileName string, expr ast.Expr, variables map[string]string) *configEntryPoint {
+	var selector *ast.SelectorExpr
+	var args []ast.Expr
+	switch v := expr.(type) {
+	case *ast.CallExpr:
+		s, ok := v.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+		selector = s
+		args = v.Args
+
+	case *ast.SelectorExpr:
+		// passed by reference, so it'll be called with the Test Data
+		selector = v
+		args = []ast.Expr{ast.NewIdent("data")}
+
+	default:
+		return nil
+	}
+
+	receiver := ""
+	switch x := selector.X.(type) {
+	case *ast.Ident:
+		receiver = variables[x.Name]
+	case *ast.CompositeLit:
+		receiver = receiverTypeName(x.Type)
+	}
+	if receiver == "" {
+		return nil
+	}
+
+	return &configEntryPoint{
+		file:     fileName,
+		receiver: receiver,
+		method:   selector.Sel.Name,
+		args:     args,
+	}
+}
+
+// renderer evaluates the (intentionally limited) subset of Go used within Test Configuration
+// functions - namely string literals, `fmt.Sprintf` and calls to other Test Configurations
+type renderer struct {
+	functions map[string]*ast.FuncDecl
+}
+
+func (r renderer) renderEntryPoint(entryPoint configEntryPoint) (string, error) {
+	// arguments from the Test Step can only be the Test Data or literal values
+	args := make([]interface{}, 0)
+	for _, arg := range entryPoint.args {
+		v, err := r.eval(arg, map[string]interface{}{"data": stubTestData}, "", "", 0)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, v)
+	}
+
+	v, err := r.call(entryPoint.receiver, entryPoint.method, args, 0)
+	if err != nil {
+		return "", err
+	}
+	config, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string but got %T", v)
+	}
+	return config, nil
+}
+
+func (r renderer) call(receiver, method string, args []interface{}, depth int) (interface{}, error) {
+	if depth > 20 {
+		return nil, fmt.Errorf("exceeded the maximum depth rendering %s", functionKey(receiver, method))
+	}
+
+	fn, ok := r.functions[functionKey(receiver, method)]
+	if !ok {
+		return nil, fmt.Errorf("function %s was not found within this package", functionKey(receiver, method))
+	}
+
+	variables := make(map[string]interface{})
+	paramIndex := 0
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			if paramIndex >= len(args) {
+				return nil, fmt.Errorf("%s: not enough arguments", functionKey(receiver, method))
+			}
+			variables[name.Name] = args[paramIndex]
+			paramIndex++
+		}
+	}
+
+	receiverName := ""
+	if names := fn.Recv.List[0].Names; len(names) == 1 {
+		receiverName = names[0].Name
+	}
+
+	for _, stmt := range fn.Body.List {
+		switch v := stmt.(type) {
+		case *ast.AssignStmt:
+			if len(v.Lhs) != len(v.Rhs) {
+				return nil, fmt.Errorf("%s: unsupported multi-value assignment", functionKey(receiver, method))
+			}
+			for i := range v.Lhs {
+				ident, ok := v.Lhs[i].(*ast.Ident)
+				if !ok {
+					return nil, fmt.Errorf("%s: unsupported assignment", functionKey(receiver, method))
+				}
+				value, err := r.eval(v.Rhs[i], variables, receiver, receiverName, depth)
+				if err != nil {
+					return nil, err
+				}
+				variables[ident.Name] = value
+			}
+
+		case *ast.ReturnStmt:
+			if len(v.Results) != 1 {
+				return nil, fmt.Errorf("%s: expected a single return value", functionKey(receiver, method))
+			}
+			return r.eval(v.Results[0], variables, receiver, receiverName, depth)
+
+		default:
+			return nil, fmt.Errorf("%s: unsupported statement %T", functionKey(receiver, method), stmt)
+		}
+	}
+
+	return nil, fmt.Errorf("%s: no return statement", functionKey(receiver, method))
+}
+
+func (r renderer) eval(expr ast.Expr, variables map[string]interface{}, receiver, receiverName string, depth int) (interface{}, error) {
+	evalAll := func(exprs []ast.Expr) ([]interface{}, error) {
+		output := make([]interface{}, 0)
+		for _, e := range exprs {
+			v, err := r.eval(e, variables, receiver, receiverName, depth)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, v)
+		}
+		return output, nil
+	}
+
+	switch v := expr.(type) {
+	case *ast.BasicLit:
+		switch v.Kind {
+		case token.STRING, token.CHAR:
+			return strconv.Unquote(v.Value)
+		case token.INT:
+			return strconv.Atoi(v.Value)
+		}
+		return nil, fmt.Errorf("unsupported literal %q", v.Value)
+
+	case *ast.ParenExpr:
+		return r.eval(v.X, variables, receiver, receiverName, depth)
+
+	case *ast.Ident:
+		switch v.Name {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		if value, ok := variables[v.Name]; ok {
+			return value, nil
+		}
+		return nil, fmt.Errorf("unknown variable %q", v.Name)
+
+	case *ast.BinaryExpr:
+		values, err := evalAll([]ast.Expr{v.X, v.Y})
+		if err != nil {
+			return nil, err
+		}
+		if v.Op != token.ADD {
+			return nil, fmt.Errorf("unsupported operator %q", v.Op)
+		}
+		switch left := values[0].(type) {
+		case string:
+			if right, ok := values[1].(string); ok {
+				return left + right, nil
+			}
+		case int:
+			if right, ok := values[1].(int); ok {
+				return left + right, nil
+			}
+		}
+		return nil, fmt.Errorf("unsupported addition of %T and %T", values[0], values[1])
+
+	case *ast.SelectorExpr:
+		parent, err := r.eval(v.X, variables, receiver, receiverName, depth)
+		if err != nil {
+			return nil, err
+		}
+		field := reflect.ValueOf(parent)
+		if field.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("unsupported selector %q on %T", v.Sel.Name, parent)
+		}
+		field = field.FieldByName(v.Sel.Name)
+		if !field.IsValid() || !field.CanInterface() {
+			return nil, fmt.Errorf("unsupported field %q", v.Sel.Name)
+		}
+		return field.Interface(), nil
+
+	case *ast.CallExpr:
+		selector, ok := v.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil, fmt.Errorf("unsupported function call")
+		}
+		args, err := evalAll(v.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		if pkg, ok := selector.X.(*ast.Ident); ok {
+			switch {
+			case pkg.Name == "fmt" && selector.Sel.Name == "Sprintf":
+				if len(args) == 0 {
+					return nil, fmt.Errorf("fmt.Sprintf requires a format")
+				}
+				format, ok := args[0].(string)
+				if !ok {
+					return nil, fmt.Errorf("fmt.Sprintf requires a string format")
+				}
+				return fmt.Sprintf(format, args[1:]...), nil
+
+			case pkg.Name == "strings" && selector.Sel.Name == "Repeat" && len(args) == 2:
+				count, ok := args[1].(int)
+				if !ok {
+					return nil, invalidCallError{message: fmt.Sprintf("strings.Repeat requires an integer count but got %T", args[1])}
+				}
+				if count < 0 {
+					return nil, invalidCallError{message: fmt.Sprintf("strings.Repeat requires a non-negative count but got %d", count)}
+				}
+				return strings.Repeat(fmt.Sprint(args[0]), count), nil
+
+			case pkg.Name == "strconv" && selector.Sel.Name == "Itoa" && len(args) == 1:
+				return fmt.Sprint(args[0]), nil
+
+			case pkg.Name == receiverName && receiverName != "":
+				return r.call(receiver, selector.Sel.Name, args, depth+1)
+			}
+
+			// calls to methods on the Test Data
+			if value, ok := variables[pkg.Name]; ok {
+				if data, ok := value.(acceptance.TestData); ok {
+					return callTestDataMethod(data, selector.Sel.Name, args)
+				}
+			}
+		}
+
+		// e.g. `StorageAccountResource{}.template(data)`
+		if lit, ok := selector.X.(*ast.CompositeLit); ok {
+			return r.call(receiverTypeName(lit.Type), selector.Sel.Name, args, depth+1)
+		}
+
+		return nil, fmt.Errorf("unsupported function call %q", selector.Sel.Name)
+	}
+
+	return nil, fmt.Errorf("unsupported expression %T", expr)
+}
+
+func callTestDataMethod(data acceptance.TestData, method string, args []interface{}) (interface{}, error) {
+	switch method {
+	case "RandomIntOfLength":
+		if len(args) == 1 {
+			if length, ok := args[0].(int); ok {
+				return data.RandomIntOfLength(length), nil
+			}
+		}
+
+	case "RandomStringOfLength":
+		// this is random in the Test Data, so must be stubbed to be deterministic
+		if len(args) == 1 {
+			if length, ok := args[0].(int); ok {
+				return strings.Repeat("s", length), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported Test Data method %q", method)
+}
+
+// lintConfig parses the rendered Terraform Configuration and returns a list of problems found
+func lintConfig(config string, schemas schemaLookup) []string {
+	file, diags := hclsyntax.ParseConfig([]byte(config), "config.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return []string{fmt.Sprintf("parsing rendered configuration: %s", diags.Error())}
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return []string{"parsing rendered configuration: unexpected body type"}
+	}
+
+	problems := make([]string, 0)
+	problems = append(problems, checkProviderBlock(body)...)
+	problems = append(problems, checkLocations(body)...)
+	problems = append(problems, checkReferences(body, schemas)...)
+	problems = append(problems, checkRequiredFields(body, schemas)...)
+	return problems
+}
+
+func checkProviderBlock(body *hclsyntax.Body) []string {
+	for _, block := range body.Blocks {
+		if block.Type != "provider" || len(block.Labels) != 1 || block.Labels[0] != "azurerm" {
+			continue
+		}
+
+		for _, nested := range block.Body.Blocks {
+			if nested.Type == "features" {
+				return nil
+			}
+		}
+		return []string{"the `provider \"azurerm\"` block is missing the `features {}` block"}
+	}
+
+	return []string{"no `provider \"azurerm\"` block was found"}
+}
+
+func checkLocations(body *hclsyntax.Body) []string {
+	allowed := map[string]struct{}{
+		stubTestData.Locations.Primary:   {},
+		stubTestData.Locations.Secondary: {},
+		stubTestData.Locations.Ternary:   {},
+	}
+
+	problems := make([]string, 0)
+	var walk func(path string, body *hclsyntax.Body)
+	walk = func(path string, body *hclsyntax.Body) {
+		for name, attr := range body.Attributes {
+			if name != "location" {
+				continue
+			}
+			// references to other resources (e.g. `azurerm_resource_group.test.location`) are fine
+			if len(attr.Expr.Variables()) > 0 {
+				continue
+			}
+			value, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || !value.Type().Equals(cty.String) || value.IsNull() {
+				continue
+			}
+			if _, ok := allowed[value.AsString()]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: hard-coded location %q - use `data.Locations` instead", path, value.AsString()))
+			}
+		}
+		for _, block := range body.Blocks {
+			walk(blockAddress(path, block), block.Body)
+		}
+	}
+	walk("", body)
+
+	sort.Strings(problems)
+	return problems
+}
+
+func checkReferences(body *hclsyntax.Body, schemas schemaLookup) []string {
+	declared := make(map[string]struct{})
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "resource":
+			if len(block.Labels) == 2 {
+				declared[fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])] = struct{}{}
+			}
+		case "data":
+			if len(block.Labels) == 2 {
+				declared[fmt.Sprintf("data.%s.%s", block.Labels[0], block.Labels[1])] = struct{}{}
+			}
+		case "variable", "module":
+			if len(block.Labels) == 1 {
+				prefix := "var"
+				if block.Type == "module" {
+					prefix = "module"
+				}
+				declared[fmt.Sprintf("%s.%s", prefix, block.Labels[0])] = struct{}{}
+			}
+		case "locals":
+			for name := range block.Body.Attributes {
+				declared[fmt.Sprintf("local.%s", name)] = struct{}{}
+			}
+		}
+	}
+
+	isResourceType := func(name string) bool {
+		if _, ok := schemas.resources[name]; ok {
+			return true
+		}
+		return strings.HasPrefix(name, "azurerm_") || strings.HasPrefix(name, "azuread_")
+	}
+
+	problems := make(map[string]struct{})
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			return nil
+		}
+
+		names := make([]string, 0)
+		for _, step := range expr.Traversal {
+			switch v := step.(type) {
+			case hcl.TraverseRoot:
+				names = append(names, v.Name)
+			case hcl.TraverseAttr:
+				names = append(names, v.Name)
+			}
+			if len(names) == 3 {
+				break
+			}
+		}
+		if len(names) < 2 {
+			return nil
+		}
+
+		address := ""
+		switch {
+		case names[0] == "data" && len(names) == 3:
+			address = strings.Join(names, ".")
+		case names[0] == "var" || names[0] == "local" || names[0] == "module":
+			address = strings.Join(names[0:2], ".")
+		case isResourceType(names[0]):
+			address = strings.Join(names[0:2], ".")
+		default:
+			return nil
+		}
+
+		if _, ok := declared[address]; !ok {
+			problems[fmt.Sprintf("reference to undeclared %q", address)] = struct{}{}
+		}
+		return nil
+	})
+
+	output := make([]string, 0)
+	for k := range problems {
+		output = append(output, k)
+	}
+	sort.Strings(output)
+	return output
+}
+
+func checkRequiredFields(body *hclsyntax.Body, schemas schemaLookup) []string {
+	problems := make([]string, 0)
+	for _, block := range body.Blocks {
+		if len(block.Labels) != 2 {
+			continue
+		}
+
+		var resourceSchema map[string]*schema.Schema
+		switch block.Type {
+		case "resource":
+			resourceSchema = schemas.resources[block.Labels[0]]
+		case "data":
+			resourceSchema = schemas.dataSources[block.Labels[0]]
+		}
+		if resourceSchema == nil {
+			continue
+		}
+
+		problems = append(problems, checkRequiredFieldsInBody(blockAddress("", block), block.Body, resourceSchema)...)
+	}
+
+	return problems
+}
+
+func checkRequiredFieldsInBody(path string, body *hclsyntax.Body, fields map[string]*schema.Schema) []string {
+	// `dynamic` blocks can't be evaluated - so assume they're set
+	dynamicBlocks := make(map[string]struct{})
+	nestedBlocks := make(map[string][]*hclsyntax.Block)
+	for _, block := range body.Blocks {
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			dynamicBlocks[block.Labels[0]] = struct{}{}
+			continue
+		}
+		nestedBlocks[block.Type] = append(nestedBlocks[block.Type], block)
+	}
+
+	names := make([]string, 0)
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	problems := make([]string, 0)
+	for _, name := range names {
+		field := fields[name]
+		_, isAttribute := body.Attributes[name]
+		_, isDynamic := dynamicBlocks[name]
+		blocks := nestedBlocks[name]
+
+		if field.Required && !isAttribute && !isDynamic && len(blocks) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: the required field %q is not set", path, name))
+			continue
+		}
+
+		nestedResource, ok := field.Elem.(*schema.Resource)
+		if !ok {
+			continue
+		}
+		for _, block := range blocks {
+			problems = append(problems, checkRequiredFieldsInBody(blockAddress(path, block), block.Body, nestedResource.Schema)...)
+		}
+	}
+
+	return problems
+}
+
+func blockAddress(parent string, block *hclsyntax.Block) string {
+	address := block.Type
+	if len(block.Labels) > 0 {
+		address = fmt.Sprintf("%s.%s", block.Type, strings.Join(block.Labels, "."))
+	}
+	if block.Type == "resource" && len(block.Labels) == 2 {
+		address = strings.Join(block.Labels, ".")
+	}
+
+	if parent == "" {
+		return address
+	}
+	return fmt.Sprintf("%s.%s", parent, address)
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func testSchemas() schemaLookup {
+	return schemaLookup{
+		dataSources: map[string]map[string]*schema.Schema{
+			"azurerm_resource_group": {
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+		resources: map[string]map[string]*schema.Schema{
+			"azurerm_resource_group": {
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"location": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+			"azurerm_example": {
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"resource_group_name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"rule": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"priority": {
+								Type:     schema.TypeInt,
+								Required: true,
+							},
+							"description": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		importable: map[string]struct{}{
+			"azurerm_example":        {},
+			"azurerm_resource_group": {},
+		},
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	testData := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name: "valid",
+			config: `
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-1"
+  location = "stublocationprimary"
+}
+
+resource "azurerm_example" "test" {
+  name                = "example"
+  resource_group_name = azurerm_resource_group.test.name
+
+  rule {
+    priority = 1
+  }
+
+  dynamic "rule" {
+    for_each = ["a"]
+    content {
+      description = rule.value
+    }
+  }
+}
+`,
+			expected: []string{},
+		},
+		{
+			name: "missing provider block",
+			config: `
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-1"
+  location = "stublocationprimary"
+}
+`,
+			expected: []string{
+				"no `provider \"azurerm\"` block was found",
+			},
+		},
+		{
+			name: "missing features block",
+			config: `
+provider "azurerm" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-1"
+  location = "stublocationprimary"
+}
+`,
+			expected: []string{
+				"the `provider \"azurerm\"` block is missing the `features {}` block",
+			},
+		},
+		{
+			name: "hard-coded location",
+			config: `
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-1"
+  location = "westeurope"
+}
+`,
+			expected: []string{
+				`azurerm_resource_group.test: hard-coded location "westeurope" - use ` + "`data.Locations`" + ` instead`,
+			},
+		},
+		{
+			name: "undeclared references",
+			config: `
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_example" "test" {
+  name                = data.azurerm_resource_group.other.name
+  resource_group_name = azurerm_resource_group.test.name
+}
+`,
+			expected: []string{
+				`reference to undeclared "azurerm_resource_group.test"`,
+				`reference to undeclared "data.azurerm_resource_group.other"`,
+			},
+		},
+		{
+			name: "missing required fields",
+			config: `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_resource_group" "test" {}
+
+resource "azurerm_example" "test" {
+  name = "example"
+
+  rule {
+    description = "hello"
+  }
+}
+`,
+			expected: []string{
+				`data.azurerm_resource_group.test: the required field "name" is not set`,
+				`azurerm_example.test: the required field "resource_group_name" is not set`,
+				`azurerm_example.test.rule: the required field "priority" is not set`,
+			},
+		},
+		{
+			name:   "invalid hcl",
+			config: `resource "azurerm_example" "test" {`,
+			expected: []string{
+				"parsing rendered configuration: config.tf:1,36-36: Argument or block definition required; An argument or block definition is required here.",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := lintConfig(v.config, testSchemas())
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("Expected %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	servicesPath, err := ioutil.TempDir("", "acceptance-config-linter")
+	if err != nil {
+		t.Fatalf("creating temp directory: %+v", err)
+	}
+	defer os.RemoveAll(servicesPath)
+
+	if err := os.Mkdir(filepath.Join(servicesPath, "example"), 0755); err != nil {
+		t.Fatalf("creating service directory: %+v", err)
+	}
+
+	contents := "package example_test\n" + `
+type ExampleResource struct{}
+
+func TestAccExample_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_example", "test")
+	r := ExampleResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+		{
+			Config: r.complete(data, "updated"),
+		},
+		{
+			Config: r.conditional(data),
+		},
+		{
+			Config: r.repeated(data),
+		},
+	})
+}
+
+func TestAccExample_import(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_example", "test")
+	r := ExampleResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+		},
+		data.ImportStepFromSchema(),
+	})
+}
+
+func TestAccResourceGroup_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	r := ExampleResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.template(data),
+		},
+	})
+}
+
+func (r ExampleResource) basic(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(` + "`" + `
+%s
+
+resource "azurerm_example" "test" {
+  name                = "acctest-%d"
+  resource_group_name = azurerm_resource_group.test.name
+}
+` + "`" + `, template, data.RandomInteger)
+}
+
+func (r ExampleResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+%s
+
+resource "azurerm_example" "import" {
+  name                = azurerm_example.test.name
+  resource_group_name = azurerm_example.test.resource_group_name
+}
+` + "`" + `, r.basic(data))
+}
+
+func (r ExampleResource) complete(data acceptance.TestData, description string) string {
+	return fmt.Sprintf(` + "`" + `
+%s
+
+resource "azurerm_example" "test" {
+  name = "acctest-%s-%s"
+}
+` + "`" + `, r.template(data), data.RandomStringOfLength(3), description)
+}
+
+func (r ExampleResource) conditional(data acceptance.TestData) string {
+	if data.RandomInteger > 0 {
+		return r.basic(data)
+	}
+	return r.template(data)
+}
+
+func (r ExampleResource) repeated(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+%s
+
+resource "azurerm_example" "test" {
+  name                = "acctest-%s"
+  resource_group_name = azurerm_resource_group.test.name
+}
+` + "`" + `, r.template(data), strings.Repeat("a", "3"))
+}
+
+func (ExampleResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+` + "`" + `, data.RandomInteger, data.Locations.Primary)
+}
+`
+	if err := ioutil.WriteFile(filepath.Join(servicesPath, "example", "example_resource_test.go"), []byte(contents), 0600); err != nil {
+		t.Fatalf("writing test file: %+v", err)
+	}
+
+	result, err := run(servicesPath, "", testSchemas())
+	if err != nil {
+		t.Fatalf("running: %+v", err)
+	}
+
+	if result.linted != 4 {
+		t.Fatalf("Expected 4 Test Configurations to be linted but got %d", result.linted)
+	}
+	if len(result.skipped) != 1 {
+		t.Fatalf("Expected 1 Test Configuration to be skipped but got %+v", result.skipped)
+	}
+
+	expected := []string{
+		`example/example_resource_test.go: ExampleResource.complete: azurerm_example.test: the required field "resource_group_name" is not set`,
+		`example/example_resource_test.go: ExampleResource.repeated: strings.Repeat requires an integer count but got string`,
+		`example/example_resource_test.go: azurerm_resource_group: this Resource supports Import but none of the Tests include an Import Step`,
+	}
+	actual := make([]string, 0)
+	for _, p := range result.problems {
+		actual = append(actual, p.String())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
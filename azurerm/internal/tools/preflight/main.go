@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/testclient"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/preflight"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/provider"
+)
+
+// plan is the subset of the JSON representation of a Terraform Plan (from `terraform show -json`) used here
+type plan struct {
+	ResourceChanges []resourceChange `json:"resource_changes"`
+}
+
+type resourceChange struct {
+	Mode   string `json:"mode"`
+	Type   string `json:"type"`
+	Change struct {
+		Actions []string `json:"actions"`
+	} `json:"change"`
+}
+
+func main() {
+	planFile := flag.String("plan", "", "The path to the JSON representation of a Terraform Plan, output from `terraform show -json`")
+	scope := flag.String("scope", "", "The ID of the Subscription or Resource Group where permissions should be checked, defaults to the Subscription")
+	showHelp := flag.Bool("help", false, "Display this message")
+
+	flag.Parse()
+
+	if *showHelp || *planFile == "" {
+		flag.Usage()
+		return
+	}
+
+	if err := run(*planFile, *scope); err != nil {
+		log.Printf("[ERROR] %+v", err)
+		os.Exit(1)
+	}
+}
+
+func run(planFile, scope string) error {
+	resourceTypes, err := resourceTypesFromPlan(planFile)
+	if err != nil {
+		return err
+	}
+
+	declared := provider.RequiredPermissions()
+	required := make(map[string][]string)
+	for _, resourceType := range resourceTypes {
+		actions, ok := declared[resourceType]
+		if !ok {
+			log.Printf("[WARN] The permissions required by %q aren't known - skipping", resourceType)
+			continue
+		}
+		required[resourceType] = actions
+	}
+
+	client, err := testclient.Build()
+	if err != nil {
+		return fmt.Errorf("building client: %+v", err)
+	}
+
+	if scope == "" {
+		scope = fmt.Sprintf("/subscriptions/%s", client.Account.SubscriptionId)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if err := preflight.NewChecker(client).Check(ctx, scope, required); err != nil {
+		return err
+	}
+
+	log.Printf("The permissions required by %d resource types are granted at the scope %q", len(required), scope)
+	return nil
+}
+
+// resourceTypesFromPlan returns the types of the Resources which are created or updated by the Plan
+func resourceTypesFromPlan(planFile string) ([]string, error) {
+	contents, err := ioutil.ReadFile(planFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %+v", planFile, err)
+	}
+
+	var p plan
+	if err := json.Unmarshal(contents, &p); err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", planFile, err)
+	}
+
+	types := make(map[string]struct{})
+	for _, change := range p.ResourceChanges {
+		if change.Mode != "managed" {
+			continue
+		}
+		for _, action := range change.Change.Actions {
+			if action == "create" || action == "update" {
+				types[change.Type] = struct{}{}
+			}
+		}
+	}
+
+	output := make([]string, 0)
+	for k := range types {
+		output = append(output, k)
+	}
+	sort.Strings(output)
+	return output, nil
+}
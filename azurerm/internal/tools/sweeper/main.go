@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance/testclient"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/sweep"
+)
+
+func main() {
+	olderThan := flag.Duration("older-than", 24*time.Hour, "The minimum age of a resource for it to be swept")
+	dryRun := flag.Bool("dry-run", false, "Output the resources which would be swept, without deleting them")
+	serviceName := flag.String("service", "", "The name of the Service (e.g. KeyVault) whose resources should be swept, defaults to all Services")
+	showHelp := flag.Bool("help", false, "Display this message")
+
+	flag.Parse()
+
+	if *showHelp {
+		flag.Usage()
+		return
+	}
+
+	if err := run(*serviceName, *olderThan, *dryRun); err != nil {
+		log.Printf("[ERROR] %+v", err)
+		os.Exit(1)
+	}
+}
+
+func run(serviceName string, olderThan time.Duration, dryRun bool) error {
+	sweepers, err := sweep.Sweepers(serviceName)
+	if err != nil {
+		return err
+	}
+
+	client, err := testclient.Build()
+	if err != nil {
+		return fmt.Errorf("building client: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Hour)
+	defer cancel()
+
+	result := sweep.Run(ctx, client, sweepers, sweep.Options{
+		OlderThan: olderThan,
+		DryRun:    dryRun,
+	})
+
+	for _, v := range result.Swept {
+		action := "Swept"
+		if dryRun {
+			action = "Would sweep"
+		}
+		log.Printf("%s %s %q (%s old)", action, v.Sweeper, v.Resource.ID, v.Age.Round(time.Minute))
+	}
+	log.Printf("%d resources swept", len(result.Swept))
+
+	for _, err := range result.Errors {
+		log.Printf("[ERROR] %+v", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d errors were encountered whilst sweeping", len(result.Errors))
+	}
+
+	return nil
+}